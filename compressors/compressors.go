@@ -0,0 +1,44 @@
+package compressors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrMismatchVectorsAndDocuments is returned when an embedder returns a
+// different number of vectors than documents given to it.
+var ErrMismatchVectorsAndDocuments = errors.New("number of vectors and documents does not match")
+
+// Compressor is the interface for compressing documents retrieved for a query.
+// Compressors may drop documents, shorten their content or both.
+type Compressor interface {
+	CompressDocuments(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// Pipeline is a compressor applying its compressors in order. It stops early
+// when no documents are left.
+type Pipeline []Compressor
+
+var _ Compressor = Pipeline{}
+
+// NewPipeline creates a new pipeline of the compressors.
+func NewPipeline(compressors ...Compressor) Pipeline {
+	return Pipeline(compressors)
+}
+
+// CompressDocuments compresses the documents with every compressor of the pipeline.
+func (p Pipeline) CompressDocuments(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	var err error
+	for _, compressor := range p {
+		if len(docs) == 0 {
+			break
+		}
+		docs, err = compressor.CompressDocuments(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,146 @@
+package compressors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// funcLanguageModel answers prompts with a function of the prompt.
+type funcLanguageModel func(prompt string) string
+
+func (l funcLanguageModel) GeneratePrompt(_ context.Context, promptValues []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.LLMResult{
+		Generations: [][]*llms.Generation{{&llms.Generation{
+			Text: l(promptValues[0].String()),
+		}}},
+	}, nil
+}
+
+func (l funcLanguageModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+// keywordEmbedder embeds texts as counts of a few keywords.
+type keywordEmbedder struct{}
+
+func (keywordEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vector, err := keywordEmbedder{}.EmbedQuery(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+func (keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	vector := make([]float64, 0, 3)
+	for _, keyword := range []string{"年假", "病假", "加班"} {
+		vector = append(vector, float64(strings.Count(text, keyword)))
+	}
+	return vector, nil
+}
+
+func docs(contents ...string) []schema.Document {
+	docs := make([]schema.Document, 0, len(contents))
+	for _, content := range contents {
+		docs = append(docs, schema.Document{PageContent: content, Metadata: map[string]any{"source": content}})
+	}
+	return docs
+}
+
+func contents(docs []schema.Document) []string {
+	contents := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		contents = append(contents, doc.PageContent)
+	}
+	return contents
+}
+
+func TestLLMExtractor(t *testing.T) {
+	t.Parallel()
+
+	llm := funcLanguageModel(func(prompt string) string {
+		if strings.Contains(prompt, "年假五天。病假十天。") {
+			return " 年假五天。 "
+		}
+		return NoOutput
+	})
+
+	compressed, err := NewLLMExtractor(llm).CompressDocuments(context.Background(), "年假", docs("年假五天。病假十天。", "加班调休。"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假五天。"}, contents(compressed))
+	assert.Equal(t, "年假五天。病假十天。", compressed[0].Metadata["source"])
+}
+
+func TestLLMFilter(t *testing.T) {
+	t.Parallel()
+
+	llm := funcLanguageModel(func(prompt string) string {
+		if strings.Contains(prompt, "Context:\n>>>\n病假") {
+			return "No."
+		}
+		return "yes, it is relevant"
+	})
+
+	filtered, err := NewLLMFilter(llm).CompressDocuments(context.Background(), "年假", docs("年假五天", "病假十天", "年假规定"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假五天", "年假规定"}, contents(filtered))
+}
+
+func TestEmbeddingsFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	input := docs("病假", "年假病假", "年假年假", "加班")
+
+	filtered, err := NewEmbeddingsFilter(keywordEmbedder{}, 0.5).CompressDocuments(ctx, "年假", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假病假", "年假年假"}, contents(filtered))
+	assert.InDelta(t, 0.7071, filtered[0].Metadata[SimilarityScoreKey], 1e-4)
+	assert.NotContains(t, input[1].Metadata, SimilarityScoreKey)
+
+	filtered, err = NewEmbeddingsFilter(keywordEmbedder{}, 0.5, WithTopK(1)).CompressDocuments(ctx, "年假", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假年假"}, contents(filtered))
+}
+
+func TestRedundancyFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	input := docs("年假", "年假年假", "年假病假", "病假")
+
+	filtered, err := NewRedundancyFilter(keywordEmbedder{}).CompressDocuments(ctx, "", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假", "年假病假", "病假"}, contents(filtered))
+
+	filtered, err = NewRedundancyFilter(keywordEmbedder{}, WithRedundancyThreshold(0.7)).CompressDocuments(ctx, "", input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假", "病假"}, contents(filtered))
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	llm := funcLanguageModel(func(string) string { return "YES" })
+	pipeline := NewPipeline(
+		NewEmbeddingsFilter(keywordEmbedder{}, 0.5),
+		NewRedundancyFilter(keywordEmbedder{}),
+		NewLLMFilter(llm),
+	)
+
+	compressed, err := pipeline.CompressDocuments(context.Background(), "年假", docs("年假", "年假年假", "加班"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假"}, contents(compressed))
+
+	compressed, err = pipeline.CompressDocuments(context.Background(), "病假", docs("加班"))
+	require.NoError(t, err)
+	assert.Empty(t, compressed)
+}
@@ -0,0 +1,21 @@
+/*
+Package compressors contains the Compressor interface, an interface for
+shortening or filtering retrieved documents with respect to a query, and its
+implementations.
+
+The main components of this package are:
+
+- Compressor interface: a common interface for compressing documents.
+- Pipeline: a compressor applying several compressors in order.
+- LLMExtractor: a compressor using an LLM to keep only the relevant parts of
+each document.
+- LLMFilter: a compressor using an LLM to drop documents that are not relevant.
+- EmbeddingsFilter: a compressor dropping documents whose embeddings are not
+similar enough to the query.
+- RedundancyFilter: a compressor dropping documents whose embeddings are too
+similar to an earlier document.
+
+Use retrievers.NewContextualCompression to apply a compressor to the results
+of any schema.Retriever.
+*/
+package compressors
@@ -0,0 +1,117 @@
+package compressors
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// SimilarityScoreKey is the metadata key the embeddings filter stores the
+// similarity of a document to the query under.
+const SimilarityScoreKey = "query_similarity_score"
+
+// EmbeddingsFilter is a compressor dropping documents whose embeddings have a
+// cosine similarity to the query embedding below a threshold.
+type EmbeddingsFilter struct {
+	embedder  embeddings.Embedder
+	threshold float64
+	topK      int
+}
+
+var _ Compressor = EmbeddingsFilter{}
+
+// EmbeddingsFilterOption is a function type that can be used to modify the embeddings filter.
+type EmbeddingsFilterOption func(*EmbeddingsFilter)
+
+// WithTopK is an option for keeping at most k documents, sorted by similarity.
+// By default all documents above the threshold are kept in their original order.
+func WithTopK(k int) EmbeddingsFilterOption {
+	return func(f *EmbeddingsFilter) {
+		f.topK = k
+	}
+}
+
+// NewEmbeddingsFilter creates a new embeddings filter keeping documents with a
+// similarity to the query of at least threshold.
+func NewEmbeddingsFilter(
+	embedder embeddings.Embedder,
+	threshold float64,
+	opts ...EmbeddingsFilterOption,
+) EmbeddingsFilter {
+	f := EmbeddingsFilter{
+		embedder:  embedder,
+		threshold: threshold,
+	}
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+// CompressDocuments returns the documents similar enough to the query.
+func (f EmbeddingsFilter) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	queryVector, err := f.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := embedDocuments(ctx, f.embedder, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		similarity, err := embeddings.CosineSimilarity(queryVector, vectors[i])
+		if err != nil {
+			return nil, err
+		}
+		if similarity < f.threshold {
+			continue
+		}
+
+		metadata := make(map[string]any, len(doc.Metadata)+1)
+		for key, value := range doc.Metadata {
+			metadata[key] = value
+		}
+		metadata[SimilarityScoreKey] = similarity
+		filtered = append(filtered, schema.Document{PageContent: doc.PageContent, Metadata: metadata})
+	}
+
+	if f.topK > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Metadata[SimilarityScoreKey].(float64) > //nolint:forcetypeassert
+				filtered[j].Metadata[SimilarityScoreKey].(float64)
+		})
+		if len(filtered) > f.topK {
+			filtered = filtered[:f.topK]
+		}
+	}
+
+	return filtered, nil
+}
+
+func embedDocuments(ctx context.Context, embedder embeddings.Embedder, docs []schema.Document) ([][]float64, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrMismatchVectorsAndDocuments
+	}
+	return vectors, nil
+}
@@ -0,0 +1,88 @@
+package compressors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	// NoOutput is the answer the LLM extractor prompt asks for when no part of
+	// a document is relevant.
+	NoOutput = "NO_OUTPUT"
+
+	_defaultExtractorTemplate = `Given the following question and context, extract any part of the context *AS IS* that is relevant to answer the question. Do not change the extracted sentences. If none of the context is relevant return ` + NoOutput + `.
+
+> Question: {{.question}}
+> Context:
+>>>
+{{.context}}
+>>>
+Extracted relevant parts:`
+)
+
+// LLMExtractor is a compressor using an LLM to extract the sentences of each
+// document that are relevant to the query. Documents without relevant
+// sentences are dropped.
+type LLMExtractor struct {
+	chain *chains.LLMChain
+}
+
+var _ Compressor = LLMExtractor{}
+
+// LLMExtractorOption is a function type that can be used to modify the LLM extractor.
+type LLMExtractorOption func(*LLMExtractor)
+
+// WithExtractorPrompt is an option for setting the extraction prompt. The
+// prompt receives the variables "question" and "context" and must make the LLM
+// answer NoOutput if nothing is relevant.
+func WithExtractorPrompt(prompt prompts.PromptTemplate) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		e.chain.Prompt = prompt
+	}
+}
+
+// NewLLMExtractor creates a new LLM extractor using the llm.
+func NewLLMExtractor(llm llms.LanguageModel, opts ...LLMExtractorOption) LLMExtractor {
+	e := LLMExtractor{
+		chain: chains.NewLLMChain(
+			llm,
+			prompts.NewPromptTemplate(_defaultExtractorTemplate, []string{"question", "context"}),
+		),
+	}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e
+}
+
+// CompressDocuments replaces the content of each document with its relevant
+// parts and drops the documents without relevant parts.
+func (e LLMExtractor) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	compressed := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		output, err := chains.Predict(ctx, e.chain, map[string]any{
+			"question": query,
+			"context":  doc.PageContent,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		output = strings.TrimSpace(output)
+		if output == "" || strings.Contains(output, NoOutput) {
+			continue
+		}
+
+		compressed = append(compressed, schema.Document{PageContent: output, Metadata: doc.Metadata})
+	}
+	return compressed, nil
+}
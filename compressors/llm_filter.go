@@ -0,0 +1,81 @@
+package compressors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _defaultFilterTemplate = `Given the following question and context, return YES if the context is relevant to the question and NO if it isn't.
+
+> Question: {{.question}}
+> Context:
+>>>
+{{.context}}
+>>>
+> Relevant (YES / NO):`
+
+// LLMFilter is a compressor asking an LLM whether each document is relevant to
+// the query and dropping the documents it answers no for. The content of the
+// kept documents is not changed.
+type LLMFilter struct {
+	chain *chains.LLMChain
+}
+
+var _ Compressor = LLMFilter{}
+
+// LLMFilterOption is a function type that can be used to modify the LLM filter.
+type LLMFilterOption func(*LLMFilter)
+
+// WithFilterPrompt is an option for setting the filter prompt. The prompt
+// receives the variables "question" and "context" and must make the LLM answer
+// YES or NO.
+func WithFilterPrompt(prompt prompts.PromptTemplate) LLMFilterOption {
+	return func(f *LLMFilter) {
+		f.chain.Prompt = prompt
+	}
+}
+
+// NewLLMFilter creates a new LLM filter using the llm.
+func NewLLMFilter(llm llms.LanguageModel, opts ...LLMFilterOption) LLMFilter {
+	f := LLMFilter{
+		chain: chains.NewLLMChain(
+			llm,
+			prompts.NewPromptTemplate(_defaultFilterTemplate, []string{"question", "context"}),
+		),
+	}
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+// CompressDocuments returns the documents the LLM considers relevant.
+func (f LLMFilter) CompressDocuments(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	filtered := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		output, err := chains.Predict(ctx, f.chain, map[string]any{
+			"question": query,
+			"context":  doc.PageContent,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if isYes(output) {
+			filtered = append(filtered, doc)
+		}
+	}
+	return filtered, nil
+}
+
+// isYes reports whether an answer of the LLM is affirmative. LLMs often add
+// punctuation or explanations after the answer, so only the start is checked.
+func isYes(output string) bool {
+	output = strings.ToUpper(strings.TrimSpace(output))
+	return strings.HasPrefix(output, "YES") || strings.HasPrefix(output, "是")
+}
@@ -0,0 +1,83 @@
+package compressors
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _defaultRedundancyThreshold = 0.95
+
+// RedundancyFilter is a compressor dropping documents whose embeddings are
+// nearly the same as the embedding of an earlier document. Retrievers return
+// the most relevant documents first, so the first of several duplicates is kept.
+type RedundancyFilter struct {
+	embedder  embeddings.Embedder
+	threshold float64
+}
+
+var _ Compressor = RedundancyFilter{}
+
+// RedundancyFilterOption is a function type that can be used to modify the redundancy filter.
+type RedundancyFilterOption func(*RedundancyFilter)
+
+// WithRedundancyThreshold is an option for setting the cosine similarity from
+// which two documents are considered redundant. Defaults to 0.95.
+func WithRedundancyThreshold(threshold float64) RedundancyFilterOption {
+	return func(f *RedundancyFilter) {
+		f.threshold = threshold
+	}
+}
+
+// NewRedundancyFilter creates a new redundancy filter using the embedder.
+func NewRedundancyFilter(embedder embeddings.Embedder, opts ...RedundancyFilterOption) RedundancyFilter {
+	f := RedundancyFilter{
+		embedder:  embedder,
+		threshold: _defaultRedundancyThreshold,
+	}
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+// CompressDocuments returns the documents that are not redundant.
+func (f RedundancyFilter) CompressDocuments(
+	ctx context.Context,
+	_ string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	if len(docs) < 2 { //nolint:gomnd
+		return docs, nil
+	}
+
+	vectors, err := embedDocuments(ctx, f.embedder, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]int, 0, len(docs))
+	for i := range docs {
+		redundant := false
+		for _, j := range kept {
+			similarity, err := embeddings.CosineSimilarity(vectors[i], vectors[j])
+			if err != nil {
+				return nil, err
+			}
+			if similarity >= f.threshold {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			kept = append(kept, i)
+		}
+	}
+
+	filtered := make([]schema.Document, 0, len(kept))
+	for _, i := range kept {
+		filtered = append(filtered, docs[i])
+	}
+	return filtered, nil
+}
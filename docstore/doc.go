@@ -0,0 +1,16 @@
+/*
+Package docstore contains the DocStore interface for storing documents by key
+and its implementations.
+
+The main components of this package are:
+
+- DocStore interface: a common interface for getting, setting and deleting
+documents by key.
+- InMemory: a docstore keeping the documents in a map.
+- File: a docstore keeping each document as a JSON file in a directory.
+- Redis: a docstore keeping the documents as JSON values in Redis.
+
+Docstores are used by retrievers that search small chunks of a document but
+return a larger one, like the multivector retriever.
+*/
+package docstore
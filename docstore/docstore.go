@@ -0,0 +1,38 @@
+package docstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrMismatchKeysAndDocuments is returned when the number of keys and
+// documents given to MSet does not match.
+var ErrMismatchKeysAndDocuments = errors.New("number of keys and documents does not match")
+
+// DocStore is the interface for storing documents by key.
+type DocStore interface {
+	// MGet returns the documents stored under the keys. Keys without a
+	// document are missing from the returned map.
+	MGet(ctx context.Context, keys []string) (map[string]schema.Document, error)
+	// MSet stores the documents under the keys, replacing existing documents.
+	MSet(ctx context.Context, keys []string, docs []schema.Document) error
+	// MDelete deletes the documents stored under the keys.
+	MDelete(ctx context.Context, keys []string) error
+}
+
+func checkKeysAndDocuments(keys []string, docs []schema.Document) error {
+	if len(keys) != len(docs) {
+		return ErrMismatchKeysAndDocuments
+	}
+	return nil
+}
+
+func copyDocument(doc schema.Document) schema.Document {
+	metadata := make(map[string]any, len(doc.Metadata))
+	for key, value := range doc.Metadata {
+		metadata[key] = value
+	}
+	return schema.Document{PageContent: doc.PageContent, Metadata: metadata}
+}
@@ -0,0 +1,69 @@
+package docstore
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func testDocStore(t *testing.T, store DocStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	keys := []string{"a", "b/../c", "中文"}
+	err := store.MSet(ctx, keys, []schema.Document{
+		{PageContent: "doc a", Metadata: map[string]any{"source": "a.txt"}},
+		{PageContent: "doc b"},
+		{PageContent: "文档"},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.MGet(ctx, append(keys, "missing"))
+	require.NoError(t, err)
+	assert.Len(t, docs, 3)
+	assert.Equal(t, "doc a", docs["a"].PageContent)
+	assert.Equal(t, "a.txt", docs["a"].Metadata["source"])
+	assert.Equal(t, "doc b", docs["b/../c"].PageContent)
+	assert.Equal(t, "文档", docs["中文"].PageContent)
+
+	require.NoError(t, store.MSet(ctx, []string{"a"}, []schema.Document{{PageContent: "new a"}}))
+	require.NoError(t, store.MDelete(ctx, []string{"b/../c", "missing"}))
+
+	docs, err = store.MGet(ctx, keys)
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "new a", docs["a"].PageContent)
+
+	err = store.MSet(ctx, []string{"a", "b"}, []schema.Document{{PageContent: "a"}})
+	require.ErrorIs(t, err, ErrMismatchKeysAndDocuments)
+}
+
+func TestInMemory(t *testing.T) {
+	t.Parallel()
+	testDocStore(t, NewInMemory())
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+	store, err := NewFile(t.TempDir())
+	require.NoError(t, err)
+	testDocStore(t, store)
+}
+
+func TestRedis(t *testing.T) {
+	t.Parallel()
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		t.Skip("REDIS_URL not set")
+	}
+	opts, err := redis.ParseURL(url)
+	require.NoError(t, err)
+
+	testDocStore(t, NewRedis(redis.NewClient(opts), WithKeyPrefix(uuid.NewString()+":")))
+}
@@ -0,0 +1,98 @@
+package docstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// File is a docstore keeping each document as a JSON file in a directory.
+// File names are the base64 encoded keys, so any string can be used as key.
+type File struct {
+	dir string
+}
+
+var _ DocStore = File{}
+
+// NewFile creates a new file docstore in the directory, creating the
+// directory if it does not exist.
+func NewFile(dir string) (File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+		return File{}, err
+	}
+	return File{dir: dir}, nil
+}
+
+// MGet returns the documents stored under the keys.
+func (s File) MGet(_ context.Context, keys []string) (map[string]schema.Document, error) {
+	docs := make(map[string]schema.Document, len(keys))
+	for _, key := range keys {
+		data, err := os.ReadFile(s.path(key))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc schema.Document
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		docs[key] = doc
+	}
+	return docs, nil
+}
+
+// MSet stores the documents under the keys. Each document is written to a
+// temporary file first and renamed, so readers never see partial documents.
+func (s File) MSet(_ context.Context, keys []string, docs []schema.Document) error {
+	if err := checkKeysAndDocuments(keys, docs); err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		data, err := json.Marshal(docs[i])
+		if err != nil {
+			return err
+		}
+
+		tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+	return nil
+}
+
+// MDelete deletes the documents stored under the keys.
+func (s File) MDelete(_ context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s File) path(key string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(key))+".json")
+}
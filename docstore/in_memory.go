@@ -0,0 +1,62 @@
+package docstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// InMemory is a docstore keeping the documents in a map. It is safe for
+// concurrent use.
+type InMemory struct {
+	mu   sync.RWMutex
+	docs map[string]schema.Document
+}
+
+var _ DocStore = &InMemory{}
+
+// NewInMemory creates a new empty in memory docstore.
+func NewInMemory() *InMemory {
+	return &InMemory{docs: make(map[string]schema.Document)}
+}
+
+// MGet returns the documents stored under the keys.
+func (s *InMemory) MGet(_ context.Context, keys []string) (map[string]schema.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make(map[string]schema.Document, len(keys))
+	for _, key := range keys {
+		if doc, ok := s.docs[key]; ok {
+			docs[key] = copyDocument(doc)
+		}
+	}
+	return docs, nil
+}
+
+// MSet stores the documents under the keys.
+func (s *InMemory) MSet(_ context.Context, keys []string, docs []schema.Document) error {
+	if err := checkKeysAndDocuments(keys, docs); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, key := range keys {
+		s.docs[key] = copyDocument(docs[i])
+	}
+	return nil
+}
+
+// MDelete deletes the documents stored under the keys.
+func (s *InMemory) MDelete(_ context.Context, keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.docs, key)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package docstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _defaultRedisKeyPrefix = "docstore:"
+
+// Redis is a docstore keeping the documents as JSON values in Redis.
+type Redis struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+var _ DocStore = Redis{}
+
+// RedisOption is a function type that can be used to modify the Redis docstore.
+type RedisOption func(*Redis)
+
+// WithKeyPrefix is an option for setting the prefix added to all keys in
+// Redis. Defaults to "docstore:".
+func WithKeyPrefix(keyPrefix string) RedisOption {
+	return func(r *Redis) {
+		r.keyPrefix = keyPrefix
+	}
+}
+
+// WithTTL is an option for setting the expiration of stored documents. By
+// default documents do not expire.
+func WithTTL(ttl time.Duration) RedisOption {
+	return func(r *Redis) {
+		r.ttl = ttl
+	}
+}
+
+// NewRedis creates a new Redis docstore using the client.
+func NewRedis(client redis.UniversalClient, opts ...RedisOption) Redis {
+	r := Redis{
+		client:    client,
+		keyPrefix: _defaultRedisKeyPrefix,
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// MGet returns the documents stored under the keys.
+func (s Redis) MGet(ctx context.Context, keys []string) (map[string]schema.Document, error) {
+	docs := make(map[string]schema.Document, len(keys))
+	if len(keys) == 0 {
+		return docs, nil
+	}
+
+	values, err := s.client.MGet(ctx, s.redisKeys(keys)...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var doc schema.Document
+		if err := json.Unmarshal([]byte(data), &doc); err != nil {
+			return nil, err
+		}
+		docs[keys[i]] = doc
+	}
+	return docs, nil
+}
+
+// MSet stores the documents under the keys.
+func (s Redis) MSet(ctx context.Context, keys []string, docs []schema.Document) error {
+	if err := checkKeysAndDocuments(keys, docs); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := s.client.Pipeline()
+	for i, key := range keys {
+		data, err := json.Marshal(docs[i])
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, s.keyPrefix+key, data, s.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// MDelete deletes the documents stored under the keys.
+func (s Redis) MDelete(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.client.Del(ctx, s.redisKeys(keys)...).Err()
+}
+
+func (s Redis) redisKeys(keys []string) []string {
+	redisKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		redisKeys = append(redisKeys, s.keyPrefix+key)
+	}
+	return redisKeys
+}
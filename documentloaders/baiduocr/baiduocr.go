@@ -0,0 +1,273 @@
+package baiduocr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/documentloaders"
+)
+
+const (
+	// DefaultBaseURL is the base URL of the Baidu AI APIs.
+	DefaultBaseURL = "https://aip.baidubce.com"
+	// EndpointAccurateBasic is the high accuracy text recognition endpoint.
+	EndpointAccurateBasic = "accurate_basic"
+	// EndpointGeneralBasic is the standard text recognition endpoint.
+	EndpointGeneralBasic = "general_basic"
+
+	// _tokenCacheMargin is how long before it expires, in seconds, a cached
+	// access token is dropped. Access tokens are valid for 30 days.
+	_tokenCacheMargin = 3600 * 24 * 2
+)
+
+var (
+	ErrNotSetAuth      = errors.New("both accessToken and apiKey secretKey are not set")
+	ErrAccessTokenCode = errors.New("get access_token API returned unexpected status code")
+	ErrOCRCode         = errors.New("ocr API returned unexpected status code")
+)
+
+// Error codes of invalid and expired access tokens.
+var _tokenErrorCodes = map[int]bool{110: true, 111: true} //nolint:gochecknoglobals
+
+// Cache caches the access token, keyed by the api key.
+type Cache interface {
+	Set(key string, value string, second int) error
+	Get(key string) (string, error)
+}
+
+// Doer performs a HTTP request.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// OCR recognizes text with the Baidu OCR API.
+type OCR struct {
+	apiKey     string
+	secretKey  string
+	baseURL    string
+	endpoint   string
+	httpClient Doer
+	cache      Cache
+
+	mu          sync.Mutex
+	accessToken string
+}
+
+var _ documentloaders.OCR = (*OCR)(nil)
+
+// Option is an option for the Baidu OCR.
+type Option func(*OCR)
+
+// WithAKSK sets the api key and the secret key used to get access tokens.
+func WithAKSK(apiKey, secretKey string) Option {
+	return func(o *OCR) {
+		o.apiKey = apiKey
+		o.secretKey = secretKey
+	}
+}
+
+// WithAccessToken sets a fixed access token, usually for development.
+func WithAccessToken(accessToken string) Option {
+	return func(o *OCR) {
+		o.accessToken = accessToken
+	}
+}
+
+// WithCache sets the cache of the access token.
+func WithCache(cache Cache) Option {
+	return func(o *OCR) {
+		o.cache = cache
+	}
+}
+
+// WithEndpoint sets the recognition endpoint, EndpointAccurateBasic by default.
+func WithEndpoint(endpoint string) Option {
+	return func(o *OCR) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithBaseURL sets the base URL of the API.
+func WithBaseURL(baseURL string) Option {
+	return func(o *OCR) {
+		o.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client.
+func WithHTTPClient(client Doer) Option {
+	return func(o *OCR) {
+		o.httpClient = client
+	}
+}
+
+// New returns a new Baidu OCR. The access token is requested on first use.
+func New(opts ...Option) (*OCR, error) {
+	o := &OCR{
+		baseURL:    DefaultBaseURL,
+		endpoint:   EndpointAccurateBasic,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.accessToken == "" && (o.apiKey == "" || o.secretKey == "") {
+		return nil, ErrNotSetAuth
+	}
+	return o, nil
+}
+
+// RecognizePDFPage returns the text of the page, numbered from 1, of the pdf
+// file, one line per recognized line. The API recognizes one page per request
+// but takes the whole file, so every call uploads the whole file base64
+// encoded.
+func (o *OCR) RecognizePDFPage(ctx context.Context, pdf []byte, page int) (string, error) {
+	return o.recognize(ctx, url.Values{
+		"pdf_file":     {base64.StdEncoding.EncodeToString(pdf)},
+		"pdf_file_num": {strconv.Itoa(page)},
+	})
+}
+
+// RecognizeImage returns the text of a jpg, png or bmp image, one line per
+// recognized line.
+func (o *OCR) RecognizeImage(ctx context.Context, image []byte) (string, error) {
+	return o.recognize(ctx, url.Values{
+		"image": {base64.StdEncoding.EncodeToString(image)},
+	})
+}
+
+type ocrResponse struct {
+	ErrorCode   int    `json:"error_code"`
+	ErrorMsg    string `json:"error_msg"`
+	WordsResult []struct {
+		Words string `json:"words"`
+	} `json:"words_result"`
+}
+
+func (o *OCR) recognize(ctx context.Context, form url.Values) (string, error) {
+	response, err := o.doRecognize(ctx, form)
+	if err == nil && _tokenErrorCodes[response.ErrorCode] && o.apiKey != "" {
+		// The access token expired before the cache did, get a new one.
+		o.resetAccessToken()
+		response, err = o.doRecognize(ctx, form)
+	}
+	if err != nil {
+		return "", err
+	}
+	if response.ErrorCode != 0 {
+		return "", fmt.Errorf("%w: %d %s", ErrOCRCode, response.ErrorCode, response.ErrorMsg)
+	}
+
+	lines := make([]string, 0, len(response.WordsResult))
+	for _, w := range response.WordsResult {
+		lines = append(lines, w.Words)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (o *OCR) doRecognize(ctx context.Context, form url.Values) (*ocrResponse, error) {
+	token, err := o.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u := o.baseURL + "/rest/2.0/ocr/v1/" + o.endpoint + "?access_token=" + url.QueryEscape(token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrOCRCode, resp.StatusCode)
+	}
+
+	var response ocrResponse
+	return &response, json.NewDecoder(resp.Body).Decode(&response)
+}
+
+type authResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// getAccessToken returns the access token, from the cache if possible.
+func (o *OCR) getAccessToken(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.accessToken != "" {
+		return o.accessToken, nil
+	}
+
+	key := o.cacheKey()
+	if o.cache != nil {
+		if token, err := o.cache.Get(key); err == nil && token != "" {
+			o.accessToken = token
+			return token, nil
+		}
+	}
+
+	u := fmt.Sprintf("%s/oauth/2.0/token?grant_type=client_credentials&client_id=%s&client_secret=%s",
+		o.baseURL, url.QueryEscape(o.apiKey), url.QueryEscape(o.secretKey))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %d", ErrAccessTokenCode, resp.StatusCode)
+	}
+
+	var response authResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+	if response.AccessToken == "" {
+		return "", fmt.Errorf("%w: %s %s", ErrAccessTokenCode, response.Error, response.ErrorDescription)
+	}
+
+	o.accessToken = response.AccessToken
+	if o.cache != nil && response.ExpiresIn > _tokenCacheMargin {
+		// A failure to cache only costs another token request.
+		_ = o.cache.Set(key, response.AccessToken, response.ExpiresIn-_tokenCacheMargin)
+	}
+	return o.accessToken, nil
+}
+
+func (o *OCR) resetAccessToken() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.accessToken = ""
+	if o.cache != nil {
+		_ = o.cache.Set(o.cacheKey(), "", 1)
+	}
+}
+
+func (o *OCR) cacheKey() string {
+	return "langchain:baiduocr:" + o.apiKey
+}
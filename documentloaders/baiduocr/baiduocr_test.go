@@ -0,0 +1,91 @@
+package baiduocr
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapCache struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (c *mapCache) Set(key string, value string, _ int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *mapCache) Get(key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[key], nil
+}
+
+func TestRecognizePDFPage(t *testing.T) {
+	t.Parallel()
+
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/2.0/token":
+			assert.Equal(t, "ak", r.URL.Query().Get("client_id"))
+			assert.Equal(t, "sk", r.URL.Query().Get("client_secret"))
+			tokens++
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":2592000}`, tokens)
+		case "/rest/2.0/ocr/v1/accurate_basic":
+			if r.URL.Query().Get("access_token") == "expired" {
+				fmt.Fprint(w, `{"error_code":111,"error_msg":"Access token expired"}`)
+				return
+			}
+			assert.Equal(t, "token-1", r.URL.Query().Get("access_token"))
+			require.NoError(t, r.ParseForm())
+			pdf, err := base64.StdEncoding.DecodeString(r.PostForm.Get("pdf_file"))
+			assert.NoError(t, err)
+			assert.Equal(t, "%PDF-1.4", string(pdf))
+			assert.Equal(t, "2", r.PostForm.Get("pdf_file_num"))
+			fmt.Fprint(w, `{"words_result":[{"words":"第一行"},{"words":"second line"}],"words_result_num":2}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cache := &mapCache{values: map[string]string{"langchain:baiduocr:ak": "expired"}}
+	ocr, err := New(WithAKSK("ak", "sk"), WithBaseURL(server.URL), WithCache(cache))
+	require.NoError(t, err)
+
+	text, err := ocr.RecognizePDFPage(context.Background(), []byte("%PDF-1.4"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "第一行\nsecond line", text)
+	assert.Equal(t, 1, tokens)
+	assert.Equal(t, "token-1", cache.values["langchain:baiduocr:ak"])
+}
+
+func TestRecognizeError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"error_code":216201,"error_msg":"image format error"}`)
+	}))
+	defer server.Close()
+
+	ocr, err := New(WithAccessToken("token"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = ocr.RecognizeImage(context.Background(), []byte{0x89, 'P', 'N', 'G'})
+	require.ErrorIs(t, err, ErrOCRCode)
+	assert.Contains(t, err.Error(), "image format error")
+
+	_, err = New()
+	require.ErrorIs(t, err, ErrNotSetAuth)
+}
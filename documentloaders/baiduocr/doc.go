@@ -0,0 +1,3 @@
+// Package baiduocr provides a documentloaders.OCR backed by the Baidu OCR API,
+// to read scanned pdf pages with the pdf loader.
+package baiduocr
@@ -0,0 +1,215 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/tools/scraper"
+)
+
+const (
+	_defaultCrawlerMaxDepth = 2
+	// _maxSitemapDepth is how deep sitemap indexes are followed.
+	_maxSitemapDepth = 2
+)
+
+// Crawler loads the pages of websites, crawling from seed URLs with
+// scraper.Scraper. Every page is a document loaded with the HTML loader, with
+// the metadata "source", "title", "description" and "links".
+type Crawler struct {
+	urls        []string
+	options     []scraper.Options
+	sitemap     bool
+	httpClient  *http.Client
+	htmlOptions []HTMLOptions
+}
+
+var _ Loader = Crawler{}
+
+// CrawlerOptions are options for the Crawler loader.
+type CrawlerOptions func(c *Crawler)
+
+// WithCrawlerMaxDepth sets how many links deep the crawler follows from the
+// seed URLs. The seed URLs are at depth 1. It defaults to 2.
+func WithCrawlerMaxDepth(depth int) CrawlerOptions {
+	return func(c *Crawler) {
+		c.options = append(c.options, scraper.WithMaxDepth(depth))
+	}
+}
+
+// WithCrawlerAllowedDomains sets the domains, including their subdomains, that
+// the crawler follows links to. By default it only follows links to the host
+// of the page the link is on.
+func WithCrawlerAllowedDomains(domains ...string) CrawlerOptions {
+	return func(c *Crawler) {
+		c.options = append(c.options, scraper.WithAllowedDomains(domains...))
+	}
+}
+
+// WithCrawlerRobotsTxt sets whether the crawler respects robots.txt. It is
+// respected by default.
+func WithCrawlerRobotsTxt(respect bool) CrawlerOptions {
+	return func(c *Crawler) {
+		c.options = append(c.options, scraper.WithRobotsTxt(respect))
+	}
+}
+
+// WithCrawlerSitemap also crawls the pages listed in the sitemap.xml of the
+// hosts of the seed URLs, following sitemap indexes.
+func WithCrawlerSitemap() CrawlerOptions {
+	return func(c *Crawler) {
+		c.sitemap = true
+	}
+}
+
+// WithCrawlerScraperOptions sets options of the scraper, like the delay
+// between requests or the blacklist.
+func WithCrawlerScraperOptions(opts ...scraper.Options) CrawlerOptions {
+	return func(c *Crawler) {
+		c.options = append(c.options, opts...)
+	}
+}
+
+// WithCrawlerHTMLOptions sets options of the HTML loader used for every page,
+// like WithHTMLMarkdown or WithHTMLSelector.
+func WithCrawlerHTMLOptions(opts ...HTMLOptions) CrawlerOptions {
+	return func(c *Crawler) {
+		c.htmlOptions = append(c.htmlOptions, opts...)
+	}
+}
+
+// WithCrawlerHTTPClient sets the HTTP client used to read sitemaps.
+func WithCrawlerHTTPClient(client *http.Client) CrawlerOptions {
+	return func(c *Crawler) {
+		c.httpClient = client
+	}
+}
+
+// NewCrawler creates a new crawler loader starting from the seed URLs.
+func NewCrawler(urls []string, opts ...CrawlerOptions) Crawler {
+	c := Crawler{
+		urls: urls,
+		options: []scraper.Options{
+			scraper.WithMaxDepth(_defaultCrawlerMaxDepth),
+			scraper.WithRobotsTxt(true),
+		},
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Load crawls the websites and returns a document per page.
+func (c Crawler) Load(ctx context.Context) ([]schema.Document, error) {
+	s, err := scraper.New(c.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := append([]string{}, c.urls...)
+	if c.sitemap {
+		urls = append(urls, c.sitemapURLs(ctx, *s)...)
+	}
+
+	pages, err := s.Scrape(ctx, urls...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(pages))
+	for _, page := range pages {
+		opts := append([]HTMLOptions{WithHTMLURL(page.URL), WithHTMLMetadata()}, c.htmlOptions...)
+		pageDocs, err := NewHTML(bytes.NewReader(page.HTML), opts...).Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, pageDocs...)
+	}
+	return docs, nil
+}
+
+// LoadAndSplit crawls the websites and splits the pages into multiple
+// documents using a text splitter.
+func (c Crawler) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := c.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// sitemap is a sitemap or a sitemap index.
+type sitemap struct {
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// sitemapURLs returns the pages of the sitemaps of the hosts of the seed URLs
+// that the scraper would follow a link to. Missing or invalid sitemaps are
+// skipped.
+func (c Crawler) sitemapURLs(ctx context.Context, s scraper.Scraper) []string {
+	urls := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, seed := range c.urls {
+		u, err := url.Parse(seed)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		root := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/sitemap.xml"}
+		for _, loc := range c.readSitemap(ctx, root.String(), 0) {
+			if seen[loc] || !s.Follows(loc, u) {
+				continue
+			}
+			seen[loc] = true
+			urls = append(urls, loc)
+		}
+	}
+	return urls
+}
+
+func (c Crawler) readSitemap(ctx context.Context, location string, depth int) []string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var sm sitemap
+	if err := xml.NewDecoder(resp.Body).Decode(&sm); err != nil {
+		return nil
+	}
+
+	urls := make([]string, 0, len(sm.URLs))
+	for _, u := range sm.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			urls = append(urls, loc)
+		}
+	}
+	if depth < _maxSitemapDepth {
+		for _, child := range sm.Sitemaps {
+			if loc := strings.TrimSpace(child.Loc); loc != "" {
+				urls = append(urls, c.readSitemap(ctx, loc, depth+1)...)
+			}
+		}
+	}
+	return urls
+}
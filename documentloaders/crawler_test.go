@@ -0,0 +1,115 @@
+package documentloaders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools/scraper"
+)
+
+func newTestSite(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	pages := map[string]string{
+		"/":              `<title>Home</title><p>Welcome</p><a href="/docs">Docs</a> <a href="/private/admin">Admin</a>`,
+		"/docs":          `<title>Docs</title><p>Read the docs.</p><a href="/docs/api">API</a>`,
+		"/docs/api":      `<title>API</title><p>Endpoints.</p>`,
+		"/private/admin": `<title>Admin</title><p>Secret.</p>`,
+		"/hidden":        `<title>Hidden</title><p>Only in the sitemap.</p>`,
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%s/sitemap-pages.xml</loc></sitemap>
+</sitemapindex>`, server.URL)
+		case "/sitemap-pages.xml":
+			fmt.Fprintf(w, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>%s/hidden</loc></url>
+  <url><loc>https://other.example.com/page</loc></url>
+</urlset>`, server.URL)
+		default:
+			page, ok := pages[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprintf(w, "<html><head>%s</head></html>", page)
+		}
+	}))
+	return server
+}
+
+func crawledTitles(docs []schema.Document) []string {
+	titles := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		titles = append(titles, fmt.Sprint(doc.Metadata["title"]))
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func TestCrawler(t *testing.T) {
+	t.Parallel()
+
+	server := newTestSite(t)
+	defer server.Close()
+
+	docs, err := NewCrawler([]string{server.URL + "/"},
+		WithCrawlerScraperOptions(scraper.WithDelay(0)),
+	).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Docs", "Home"}, crawledTitles(docs))
+
+	for _, doc := range docs {
+		if doc.Metadata["title"] == "Docs" {
+			assert.Equal(t, server.URL+"/docs", doc.Metadata["source"])
+			assert.Equal(t, "Read the docs.API", doc.PageContent)
+			assert.Equal(t, []string{server.URL + "/docs/api"}, doc.Metadata["links"])
+		}
+	}
+}
+
+func TestCrawlerDepthRobotsAndSitemap(t *testing.T) {
+	t.Parallel()
+
+	server := newTestSite(t)
+	defer server.Close()
+
+	docs, err := NewCrawler([]string{server.URL + "/"},
+		WithCrawlerMaxDepth(3),
+		WithCrawlerSitemap(),
+		WithCrawlerScraperOptions(scraper.WithDelay(0)),
+	).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API", "Docs", "Hidden", "Home"}, crawledTitles(docs))
+
+	docs, err = NewCrawler([]string{server.URL + "/"},
+		WithCrawlerRobotsTxt(false),
+		WithCrawlerAllowedDomains("example.com"),
+		WithCrawlerScraperOptions(scraper.WithDelay(0)),
+	).Load(context.Background())
+	require.NoError(t, err)
+	// Links to 127.0.0.1 are not followed, only the seed is loaded.
+	assert.Equal(t, []string{"Home"}, crawledTitles(docs))
+
+	docs, err = NewCrawler([]string{server.URL + "/"},
+		WithCrawlerRobotsTxt(false),
+		WithCrawlerScraperOptions(scraper.WithDelay(0)),
+	).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Admin", "Docs", "Home"}, crawledTitles(docs))
+}
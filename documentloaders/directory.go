@@ -0,0 +1,402 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+const _defaultDirectoryConcurrency = 4
+
+// FileLoaderFunc creates a loader for the content of a file.
+type FileLoaderFunc func(r io.ReaderAt, size int64) Loader
+
+// FileError is the error of loading a single file of a directory.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("load %s: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultFileLoaders returns the loaders used by the directory loader for file
+// extensions. The extensions are lowercase and include the leading dot.
+func DefaultFileLoaders() map[string]FileLoaderFunc {
+	text := func(r io.ReaderAt, size int64) Loader { return NewText(io.NewSectionReader(r, 0, size)) }
+	return map[string]FileLoaderFunc{
+		".txt":      text,
+		".md":       func(r io.ReaderAt, size int64) Loader { return NewMarkdown(io.NewSectionReader(r, 0, size)) },
+		".markdown": func(r io.ReaderAt, size int64) Loader { return NewMarkdown(io.NewSectionReader(r, 0, size)) },
+		".csv":      func(r io.ReaderAt, size int64) Loader { return NewCSV(io.NewSectionReader(r, 0, size)) },
+		".html":     func(r io.ReaderAt, size int64) Loader { return NewHTML(io.NewSectionReader(r, 0, size)) },
+		".htm":      func(r io.ReaderAt, size int64) Loader { return NewHTML(io.NewSectionReader(r, 0, size)) },
+		".pdf":      func(r io.ReaderAt, size int64) Loader { return NewPDF(r, size) },
+		".docx":     func(r io.ReaderAt, size int64) Loader { return NewDOCX(r, size) },
+		".xlsx":     func(r io.ReaderAt, size int64) Loader { return NewXLSX(r, size) },
+		".pptx":     func(r io.ReaderAt, size int64) Loader { return NewPPTX(r, size) },
+	}
+}
+
+// DefaultMIMELoaders returns the loaders used by the directory loader for the
+// MIME types sniffed from the content of files with unknown extensions.
+func DefaultMIMELoaders() map[string]FileLoaderFunc {
+	return map[string]FileLoaderFunc{
+		"text/plain":      func(r io.ReaderAt, size int64) Loader { return NewText(io.NewSectionReader(r, 0, size)) },
+		"text/html":       func(r io.ReaderAt, size int64) Loader { return NewHTML(io.NewSectionReader(r, 0, size)) },
+		"application/pdf": func(r io.ReaderAt, size int64) Loader { return NewPDF(r, size) },
+	}
+}
+
+// Directory loads the files of a directory or an fs.FS with the loader for
+// their extension or sniffed MIME type.
+type Directory struct {
+	fsys        fs.FS
+	dir         string
+	include     []string
+	exclude     []string
+	loaders     map[string]FileLoaderFunc
+	mimeLoaders map[string]FileLoaderFunc
+	concurrency int
+	skipErrors  bool
+	collectErrs bool
+}
+
+var _ Loader = Directory{}
+
+// DirectoryOptions are options for the directory loader.
+type DirectoryOptions func(d *Directory)
+
+// WithDirectoryInclude loads only the files matching one of the glob patterns.
+// Patterns use the syntax of path.Match plus "**" for any number of
+// directories, and are matched against the slash separated path relative to the
+// root. Patterns without a slash are matched against the file name, so "*.pdf"
+// matches PDF files in all directories.
+func WithDirectoryInclude(patterns ...string) DirectoryOptions {
+	return func(d *Directory) {
+		d.include = append(d.include, patterns...)
+	}
+}
+
+// WithDirectoryExclude skips the files and directories matching one of the glob
+// patterns, with the same syntax as WithDirectoryInclude.
+func WithDirectoryExclude(patterns ...string) DirectoryOptions {
+	return func(d *Directory) {
+		d.exclude = append(d.exclude, patterns...)
+	}
+}
+
+// WithDirectoryFileLoader registers the loader for files with the extension,
+// like ".json", replacing the default loader for it.
+func WithDirectoryFileLoader(ext string, loader FileLoaderFunc) DirectoryOptions {
+	return func(d *Directory) {
+		d.loaders[strings.ToLower(ext)] = loader
+	}
+}
+
+// WithDirectoryMIMELoader registers the loader for files with unknown
+// extensions whose content is sniffed as the MIME type, like
+// "application/json".
+func WithDirectoryMIMELoader(mimeType string, loader FileLoaderFunc) DirectoryOptions {
+	return func(d *Directory) {
+		d.mimeLoaders[mimeType] = loader
+	}
+}
+
+// WithDirectoryConcurrency sets the maximum number of files loaded at once.
+// Defaults to 4.
+func WithDirectoryConcurrency(concurrency int) DirectoryOptions {
+	return func(d *Directory) {
+		d.concurrency = concurrency
+	}
+}
+
+// WithDirectorySkipErrors skips the files that fail to load instead of failing
+// the whole load.
+func WithDirectorySkipErrors() DirectoryOptions {
+	return func(d *Directory) {
+		d.skipErrors = true
+	}
+}
+
+// WithDirectoryCollectErrors skips the files that fail to load, and returns the
+// documents of the other files together with an error joining a *FileError for
+// every failed file.
+func WithDirectoryCollectErrors() DirectoryOptions {
+	return func(d *Directory) {
+		d.collectErrs = true
+	}
+}
+
+// NewDirectory creates a new loader for the files in a directory of the
+// operating system.
+func NewDirectory(dir string, opts ...DirectoryOptions) Directory {
+	d := NewFS(os.DirFS(dir), opts...)
+	d.dir = dir
+	return d
+}
+
+// NewFS creates a new loader for the files in an fs.FS.
+func NewFS(fsys fs.FS, opts ...DirectoryOptions) Directory {
+	d := Directory{
+		fsys:        fsys,
+		loaders:     DefaultFileLoaders(),
+		mimeLoaders: DefaultMIMELoaders(),
+		concurrency: _defaultDirectoryConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// Load walks the directory and loads the matching files concurrently. Files
+// without a loader are skipped. The documents are returned in the lexical
+// order of the file paths, and have the path of the file as "source", its
+// modification time as "mtime" and its size as "size" metadata. For a
+// directory of the operating system the source includes the directory.
+func (d Directory) Load(ctx context.Context) ([]schema.Document, error) {
+	paths, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := d.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	failFast := !d.skipErrors && !d.collectErrs
+	results := make([][]schema.Document, len(paths))
+	errs := make([]error, len(paths))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i, p := range paths {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, p string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			docs, err := d.loadFile(ctx, p)
+			if err != nil {
+				errs[i] = &FileError{Path: d.source(p), Err: err}
+				if failFast {
+					errOnce.Do(func() {
+						firstErr = errs[i]
+						cancel()
+					})
+				}
+				return
+			}
+			results[i] = docs
+		}(i, p)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var docs []schema.Document
+	var fileErrs []error
+	for i := range paths {
+		if errs[i] != nil {
+			fileErrs = append(fileErrs, errs[i])
+			continue
+		}
+		docs = append(docs, results[i]...)
+	}
+	if d.collectErrs {
+		return docs, errors.Join(fileErrs...)
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit loads the files of the directory and splits them into
+// multiple documents using a text splitter.
+func (d Directory) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := d.Load(ctx)
+	if err != nil && docs == nil {
+		return nil, err
+	}
+
+	split, splitErr := textsplitter.SplitDocuments(splitter, docs)
+	if splitErr != nil {
+		return nil, splitErr
+	}
+	return split, err
+}
+
+// files returns the paths of the regular files matching the patterns.
+func (d Directory) files() ([]string, error) {
+	var paths []string
+	err := fs.WalkDir(d.fsys, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != "." && matchAny(d.exclude, p) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if len(d.include) > 0 && !matchAny(d.include, p) {
+			return nil
+		}
+		paths = append(paths, p)
+		return nil
+	})
+
+	return paths, err
+}
+
+// loadFile loads a file with the loader for its extension or sniffed MIME
+// type, and adds the file metadata to the documents.
+func (d Directory) loadFile(ctx context.Context, p string) ([]schema.Document, error) {
+	f, err := d.fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := f.(io.ReaderAt)
+	if !ok {
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(content)
+	}
+
+	newLoader, ok := d.loaders[strings.ToLower(path.Ext(p))]
+	if !ok {
+		newLoader, err = d.sniffLoader(r)
+		if err != nil || newLoader == nil {
+			return nil, err
+		}
+	}
+
+	docs, err := newLoader(r, info.Size()).Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range docs {
+		metadata := make(map[string]any, len(docs[i].Metadata)+3)
+		for k, v := range docs[i].Metadata {
+			metadata[k] = v
+		}
+		metadata["source"] = d.source(p)
+		metadata["mtime"] = info.ModTime()
+		metadata["size"] = info.Size()
+		docs[i].Metadata = metadata
+	}
+	return docs, nil
+}
+
+// sniffLoader returns the loader for the MIME type of the content, or nil if
+// there is none.
+func (d Directory) sniffLoader(r io.ReaderAt) (FileLoaderFunc, error) {
+	head := make([]byte, 512)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	mimeType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return nil, nil //nolint:nilerr
+	}
+	return d.mimeLoaders[mimeType], nil
+}
+
+// source returns the source metadata of a file path.
+func (d Directory) source(p string) string {
+	if d.dir == "" {
+		return p
+	}
+	return filepath.Join(d.dir, filepath.FromSlash(p))
+}
+
+// matchAny reports whether the slash separated path matches one of the glob
+// patterns.
+func matchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(p)); ok {
+				return true
+			}
+			continue
+		}
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(p, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments, where a "**"
+// segment matches any number of path segments.
+func matchGlob(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlob(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
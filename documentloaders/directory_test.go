@@ -0,0 +1,117 @@
+package documentloaders
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func directoryTestFS() fstest.MapFS {
+	mtime := time.Date(2023, 12, 1, 8, 0, 0, 0, time.UTC)
+	return fstest.MapFS{
+		"a.txt":          {Data: []byte("年假制度"), ModTime: mtime},
+		"b.csv":          {Data: []byte("name,days\n张三,5\n"), ModTime: mtime},
+		"bad.pdf":        {Data: []byte("not a pdf"), ModTime: mtime},
+		"data.bin":       {Data: []byte{0x00, 0x01, 0x02, 0xff}, ModTime: mtime},
+		"notes":          {Data: []byte("plain text without extension"), ModTime: mtime},
+		"sub/c.HTML":     {Data: []byte("<html><body><p>报销</p></body></html>"), ModTime: mtime},
+		"sub/deep/d.md":  {Data: []byte("# 标题"), ModTime: mtime},
+		"vendor/x.txt":   {Data: []byte("vendored"), ModTime: mtime},
+		"sub/deep/e.txt": {Data: []byte("深层"), ModTime: mtime},
+	}
+}
+
+func sources(docs []schema.Document) []string {
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.Metadata["source"].(string))
+	}
+	return result
+}
+
+func TestDirectoryLoader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fsys := directoryTestFS()
+
+	_, err := NewFS(fsys).Load(ctx)
+	var fileErr *FileError
+	require.ErrorAs(t, err, &fileErr)
+	assert.Equal(t, "bad.pdf", fileErr.Path)
+
+	docs, err := NewFS(fsys, WithDirectoryExclude("*.pdf", "vendor"), WithDirectoryConcurrency(2)).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.csv", "notes", "sub/c.HTML", "sub/deep/d.md", "sub/deep/e.txt"}, sources(docs))
+	assert.Equal(t, "年假制度", docs[0].PageContent)
+	assert.Equal(t, "name: 张三\ndays: 5", docs[1].PageContent)
+	assert.Equal(t, 1, docs[1].Metadata["row"])
+	assert.Equal(t, "plain text without extension", docs[2].PageContent)
+	assert.Equal(t, "报销", docs[3].PageContent)
+	assert.Equal(t, time.Date(2023, 12, 1, 8, 0, 0, 0, time.UTC), docs[0].Metadata["mtime"])
+	assert.Equal(t, int64(len("年假制度")), docs[0].Metadata["size"])
+
+	docs, err = NewFS(fsys, WithDirectoryInclude("sub/**/*.md", "a.*")).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "sub/deep/d.md"}, sources(docs))
+}
+
+func TestDirectoryLoaderErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fsys := directoryTestFS()
+
+	docs, err := NewFS(fsys, WithDirectorySkipErrors(), WithDirectoryInclude("*.pdf", "*.txt")).Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "sub/deep/e.txt", "vendor/x.txt"}, sources(docs))
+
+	docs, err = NewFS(fsys, WithDirectoryCollectErrors(), WithDirectoryInclude("*.pdf", "*.txt")).Load(ctx)
+	var fileErr *FileError
+	require.ErrorAs(t, err, &fileErr)
+	assert.Equal(t, "bad.pdf", fileErr.Path)
+	assert.Len(t, docs, 3)
+}
+
+func TestDirectoryLoaderRegistered(t *testing.T) {
+	t.Parallel()
+
+	raw := func(r io.ReaderAt, size int64) Loader {
+		return NewText(io.NewSectionReader(r, 0, size))
+	}
+	docs, err := NewFS(directoryTestFS(),
+		WithDirectoryInclude("*.bin"),
+		WithDirectoryMIMELoader("application/octet-stream", raw),
+	).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data.bin"}, sources(docs))
+
+	docs, err = NewFS(directoryTestFS(),
+		WithDirectoryInclude("*.md"),
+		WithDirectoryFileLoader(".MD", func(io.ReaderAt, int64) Loader { return NewText(strings.NewReader("custom")) }),
+	).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "custom", docs[0].PageContent)
+}
+
+func TestDirectoryLoaderOS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("内容"), 0o600))
+
+	docs, err := NewDirectory(dir).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "内容", docs[0].PageContent)
+	assert.Equal(t, filepath.Join(dir, "sub", "a.txt"), docs[0].Metadata["source"])
+}
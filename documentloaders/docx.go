@@ -0,0 +1,294 @@
+package documentloaders
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/internal/htmlmd"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// DOCX loads text data from a Word .docx file.
+type DOCX struct {
+	r io.ReaderAt
+	s int64
+}
+
+var _ Loader = DOCX{}
+
+// NewDOCX creates a new docx loader with an io.ReaderAt and the size of the file.
+func NewDOCX(r io.ReaderAt, size int64) DOCX {
+	return DOCX{
+		r: r,
+		s: size,
+	}
+}
+
+// Load reads the docx file and returns a document per page. Headings are
+// formatted as markdown headings, list items as markdown list items and
+// tables as markdown tables. Pages are split on explicit page breaks and on
+// the page breaks recorded by Word when the file was saved, so files written
+// by other tools may load as a single page. The documents have the page
+// number and the total number of pages as metadata.
+func (d DOCX) Load(_ context.Context) ([]schema.Document, error) {
+	f, err := openOfficeFile(d.r, d.s)
+	if err != nil {
+		return nil, err
+	}
+
+	documentPart, err := f.mainPart("word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	headingStyles, err := docxHeadingStyles(f, documentPart)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := f.open(documentPart)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var pages [][]string
+	var current []string
+	// newPage starts a new page. Consecutive page breaks, like an explicit
+	// page break followed by the page break recorded by Word, start one page.
+	newPage := func() {
+		if len(current) > 0 {
+			pages = append(pages, current)
+			current = nil
+		}
+	}
+
+	dec := xml.NewDecoder(rc)
+	err = walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "p":
+			p, err := parseDocxParagraph(dec)
+			if err != nil {
+				return true, err
+			}
+			if p.pageBreakBefore {
+				newPage()
+			}
+			for i, segment := range p.segments {
+				if i > 0 {
+					newPage()
+				}
+				if strings.TrimSpace(segment) == "" {
+					continue
+				}
+				current = append(current, p.format(segment, headingStyles))
+			}
+			return true, nil
+		case "tbl":
+			rows, err := parseDocxTable(dec)
+			if err != nil {
+				return true, err
+			}
+			if table := htmlmd.Table(rows); table != "" {
+				current = append(current, table)
+			}
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	newPage()
+
+	docs := make([]schema.Document, 0, len(pages))
+	for i, page := range pages {
+		docs = append(docs, schema.Document{
+			PageContent: strings.Join(page, "\n\n"),
+			Metadata: map[string]any{
+				"page":        i + 1,
+				"total_pages": len(pages),
+			},
+		})
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit reads the docx file and splits it into multiple documents
+// using a text splitter.
+func (d DOCX) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := d.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// docxParagraph is a paragraph of a docx file.
+type docxParagraph struct {
+	// segments is the text of the paragraph split at page breaks.
+	segments        []string
+	pageBreakBefore bool
+	style           string
+	outlineLevel    int
+	list            bool
+}
+
+// format formats a segment of the paragraph as markdown.
+func (p docxParagraph) format(segment string, headingStyles map[string]int) string {
+	level := p.outlineLevel
+	if l, ok := headingStyles[p.style]; ok && level == 0 {
+		level = l
+	}
+
+	segment = strings.TrimSpace(segment)
+	switch {
+	case level > 0:
+		return strings.Repeat("#", level) + " " + segment
+	case p.list:
+		return "- " + segment
+	default:
+		return segment
+	}
+}
+
+// parseDocxParagraph reads the paragraph whose start was last read from the
+// decoder.
+func parseDocxParagraph(dec *xml.Decoder) (docxParagraph, error) {
+	var p docxParagraph
+	var sb strings.Builder
+	pageBreak := func() {
+		p.segments = append(p.segments, sb.String())
+		sb.Reset()
+	}
+
+	err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "pStyle":
+			p.style = attr(start, "val")
+		case "outlineLvl":
+			if level, err := strconv.Atoi(attr(start, "val")); err == nil && level < 9 {
+				p.outlineLevel = level + 1
+			}
+		case "numPr":
+			p.list = true
+		case "pageBreakBefore":
+			v := attr(start, "val")
+			p.pageBreakBefore = v != "0" && v != "false" && v != "off"
+		case "t":
+			var text string
+			if err := dec.DecodeElement(&text, &start); err != nil {
+				return true, err
+			}
+			sb.WriteString(text)
+			return true, nil
+		case "tab":
+			sb.WriteString("\t")
+		case "br":
+			if attr(start, "type") == "page" {
+				pageBreak()
+			} else {
+				sb.WriteString("\n")
+			}
+		case "cr":
+			sb.WriteString("\n")
+		case "lastRenderedPageBreak":
+			pageBreak()
+		case "tabs", "delText", "instrText":
+			return true, dec.Skip()
+		}
+		return false, nil
+	})
+	p.segments = append(p.segments, sb.String())
+
+	return p, err
+}
+
+// parseDocxTable reads the rows of the table whose start was last read from
+// the decoder. The paragraphs of a cell, including those of nested tables,
+// are joined with spaces.
+func parseDocxTable(dec *xml.Decoder) ([][]string, error) {
+	var rows [][]string
+	err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "tr":
+			rows = append(rows, nil)
+		case "tc":
+			var texts []string
+			err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+				if start.Name.Local != "p" {
+					return false, nil
+				}
+				p, err := parseDocxParagraph(dec)
+				if text := strings.TrimSpace(strings.Join(p.segments, " ")); text != "" {
+					texts = append(texts, text)
+				}
+				return true, err
+			})
+			if len(rows) > 0 {
+				rows[len(rows)-1] = append(rows[len(rows)-1], strings.Join(texts, " "))
+			}
+			return true, err
+		}
+		return false, nil
+	})
+
+	return rows, err
+}
+
+type docxStyles struct {
+	Styles []struct {
+		ID   string `xml:"styleId,attr"`
+		Name struct {
+			Val string `xml:"val,attr"`
+		} `xml:"name"`
+		OutlineLevel *struct {
+			Val int `xml:"val,attr"`
+		} `xml:"pPr>outlineLvl"`
+	} `xml:"style"`
+}
+
+// docxHeadingStyles returns the heading level of the heading styles by their
+// ids. Localized versions of Word use localized style ids, like "1" for
+// "heading 1", so the levels are taken from the style names and outline levels.
+func docxHeadingStyles(f *officeFile, documentPart string) (map[string]int, error) {
+	targets, types, err := f.relationships(documentPart)
+	if err != nil {
+		return nil, err
+	}
+	stylesPart := "word/styles.xml"
+	for id, typ := range types {
+		if strings.HasSuffix(typ, "/styles") {
+			stylesPart = targets[id]
+		}
+	}
+
+	levels := make(map[string]int)
+	if !f.has(stylesPart) {
+		return levels, nil
+	}
+
+	var styles docxStyles
+	if err := f.decode(stylesPart, &styles); err != nil {
+		return nil, err
+	}
+	for _, s := range styles.Styles {
+		name := strings.ToLower(s.Name.Val)
+		switch {
+		case name == "title":
+			levels[s.ID] = 1
+		case strings.HasPrefix(name, "heading "):
+			if level, err := strconv.Atoi(strings.TrimPrefix(name, "heading ")); err == nil && level > 0 {
+				levels[s.ID] = level
+			}
+		case s.OutlineLevel != nil && s.OutlineLevel.Val < 9:
+			levels[s.ID] = s.OutlineLevel.Val + 1
+		}
+	}
+	return levels, nil
+}
@@ -0,0 +1,67 @@
+package documentloaders
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _docxDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="1"/><w:tabs><w:tab w:val="left" w:pos="420"/></w:tabs></w:pPr><w:r><w:t>员工手册</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">年假规定：</w:t></w:r><w:r><w:delText>删除的内容</w:delText></w:r><w:r><w:t>每年五天。</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>提前申请</w:t></w:r></w:p>
+<w:tbl>
+<w:tblPr/>
+<w:tr><w:tc><w:p><w:r><w:t>工龄</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>天数</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>1-10年</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>5</w:t></w:r></w:p><w:p><w:r><w:t>天</w:t></w:r></w:p></w:tc></w:tr>
+</w:tbl>
+<w:p><w:r><w:t>第一页结束</w:t></w:r><w:r><w:br w:type="page"/></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:lastRenderedPageBreak/><w:t>报销</w:t></w:r></w:p>
+<w:p><w:r><w:t>发票</w:t></w:r><w:r><w:tab/><w:t>审批单</w:t></w:r></w:p>
+<w:sectPr/>
+</w:body>
+</w:document>`
+
+const _docxStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:styleId="1"><w:name w:val="heading 1"/></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/></w:style>
+<w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+</w:styles>`
+
+func TestDOCXLoader(t *testing.T) {
+	t.Parallel()
+
+	r, size := officeZip(t, map[string]string{
+		"_rels/.rels":       fmt.Sprintf(_officeRootRels, "word/document.xml"),
+		"word/document.xml": _docxDocument,
+		"word/styles.xml":   _docxStyles,
+	})
+
+	docs, err := NewDOCX(r, size).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "# 员工手册\n\n"+
+		"年假规定：每年五天。\n\n"+
+		"- 提前申请\n\n"+
+		"| 工龄 | 天数 |\n| --- | --- |\n| 1-10年 | 5 天 |\n\n"+
+		"第一页结束", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"page": 1, "total_pages": 2}, docs[0].Metadata)
+
+	assert.Equal(t, "## 报销\n\n发票\t审批单", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"page": 2, "total_pages": 2}, docs[1].Metadata)
+}
+
+func TestDOCXLoaderInvalid(t *testing.T) {
+	t.Parallel()
+
+	r, size := officeZip(t, map[string]string{"word/other.xml": "<a/>"})
+	_, err := NewDOCX(r, size).Load(context.Background())
+	require.ErrorIs(t, err, ErrMissingPart)
+}
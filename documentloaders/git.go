@@ -0,0 +1,345 @@
+package documentloaders
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+const (
+	_defaultGitMaxFileSize = 1 << 20
+	// _binarySniffLength is how many bytes are checked for a NUL byte to
+	// detect binary files, like git does.
+	_binarySniffLength = 8000
+)
+
+var (
+	// ErrGit is returned when a git command fails.
+	ErrGit = errors.New("git command failed")
+	// ErrInvalidGitRef is returned for refs starting with '-', which git would
+	// parse as options.
+	ErrInvalidGitRef = errors.New("invalid git ref")
+)
+
+// Git loads the files and the commits of a local git repository at a ref with
+// the git command. Files have the metadata "source" (the path), "commit",
+// "author" (of the last commit changing the file), "ref" and, for source code,
+// "language". Commits have the metadata "commit", "author", "email" and "date".
+type Git struct {
+	dir         string
+	ref         string
+	include     []string
+	exclude     []string
+	maxFileSize int64
+	files       bool
+	commits     int
+}
+
+var _ Loader = Git{}
+
+// GitOptions are options for the Git loader.
+type GitOptions func(g *Git)
+
+// WithGitRef sets the branch, tag or commit to load. It defaults to HEAD. Refs
+// starting with '-' are rejected with ErrInvalidGitRef.
+func WithGitRef(ref string) GitOptions {
+	return func(g *Git) {
+		g.ref = ref
+	}
+}
+
+// WithGitInclude loads only the files matching one of the glob patterns, like
+// "*.go" or "docs/**/*.md", with the same syntax as WithDirectoryInclude.
+func WithGitInclude(patterns ...string) GitOptions {
+	return func(g *Git) {
+		g.include = append(g.include, patterns...)
+	}
+}
+
+// WithGitExclude skips the files matching one of the glob patterns, like
+// "vendor/**", with the same syntax as WithDirectoryInclude.
+func WithGitExclude(patterns ...string) GitOptions {
+	return func(g *Git) {
+		g.exclude = append(g.exclude, patterns...)
+	}
+}
+
+// WithGitMaxFileSize skips files larger than size bytes. It defaults to 1MiB.
+func WithGitMaxFileSize(size int64) GitOptions {
+	return func(g *Git) {
+		g.maxFileSize = size
+	}
+}
+
+// WithGitCommits also loads a document per commit for up to n commits
+// reachable from the ref, newest first, with the commit message and the diff.
+func WithGitCommits(n int) GitOptions {
+	return func(g *Git) {
+		g.commits = n
+	}
+}
+
+// WithGitFiles sets whether the files are loaded. They are by default.
+func WithGitFiles(files bool) GitOptions {
+	return func(g *Git) {
+		g.files = files
+	}
+}
+
+// NewGit creates a new git loader for the repository in the directory.
+func NewGit(dir string, opts ...GitOptions) Git {
+	g := Git{
+		dir:         dir,
+		ref:         "HEAD",
+		maxFileSize: _defaultGitMaxFileSize,
+		files:       true,
+	}
+	for _, opt := range opts {
+		opt(&g)
+	}
+	return g
+}
+
+// Load reads the files of the repository at the ref, skipping binary files,
+// and then the commits if enabled.
+func (g Git) Load(ctx context.Context) ([]schema.Document, error) {
+	docs := make([]schema.Document, 0)
+	if !g.files && g.commits <= 0 {
+		return docs, nil
+	}
+
+	commit, err := g.resolveRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if g.files {
+		files, err := g.loadFiles(ctx, commit)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, files...)
+	}
+	if g.commits > 0 {
+		commits, err := g.loadCommits(ctx, commit)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, commits...)
+	}
+	return docs, nil
+}
+
+// LoadAndSplit reads the repository and splits the documents using a text
+// splitter. Use a textsplitter.Code splitter for source files.
+func (g Git) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := g.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// gitBlob is a file of the tree of the ref.
+type gitBlob struct {
+	sha  string
+	path string
+}
+
+// resolveRef returns the sha of the commit of the ref. Only the sha is passed
+// to later git commands, so a ref is never parsed as an option or a path.
+func (g Git) resolveRef(ctx context.Context) (string, error) {
+	if strings.HasPrefix(g.ref, "-") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidGitRef, g.ref)
+	}
+	out, err := g.git(ctx, nil, "rev-parse", "--verify", "--end-of-options", g.ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (g Git) loadFiles(ctx context.Context, commit string) ([]schema.Document, error) {
+	out, err := g.git(ctx, nil, "ls-tree", "-r", "-z", "--long", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]gitBlob, 0)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		info, p, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 4 || fields[1] != "blob" || !g.matches(p) {
+			continue
+		}
+		if size, err := strconv.ParseInt(fields[3], 10, 64); err != nil || size > g.maxFileSize {
+			continue
+		}
+		blobs = append(blobs, gitBlob{sha: fields[2], path: p})
+	}
+	if len(blobs) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	contents, err := g.readBlobs(ctx, blobs)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := g.fileAuthors(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(blobs))
+	for i, blob := range blobs {
+		content := contents[i]
+		if bytes.IndexByte(content[:minInt(len(content), _binarySniffLength)], 0) >= 0 {
+			continue
+		}
+
+		metadata := map[string]any{
+			"source": blob.path,
+			"commit": commit,
+			"author": authors[blob.path],
+			"ref":    g.ref,
+		}
+		if language, ok := textsplitter.LanguageFromPath(blob.path); ok {
+			metadata["language"] = string(language)
+		}
+		docs = append(docs, schema.Document{
+			PageContent: string(content),
+			Metadata:    metadata,
+		})
+	}
+	return docs, nil
+}
+
+// fileAuthors returns the author of the last commit changing each path,
+// walking the history of the commit once.
+func (g Git) fileAuthors(ctx context.Context, commit string) (map[string]string, error) {
+	out, err := g.git(ctx, nil, "log", "--format=%x1e%an%x00", "--name-only", "-z", "--no-renames", commit, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make(map[string]string)
+	for _, record := range strings.Split(string(out), "\x1e") {
+		// <author> NUL NUL LF <path> NUL <path> NUL ...
+		author, paths, ok := strings.Cut(record, "\x00")
+		if !ok {
+			continue
+		}
+		paths = strings.TrimPrefix(strings.TrimPrefix(paths, "\x00"), "\n")
+		for _, p := range strings.Split(paths, "\x00") {
+			if _, ok := authors[p]; p != "" && !ok {
+				authors[p] = author
+			}
+		}
+	}
+	return authors, nil
+}
+
+// readBlobs reads the contents of the blobs with a single git cat-file.
+func (g Git) readBlobs(ctx context.Context, blobs []gitBlob) ([][]byte, error) {
+	var input bytes.Buffer
+	for _, blob := range blobs {
+		input.WriteString(blob.sha + "\n")
+	}
+	out, err := g.git(ctx, &input, "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+
+	rd := bufio.NewReader(bytes.NewReader(out))
+	contents := make([][]byte, 0, len(blobs))
+	for range blobs {
+		// <sha> SP <type> SP <size> LF <contents> LF
+		header, err := rd.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: cat-file: %w", ErrGit, err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: cat-file: %s", ErrGit, strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cat-file: %w", ErrGit, err)
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(rd, content); err != nil {
+			return nil, fmt.Errorf("%w: cat-file: %w", ErrGit, err)
+		}
+		contents = append(contents, content[:size])
+	}
+	return contents, nil
+}
+
+func (g Git) loadCommits(ctx context.Context, commit string) ([]schema.Document, error) {
+	// Every commit starts with a record separator, and the header fields are
+	// separated by NUL bytes from each other and from the diff.
+	out, err := g.git(ctx, nil, "log", "-n", strconv.Itoa(g.commits), "--patch", "--no-color", "--no-ext-diff",
+		"--format=%x1e%H%x00%an%x00%ae%x00%aI%x00%B%x00", commit, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, g.commits)
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(record, "\x00", 6)
+		if len(fields) != 6 {
+			continue
+		}
+		message := strings.TrimSpace(fields[4])
+		diff := strings.TrimSpace(fields[5])
+
+		content := message
+		if diff != "" {
+			content += "\n\n" + diff
+		}
+		docs = append(docs, schema.Document{
+			PageContent: content,
+			Metadata: map[string]any{
+				"commit": fields[0],
+				"author": fields[1],
+				"email":  fields[2],
+				"date":   fields[3],
+			},
+		})
+	}
+	return docs, nil
+}
+
+// git runs a git command in the repository and returns its output.
+func (g Git) git(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.dir}, args...)...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: git %s: %w: %s", ErrGit, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// matches reports whether the path is included and not excluded.
+func (g Git) matches(p string) bool {
+	return (len(g.include) == 0 || matchAny(g.include, p)) && !matchAny(g.exclude, p)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
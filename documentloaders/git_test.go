@@ -0,0 +1,139 @@
+package documentloaders
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// newTestRepo creates a git repository with a commit by Ada and a commit by
+// Bob and returns its directory.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Ada", "-c", "user.email=ada@example.com"},
+			args...)...)
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+
+	run("init", "-q", "-b", "main")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	write("docs/guide.md", "# Guide\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "Initial commit")
+	run("tag", "v1")
+
+	write("docs/api/reference.md", "# API\n")
+	write("vendor/lib/lib.go", "package lib\n")
+	write("logo.png", "\x89PNG\r\n\x1a\n\x00\x00")
+	write("big.txt", strings.Repeat("x", 2048))
+	run("add", "-A")
+	run("-c", "user.name=Bob", "-c", "user.email=bob@example.com",
+		"commit", "-q", "-m", "Add docs\n\nWith the API reference.")
+	return dir
+}
+
+func sortedSources(docs []schema.Document) []string {
+	paths := sources(docs)
+	sort.Strings(paths)
+	return paths
+}
+
+func TestGitLoader(t *testing.T) {
+	t.Parallel()
+
+	dir := newTestRepo(t)
+
+	docs, err := NewGit(dir, WithGitMaxFileSize(1024)).Load(context.Background())
+	require.NoError(t, err)
+	// logo.png is binary and big.txt too large.
+	assert.Equal(t, []string{
+		"docs/api/reference.md", "docs/guide.md", "main.go", "vendor/lib/lib.go",
+	}, sortedSources(docs))
+
+	for _, doc := range docs {
+		assert.Len(t, doc.Metadata["commit"], 40)
+		assert.Equal(t, "HEAD", doc.Metadata["ref"])
+		if strings.HasPrefix(doc.Metadata["source"].(string), "docs/api/") {
+			assert.Equal(t, "Bob", doc.Metadata["author"])
+		}
+		if doc.Metadata["source"] == "main.go" {
+			assert.Equal(t, "package main\n\nfunc main() {}\n", doc.PageContent)
+			assert.Equal(t, "go", doc.Metadata["language"])
+			assert.Equal(t, "Ada", doc.Metadata["author"])
+		}
+	}
+
+	docs, err = NewGit(dir, WithGitRef("v1")).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md", "main.go"}, sortedSources(docs))
+}
+
+func TestGitLoaderGlobs(t *testing.T) {
+	t.Parallel()
+
+	dir := newTestRepo(t)
+
+	docs, err := NewGit(dir, WithGitInclude("*.go", "docs/**/*.md"), WithGitExclude("vendor/**")).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/api/reference.md", "docs/guide.md", "main.go"}, sortedSources(docs))
+}
+
+func TestGitLoaderCommits(t *testing.T) {
+	t.Parallel()
+
+	dir := newTestRepo(t)
+
+	docs, err := NewGit(dir, WithGitFiles(false), WithGitCommits(10)).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.True(t, strings.HasPrefix(docs[0].PageContent, "Add docs\n\nWith the API reference.\n\ndiff --git"))
+	assert.Contains(t, docs[0].PageContent, "+# API")
+	assert.Equal(t, "Bob", docs[0].Metadata["author"])
+	assert.Equal(t, "bob@example.com", docs[0].Metadata["email"])
+	assert.NotEmpty(t, docs[0].Metadata["date"])
+	assert.True(t, strings.HasPrefix(docs[1].PageContent, "Initial commit\n\ndiff --git"))
+
+	_, err = NewGit(t.TempDir()).Load(context.Background())
+	require.ErrorIs(t, err, ErrGit)
+}
+
+func TestGitLoaderRefs(t *testing.T) {
+	t.Parallel()
+
+	dir := newTestRepo(t)
+
+	for _, ref := range []string{"--output=/tmp/x", "-h"} {
+		_, err := NewGit(dir, WithGitRef(ref)).Load(context.Background())
+		require.ErrorIs(t, err, ErrInvalidGitRef, ref)
+	}
+	for _, ref := range []string{"missing", "HEAD:main.go"} {
+		_, err := NewGit(dir, WithGitRef(ref), WithGitCommits(1)).Load(context.Background())
+		require.ErrorIs(t, err, ErrGit, ref)
+	}
+}
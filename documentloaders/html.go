@@ -3,24 +3,73 @@ package documentloaders
 import (
 	"context"
 	"io"
+	"net/url"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/tmc/langchaingo/internal/htmlmd"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/textsplitter"
 )
 
 // HTML loads parses and sanitizes html content from an io.Reader.
 type HTML struct {
-	r io.Reader
+	r        io.Reader
+	markdown bool
+	selector string
+	metadata bool
+	url      string
 }
 
 var _ Loader = HTML{}
 
-// NewHTML creates a new html loader with an io.Reader.
-func NewHTML(r io.Reader) HTML {
-	return HTML{r}
+// HTMLOptions are options for the HTML loader.
+type HTMLOptions func(h *HTML)
+
+// WithHTMLMarkdown converts the html to markdown instead of plain text,
+// keeping headers, lists, tables, code blocks and links. Use it with a
+// textsplitter.MarkdownHeader splitter to tag chunks with their headers.
+func WithHTMLMarkdown() HTMLOptions {
+	return func(h *HTML) {
+		h.markdown = true
+	}
+}
+
+// WithHTMLSelector loads only the elements matching the CSS selector, like
+// "main, article", to skip navigation, sidebars and footers. The whole body is
+// loaded if nothing matches.
+func WithHTMLSelector(selector string) HTMLOptions {
+	return func(h *HTML) {
+		h.selector = selector
+	}
+}
+
+// WithHTMLMetadata adds the title, the meta description and the links of the
+// page to the metadata with the keys "title", "description" and "links".
+func WithHTMLMetadata() HTMLOptions {
+	return func(h *HTML) {
+		h.metadata = true
+	}
+}
+
+// WithHTMLURL sets the URL the html was loaded from. It is added to the
+// metadata as "source", and relative links are resolved against it.
+func WithHTMLURL(u string) HTMLOptions {
+	return func(h *HTML) {
+		h.url = u
+	}
+}
+
+// NewHTML creates a new html loader with an io.Reader. Pages scraped with
+// scraper.Scraper.Scrape can be loaded with
+// NewHTML(bytes.NewReader(page.HTML), WithHTMLURL(page.URL)).
+func NewHTML(r io.Reader, opts ...HTMLOptions) HTML {
+	h := HTML{r: r}
+	for _, opt := range opts {
+		opt(&h)
+	}
+	return h
 }
 
 // Load reads from the io.Reader and returns a single document with the data.
@@ -30,20 +79,44 @@ func (h HTML) Load(_ context.Context) ([]schema.Document, error) {
 		return nil, err
 	}
 
-	var sel *goquery.Selection
-	if doc.Has("body") != nil {
-		sel = doc.Find("body").Contents()
+	var base *url.URL
+	if h.url != "" {
+		base, err = url.Parse(h.url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pagecontent string
+	if h.markdown {
+		pagecontent = htmlmd.Convert(htmlmd.Content(doc, h.selector), base)
 	} else {
-		sel = doc.Contents()
+		var sel *goquery.Selection
+		switch {
+		case h.selector != "" && doc.Find(h.selector).Length() > 0:
+			sel = doc.Find(h.selector)
+		case doc.Has("body") != nil:
+			sel = doc.Find("body").Contents()
+		default:
+			sel = doc.Contents()
+		}
+
+		sanitized := bluemonday.UGCPolicy().Sanitize(sel.Text())
+		pagecontent = strings.TrimSpace(sanitized)
 	}
 
-	sanitized := bluemonday.UGCPolicy().Sanitize(sel.Text())
-	pagecontent := strings.TrimSpace(sanitized)
+	metadata := map[string]any{}
+	if h.url != "" {
+		metadata["source"] = h.url
+	}
+	if h.metadata {
+		addHTMLMetadata(doc, base, metadata)
+	}
 
 	return []schema.Document{
 		{
 			PageContent: pagecontent,
-			Metadata:    map[string]any{},
+			Metadata:    metadata,
 		},
 	}, nil
 }
@@ -57,3 +130,41 @@ func (h HTML) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitt
 	}
 	return textsplitter.SplitDocuments(splitter, docs)
 }
+
+// addHTMLMetadata adds the title, the description and the absolute http links
+// of the document to the metadata.
+func addHTMLMetadata(doc *goquery.Document, base *url.URL, metadata map[string]any) {
+	if title := strings.TrimSpace(doc.Find("head title").First().Text()); title != "" {
+		metadata["title"] = title
+	}
+
+	for _, selector := range []string{
+		`meta[name="description"]`, `meta[property="og:description"]`, `meta[name="twitter:description"]`,
+	} {
+		if description := strings.TrimSpace(doc.Find(selector).AttrOr("content", "")); description != "" {
+			metadata["description"] = description
+			break
+		}
+	}
+
+	links := make([]string, 0)
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		u, err := url.Parse(strings.TrimSpace(s.AttrOr("href", "")))
+		if err != nil {
+			return
+		}
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return
+		}
+		u.Fragment = ""
+		if link := u.String(); !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	})
+	metadata["links"] = links
+}
@@ -3,10 +3,12 @@ package documentloaders
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/textsplitter"
 )
 
 func TestHTMLLoader(t *testing.T) {
@@ -48,3 +50,68 @@ func TestHTMLLoader(t *testing.T) {
 	expectedMetadata := map[string]any{}
 	assert.Equal(t, docs[0].Metadata, expectedMetadata)
 }
+
+const _htmlPage = `<html>
+<head>
+  <title>Release notes</title>
+  <meta name="description" content="What changed in v2.">
+</head>
+<body>
+  <nav><a href="/">Home</a> <a href="/blog">Blog</a></nav>
+  <main>
+    <h1>Release notes</h1>
+    <p>See the <a href="/docs/upgrade#steps">upgrade guide</a> and <a href="https://github.com/org/repo">source</a>.</p>
+    <h2>Features</h2>
+    <ul><li>Faster</li><li>Smaller</li></ul>
+    <p><a href="mailto:team@example.com">Contact</a></p>
+  </main>
+</body>
+</html>`
+
+func TestHTMLLoaderMarkdown(t *testing.T) {
+	t.Parallel()
+
+	loader := NewHTML(strings.NewReader(_htmlPage),
+		WithHTMLMarkdown(),
+		WithHTMLSelector("main"),
+		WithHTMLMetadata(),
+		WithHTMLURL("https://example.com/releases/v2"),
+	)
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	assert.Equal(t, "# Release notes\n\n"+
+		"See the [upgrade guide](https://example.com/docs/upgrade#steps) and [source](https://github.com/org/repo).\n\n"+
+		"## Features\n\n- Faster\n- Smaller\n\n"+
+		"[Contact](mailto:team@example.com)", docs[0].PageContent)
+	assert.Equal(t, map[string]any{
+		"source":      "https://example.com/releases/v2",
+		"title":       "Release notes",
+		"description": "What changed in v2.",
+		"links": []string{
+			"https://example.com/",
+			"https://example.com/blog",
+			"https://example.com/docs/upgrade",
+			"https://github.com/org/repo",
+		},
+	}, docs[0].Metadata)
+}
+
+func TestHTMLLoaderSelector(t *testing.T) {
+	t.Parallel()
+
+	docs, err := NewHTML(strings.NewReader(_htmlPage), WithHTMLSelector("nav")).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Home Blog", docs[0].PageContent)
+}
+
+func TestHTMLLoaderMarkdownHeaderSplit(t *testing.T) {
+	t.Parallel()
+
+	docs, err := NewHTML(strings.NewReader(_htmlPage), WithHTMLMarkdown(), WithHTMLSelector("main")).
+		LoadAndSplit(context.Background(), textsplitter.NewMarkdownHeader())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, map[string]any{"h1": "Release notes", "h2": "Features"}, docs[1].Metadata)
+}
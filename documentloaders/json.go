@@ -0,0 +1,212 @@
+package documentloaders
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// JSON loads documents from a JSON or a JSON Lines file, one document per
+// record. The records, the content and the metadata of the documents are
+// selected with jq-style paths like ".data.items[]", ".answer" or
+// `.["ticket id"]`. The "[]" step selects the elements of arrays in order
+// and the values of objects in the sorted order of their keys.
+type JSON struct {
+	r        io.Reader
+	lines    bool
+	records  string
+	content  string
+	metadata map[string]string
+}
+
+var _ Loader = JSON{}
+
+// JSONOptions are options for the JSON loader.
+type JSONOptions func(j *JSON)
+
+// WithJSONLines reads the input as JSON Lines, one JSON value per line. The
+// records path is applied to every line.
+func WithJSONLines() JSONOptions {
+	return func(j *JSON) {
+		j.lines = true
+	}
+}
+
+// WithJSONRecords sets the path of the records, like ".items[]". Every value
+// it selects is a document. It defaults to ".", the whole value.
+func WithJSONRecords(path string) JSONOptions {
+	return func(j *JSON) {
+		j.records = path
+	}
+}
+
+// WithJSONContent sets the path of the page content in a record, like
+// ".answer". Strings are used as they are and other values as JSON, and
+// multiple values are joined with new lines. It defaults to ".", the whole
+// record.
+func WithJSONContent(path string) JSONOptions {
+	return func(j *JSON) {
+		j.content = path
+	}
+}
+
+// WithJSONMetadata sets the metadata of the documents, mapping metadata keys
+// to paths in the records like {"id": ".ticket.id"}. Paths selecting
+// multiple values set a slice, and paths selecting nothing are skipped.
+func WithJSONMetadata(metadata map[string]string) JSONOptions {
+	return func(j *JSON) {
+		j.metadata = metadata
+	}
+}
+
+// NewJSON creates a new JSON loader with an io.Reader.
+func NewJSON(r io.Reader, opts ...JSONOptions) JSON {
+	j := JSON{
+		r:       r,
+		records: ".",
+		content: ".",
+	}
+	for _, opt := range opts {
+		opt(&j)
+	}
+	return j
+}
+
+// Load reads from the io.Reader and returns a document per record, with the
+// metadata "record" set to the number of the record starting at 1.
+func (j JSON) Load(ctx context.Context) ([]schema.Document, error) {
+	docs := make([]schema.Document, 0)
+	err := j.Stream(ctx, func(doc schema.Document) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Stream reads from the io.Reader and calls fn with the document of every
+// record as soon as it is read, without keeping the input or the documents in
+// memory, except objects whose values are selected as records, which are read
+// whole. It stops at the first error returned by fn.
+func (j JSON) Stream(ctx context.Context, fn func(schema.Document) error) error {
+	records, err := parseJSONPath(j.records)
+	if err != nil {
+		return err
+	}
+	content, err := parseJSONPath(j.content)
+	if err != nil {
+		return err
+	}
+	metadata := make(map[string][]jsonPathSegment, len(j.metadata))
+	for key, path := range j.metadata {
+		if metadata[key], err = parseJSONPath(path); err != nil {
+			return err
+		}
+	}
+
+	numRecords := 0
+	emit := func(record any) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		numRecords++
+		doc, err := jsonDocument(record, content, metadata)
+		if err != nil {
+			return err
+		}
+		doc.Metadata["record"] = numRecords
+		return fn(doc)
+	}
+
+	if j.lines {
+		return streamJSONLines(j.r, records, emit)
+	}
+
+	dec := json.NewDecoder(j.r)
+	dec.UseNumber()
+	return streamJSONPath(dec, records, emit)
+}
+
+// LoadAndSplit reads json data from the io.Reader and splits it into multiple
+// documents using a text splitter.
+func (j JSON) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := j.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+func streamJSONLines(r io.Reader, records []jsonPathSegment, emit func(any) error) error {
+	rd := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := rd.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			dec := json.NewDecoder(bytes.NewReader(trimmed))
+			dec.UseNumber()
+			var value any
+			if decodeErr := dec.Decode(&value); decodeErr != nil {
+				return fmt.Errorf("line %d: %w", lineNumber, decodeErr)
+			}
+			for _, record := range evalJSONPath(normalizeJSON(value), records) {
+				if emitErr := emit(record); emitErr != nil {
+					return emitErr
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+func jsonDocument(record any, content []jsonPathSegment, metadata map[string][]jsonPathSegment) (schema.Document, error) {
+	texts := make([]string, 0)
+	for _, value := range evalJSONPath(record, content) {
+		text, err := jsonText(value)
+		if err != nil {
+			return schema.Document{}, err
+		}
+		texts = append(texts, text)
+	}
+
+	doc := schema.Document{
+		PageContent: strings.Join(texts, "\n"),
+		Metadata:    make(map[string]any, len(metadata)+1),
+	}
+	for key, path := range metadata {
+		switch values := evalJSONPath(record, path); len(values) {
+		case 0:
+		case 1:
+			doc.Metadata[key] = values[0]
+		default:
+			doc.Metadata[key] = values
+		}
+	}
+	return doc, nil
+}
+
+// jsonText returns strings as they are and other values as JSON.
+func jsonText(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	}
+	b, err := json.Marshal(value)
+	return string(b), err
+}
@@ -0,0 +1,147 @@
+package documentloaders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestJSONLoader(t *testing.T) {
+	t.Parallel()
+
+	input := `{
+  "exported": "2023-11-01",
+  "attachments": [{"name": "skipped.pdf", "size": 1024}],
+  "data": {
+    "tickets": [
+      {"id": 101, "title": "Login fails", "body": "I can't log in.", "tags": ["auth", "web"], "score": 0.5},
+      {"id": 102, "title": "Slow search", "body": "Search takes 10s.", "tags": [], "ticket owner": "ops"}
+    ]
+  }
+}`
+	loader := NewJSON(strings.NewReader(input),
+		WithJSONRecords(".data.tickets[]"),
+		WithJSONContent(".body"),
+		WithJSONMetadata(map[string]string{
+			"id":    ".id",
+			"tags":  ".tags[]",
+			"score": ".score",
+			"owner": `.["ticket owner"]`,
+		}),
+	)
+
+	docs, err := loader.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{
+			PageContent: "I can't log in.",
+			Metadata:    map[string]any{"id": 101, "tags": []any{"auth", "web"}, "score": 0.5, "record": 1},
+		},
+		{
+			PageContent: "Search takes 10s.",
+			Metadata:    map[string]any{"id": 102, "owner": "ops", "record": 2},
+		},
+	}, docs)
+}
+
+func TestJSONLoaderWholeRecord(t *testing.T) {
+	t.Parallel()
+
+	docs, err := NewJSON(strings.NewReader(`[{"q": "a", "n": 1}, "plain", {"q": "b"}]`),
+		WithJSONRecords(".[]")).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, `{"n":1,"q":"a"}`, docs[0].PageContent)
+	assert.Equal(t, "plain", docs[1].PageContent)
+
+	docs, err = NewJSON(strings.NewReader(`{"rows": [["x", 1], ["y", 2]]}`),
+		WithJSONRecords(".rows[1]"), WithJSONContent(".[0]")).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "y", docs[0].PageContent)
+}
+
+func TestJSONLinesLoader(t *testing.T) {
+	t.Parallel()
+
+	input := `{"question": "How do I reset my password?", "answer": "Use the reset link.", "category": "account"}
+
+{"question": "Where is my invoice?", "answer": "Under billing.", "category": "billing"}
+`
+	loader := NewJSON(strings.NewReader(input),
+		WithJSONLines(),
+		WithJSONContent(".answer"),
+		WithJSONMetadata(map[string]string{"question": ".question", "category": ".category"}),
+	)
+
+	var docs []schema.Document
+	err := loader.Stream(context.Background(), func(doc schema.Document) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "Under billing.", docs[1].PageContent)
+	assert.Equal(t, map[string]any{
+		"question": "Where is my invoice?", "category": "billing", "record": 2,
+	}, docs[1].Metadata)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = NewJSON(strings.NewReader(input), WithJSONLines()).Stream(context.Background(), func(schema.Document) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+
+	_, err = NewJSON(strings.NewReader("{\"a\": 1}\n{broken\n"), WithJSONLines()).Load(context.Background())
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestJSONLoaderObjectOrder(t *testing.T) {
+	t.Parallel()
+
+	// The values of objects are read in the sorted order of their keys, with
+	// and without JSON Lines.
+	input := `{"faq": {"b": {"q": "second", "tags": {"z": 1, "a": 2}}, "a": {"q": "first"}}}`
+	for _, lines := range []bool{false, true} {
+		opts := []JSONOptions{
+			WithJSONRecords(".faq[]"),
+			WithJSONContent(".q"),
+			WithJSONMetadata(map[string]string{"tags": ".tags[]"}),
+		}
+		if lines {
+			opts = append(opts, WithJSONLines())
+		}
+		docs, err := NewJSON(strings.NewReader(input), opts...).Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, []string{"first", "second"}, []string{docs[0].PageContent, docs[1].PageContent})
+		assert.Equal(t, []any{2, 1}, docs[1].Metadata["tags"])
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	t.Parallel()
+
+	segments, err := parseJSONPath(`.a["b.c"][2][].d`)
+	require.NoError(t, err)
+	assert.Equal(t, []jsonPathSegment{
+		{field: "a"}, {field: "b.c"}, {index: 2, isIndex: true}, {iterate: true}, {field: "d"},
+	}, segments)
+
+	segments, err = parseJSONPath(".")
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+
+	for _, path := range []string{"", "a", ".a.", ".a..b", ".a[", ".a[x]", `.["a"`, ".a]"} {
+		_, err := parseJSONPath(path)
+		require.ErrorIs(t, err, ErrInvalidJSONPath, path)
+	}
+}
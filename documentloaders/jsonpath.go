@@ -0,0 +1,266 @@
+package documentloaders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// ErrInvalidJSONPath is returned for path expressions that can't be parsed.
+var ErrInvalidJSONPath = errors.New("invalid json path")
+
+// jsonPathSegment is a step of a path: a field of an object, an index of an
+// array, or all the elements of an array or values of an object.
+type jsonPathSegment struct {
+	field   string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+// parseJSONPath parses a jq-style path like ".", ".data.items[]",
+// `.["a key"]` or ".rows[0].id".
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("%w %q: must start with '.'", ErrInvalidJSONPath, path)
+	}
+
+	segments := make([]jsonPathSegment, 0)
+	rest := path[1:]
+	// afterDot is true at the start of the path and after a '.', where a field
+	// name is allowed.
+	afterDot := true
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			if afterDot {
+				return nil, fmt.Errorf("%w %q: empty field", ErrInvalidJSONPath, path)
+			}
+			rest, afterDot = rest[1:], true
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if strings.HasPrefix(rest, `["`) {
+				field, n, err := parseQuotedField(rest[1:])
+				if err != nil || !strings.HasPrefix(rest[1+n:], "]") {
+					return nil, fmt.Errorf("%w %q: bad quoted field", ErrInvalidJSONPath, path)
+				}
+				segments = append(segments, jsonPathSegment{field: field})
+				rest, afterDot = rest[n+2:], false
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("%w %q: missing ']'", ErrInvalidJSONPath, path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if inner == "" {
+				segments = append(segments, jsonPathSegment{iterate: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("%w %q: bad index %q", ErrInvalidJSONPath, path, inner)
+				}
+				segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+			}
+			rest, afterDot = rest[end+1:], false
+		case afterDot:
+			end := strings.IndexAny(rest, ".[]")
+			if end < 0 {
+				end = len(rest)
+			}
+			segments = append(segments, jsonPathSegment{field: rest[:end]})
+			rest, afterDot = rest[end:], false
+		default:
+			return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidJSONPath, path, rest[0])
+		}
+	}
+	if afterDot && len(path) > 1 {
+		return nil, fmt.Errorf("%w %q: trailing '.'", ErrInvalidJSONPath, path)
+	}
+
+	return segments, nil
+}
+
+// parseQuotedField returns the json string at the start of s and its length.
+func parseQuotedField(s string) (string, int, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	var field string
+	if err := dec.Decode(&field); err != nil {
+		return "", 0, err
+	}
+	return field, int(dec.InputOffset()), nil
+}
+
+// evalJSONPath returns the values at the path of a decoded json value.
+// Missing fields and indexes have no values, and the values of objects are
+// iterated in the sorted order of their keys, the order json.Marshal writes
+// them in, not the order of the input.
+func evalJSONPath(value any, path []jsonPathSegment) []any {
+	if len(path) == 0 {
+		return []any{value}
+	}
+
+	segment, rest := path[0], path[1:]
+	switch v := value.(type) {
+	case map[string]any:
+		if segment.iterate {
+			values := make([]any, 0, len(v))
+			keys := maps.Keys(v)
+			slices.Sort(keys)
+			for _, key := range keys {
+				values = append(values, evalJSONPath(v[key], rest)...)
+			}
+			return values
+		}
+		if child, ok := v[segment.field]; ok && !segment.isIndex {
+			return evalJSONPath(child, rest)
+		}
+	case []any:
+		if segment.iterate {
+			values := make([]any, 0, len(v))
+			for _, child := range v {
+				values = append(values, evalJSONPath(child, rest)...)
+			}
+			return values
+		}
+		if segment.isIndex && segment.index < len(v) {
+			return evalJSONPath(v[segment.index], rest)
+		}
+	}
+	return nil
+}
+
+// streamJSONPath decodes the values at the path one at a time, skipping the
+// rest of the input without decoding it, so only one value is in memory.
+// Objects whose values are iterated are decoded whole, so their values are
+// emitted in the same order as evalJSONPath does.
+func streamJSONPath(dec *json.Decoder, path []jsonPathSegment, emit func(any) error) error {
+	if len(path) == 0 {
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		return emit(normalizeJSON(value))
+	}
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		// A scalar has no fields or elements.
+		return nil
+	}
+
+	segment, rest := path[0], path[1:]
+	if delim == '{' && segment.iterate {
+		object, err := decodeJSONObject(dec)
+		if err != nil {
+			return err
+		}
+		for _, value := range evalJSONPath(normalizeJSON(object), path) {
+			if err := emit(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := 0; dec.More(); i++ {
+		match := segment.iterate
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			match = match || (!segment.isIndex && key == segment.field)
+		} else {
+			match = match || (segment.isIndex && i == segment.index)
+		}
+
+		if match {
+			err = streamJSONPath(dec, rest, emit)
+		} else {
+			err = skipJSONValue(dec)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// The closing delimiter.
+	_, err = dec.Token()
+	return err
+}
+
+// decodeJSONObject decodes the rest of an object whose opening delimiter was
+// read.
+func decodeJSONObject(dec *json.Decoder) (map[string]any, error) {
+	object := make(map[string]any)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		object[key.(string)] = value //nolint:forcetypeassert
+	}
+
+	// The closing delimiter.
+	_, err := dec.Token()
+	return object, err
+}
+
+// skipJSONValue reads the next value without decoding it.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// normalizeJSON converts the json.Number values of a value decoded with
+// UseNumber to int if they are integers and to float64 otherwise.
+func normalizeJSON(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 0); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, child := range v {
+			v[key] = normalizeJSON(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = normalizeJSON(child)
+		}
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"gopkg.in/yaml.v3"
+)
+
+// Markdown loads markdown data from an io.Reader.
+type Markdown struct {
+	r io.Reader
+}
+
+var _ Loader = Markdown{}
+
+// NewMarkdown creates a new markdown loader with an io.Reader.
+func NewMarkdown(r io.Reader) Markdown {
+	return Markdown{
+		r: r,
+	}
+}
+
+// Load reads from the io.Reader and returns a single document with the
+// markdown. A YAML front matter is removed from the content and its fields are
+// added to the metadata. If there is no "title" field, the first level 1
+// header is used as the title.
+func (m Markdown) Load(_ context.Context) ([]schema.Document, error) {
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, m.r); err != nil {
+		return nil, err
+	}
+
+	content, metadata := splitFrontMatter(buf.String())
+	if _, ok := metadata["title"]; !ok {
+		if title := markdownTitle(content); title != "" {
+			metadata["title"] = title
+		}
+	}
+
+	return []schema.Document{
+		{
+			PageContent: content,
+			Metadata:    metadata,
+		},
+	}, nil
+}
+
+// LoadAndSplit reads markdown from the io.Reader and splits it into multiple
+// documents using a text splitter, like textsplitter.MarkdownHeader.
+func (m Markdown) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := m.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// splitFrontMatter returns the content after a YAML front matter delimited by
+// "---" lines and the fields of the front matter. Content without a valid
+// front matter is returned unchanged.
+func splitFrontMatter(text string) (string, map[string]any) {
+	metadata := map[string]any{}
+
+	normalized := strings.ReplaceAll(text, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return text, metadata
+	}
+
+	rest := normalized[len("---\n"):]
+	end := strings.Index(rest, "\n---\n")
+	var frontMatter, content string
+	switch {
+	case end >= 0:
+		frontMatter, content = rest[:end], rest[end+len("\n---\n"):]
+	case strings.HasSuffix(rest, "\n---"):
+		frontMatter = strings.TrimSuffix(rest, "\n---")
+	default:
+		return text, metadata
+	}
+
+	if err := yaml.Unmarshal([]byte(frontMatter), &metadata); err != nil {
+		return text, map[string]any{}
+	}
+	return strings.TrimLeft(content, "\n"), metadata
+}
+
+// markdownTitle returns the text of the first level 1 header outside of code
+// blocks.
+func markdownTitle(content string) string {
+	inCode := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || !strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+		title := strings.TrimSpace(trimmed[2:])
+		// Remove an optional closing sequence, like in "# Title #".
+		if i := strings.LastIndex(title, " "); i >= 0 && strings.Trim(title[i+1:], "#") == "" {
+			title = strings.TrimSpace(title[:i])
+		}
+		return title
+	}
+	return ""
+}
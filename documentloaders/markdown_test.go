@@ -0,0 +1,44 @@
+package documentloaders
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+func TestMarkdownLoader(t *testing.T) {
+	t.Parallel()
+
+	text := "---\nauthor: 人事部\ntags: [年假, 制度]\n---\n\n# 员工手册 #\n\n## 年假\n\n每年五天。\n\n## 报销\n\n提交发票。\n"
+	docs, err := NewMarkdown(strings.NewReader(text)).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "# 员工手册 #\n\n## 年假\n\n每年五天。\n\n## 报销\n\n提交发票。\n", docs[0].PageContent)
+	assert.Equal(t, map[string]any{
+		"author": "人事部",
+		"tags":   []any{"年假", "制度"},
+		"title":  "员工手册",
+	}, docs[0].Metadata)
+
+	docs, err = NewMarkdown(strings.NewReader(text)).LoadAndSplit(context.Background(), textsplitter.NewMarkdownHeader())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "## 报销\n\n提交发票。", docs[1].PageContent)
+	assert.Equal(t, "人事部", docs[1].Metadata["author"])
+	assert.Equal(t, "报销", docs[1].Metadata["h2"])
+	assert.Equal(t, "员工手册", docs[1].Metadata["h1"])
+}
+
+func TestMarkdownLoaderWithoutFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	text := "---\n\n```go\n# C#\n```\n\n# C# 入门"
+	docs, err := NewMarkdown(strings.NewReader(text)).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, text, docs[0].PageContent)
+	assert.Equal(t, map[string]any{"title": "C# 入门"}, docs[0].Metadata)
+}
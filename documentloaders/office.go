@@ -0,0 +1,160 @@
+package documentloaders
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrMissingPart is returned when a part, like the document body of a .docx
+	// file, is missing from an Office file.
+	ErrMissingPart = errors.New("missing part in office file")
+	// ErrInvalidOfficeFile is returned when a part of an Office file has
+	// invalid content.
+	ErrInvalidOfficeFile = errors.New("invalid office file")
+)
+
+// officeFile is an Office Open XML file, which is a zip archive of XML parts.
+type officeFile struct {
+	files map[string]*zip.File
+}
+
+func openOfficeFile(r io.ReaderAt, size int64) (*officeFile, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	return &officeFile{files: files}, nil
+}
+
+// has reports whether the part exists.
+func (o *officeFile) has(name string) bool {
+	_, ok := o.files[name]
+	return ok
+}
+
+// open opens a part by its name in the archive.
+func (o *officeFile) open(name string) (io.ReadCloser, error) {
+	f, ok := o.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPart, name)
+	}
+	return f.Open()
+}
+
+// decode unmarshals a part into v.
+func (o *officeFile) decode(name string, v any) error {
+	rc, err := o.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+type officeRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// relationships returns the targets of the relationships of a part by their
+// ids. Targets are resolved to part names in the archive. A part without
+// relationships has none.
+func (o *officeFile) relationships(part string) (map[string]string, map[string]string, error) {
+	dir, file := path.Split(part)
+	relsPart := path.Join(dir, "_rels", file+".rels")
+
+	targets := make(map[string]string)
+	types := make(map[string]string)
+	if !o.has(relsPart) {
+		return targets, types, nil
+	}
+
+	var rels officeRelationships
+	if err := o.decode(relsPart, &rels); err != nil {
+		return nil, nil, err
+	}
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(dir, target)
+		}
+		targets[rel.ID] = target
+		types[rel.ID] = rel.Type
+	}
+	return targets, types, nil
+}
+
+// attr returns the value of the attribute with the local name, or "".
+func attr(start xml.StartElement, local string) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// walkElements calls fn for the start of every element nested in the element
+// whose start was last read from the decoder, until its end. If fn returns
+// true it has consumed the element including its end, otherwise the elements
+// nested in it are walked too. On a new decoder the whole document is walked.
+func walkElements(dec *xml.Decoder, fn func(start xml.StartElement) (bool, error)) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			consumed, err := fn(t)
+			if err != nil {
+				return err
+			}
+			if !consumed {
+				depth++
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+// mainPart returns the name of the main part of an Office file, given by the
+// package relationships, or the fallback if there are none.
+func (o *officeFile) mainPart(fallback string) (string, error) {
+	targets, types, err := o.relationships("")
+	if err != nil {
+		return "", err
+	}
+	for id, typ := range types {
+		if strings.HasSuffix(typ, "/officeDocument") {
+			return targets[id], nil
+		}
+	}
+	return fallback, nil
+}
@@ -2,18 +2,32 @@ package documentloaders
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/ledongthuc/pdf"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/textsplitter"
 )
 
+// OCR recognizes the text of pdf pages without a text layer, like scanned
+// pages.
+type OCR interface {
+	// RecognizePDFPage returns the text of the page, numbered from 1, of the
+	// pdf file. The whole file is passed for every page, so OCR services
+	// taking a file and a page number upload it once per page.
+	RecognizePDFPage(ctx context.Context, pdf []byte, page int) (string, error)
+}
+
 // PDF loads text data from an io.Reader.
 type PDF struct {
 	r        io.ReaderAt
 	s        int64
 	password string
+	layout   bool
+	ocr      OCR
 }
 
 var _ Loader = PDF{}
@@ -28,7 +42,28 @@ func WithPassword(password string) PDFOptions {
 	}
 }
 
-// NewText creates a new text loader with an io.Reader.
+// WithLayout extracts the text of the pages in reading order from the
+// positions of the glyphs instead of the order of the content stream. Lines
+// are read top to bottom, the columns of multi-column pages are read one after
+// the other and simple tables are rendered as markdown tables.
+func WithLayout() PDFOptions {
+	return func(pdf *PDF) {
+		pdf.layout = true
+	}
+}
+
+// WithOCR sets the OCR used for pages without text. Pages with images but no
+// text have the metadata "image_only" set to true, and pages read with the
+// OCR have the metadata "ocr" set to true. The OCR is called once per page
+// without text, with the whole pdf file, which is costly for large scanned
+// files.
+func WithOCR(ocr OCR) PDFOptions {
+	return func(pdf *PDF) {
+		pdf.ocr = ocr
+	}
+}
+
+// NewPDF creates a new pdf loader with an io.ReaderAt and the size of the pdf.
 func NewPDF(r io.ReaderAt, size int64, opts ...PDFOptions) PDF {
 	pdf := PDF{
 		r: r,
@@ -51,7 +86,7 @@ func (p *PDF) getPassword() string {
 
 // Load reads from the io.Reader for the PDF data and returns the documents with the data and with
 // metadata attached of the page number and total number of pages of the PDF.
-func (p PDF) Load(_ context.Context) ([]schema.Document, error) {
+func (p PDF) Load(ctx context.Context) ([]schema.Document, error) {
 	var reader *pdf.Reader
 	var err error
 
@@ -73,34 +108,90 @@ func (p PDF) Load(_ context.Context) ([]schema.Document, error) {
 
 	// fonts to be used when getting plain text from pages
 	fonts := make(map[string]*pdf.Font)
+	// data is the pdf file, read for the OCR.
+	var data []byte
 	for i := 1; i < numPages+1; i++ {
-		p := reader.Page(i)
+		page := reader.Page(i)
 		// add fonts to map
-		for _, name := range p.Fonts() {
+		for _, name := range page.Fonts() {
 			// only add the font if we don't already have it
 			if _, ok := fonts[name]; !ok {
-				f := p.Font(name)
+				f := page.Font(name)
 				fonts[name] = &f
 			}
 		}
-		text, err := p.GetPlainText(fonts)
+		text, err := p.pageText(page, fonts)
 		if err != nil {
 			return nil, err
 		}
 
+		metadata := map[string]any{
+			"page":        i,
+			"total_pages": numPages,
+		}
+		if strings.TrimSpace(text) == "" {
+			if pageHasImages(page.Resources(), 0) {
+				metadata["image_only"] = true
+			}
+			if p.ocr != nil {
+				if data == nil {
+					data, err = io.ReadAll(io.NewSectionReader(p.r, 0, p.s))
+					if err != nil {
+						return nil, err
+					}
+				}
+				text, err = p.ocr.RecognizePDFPage(ctx, data, i)
+				if err != nil {
+					return nil, fmt.Errorf("ocr page %d: %w", i, err)
+				}
+				metadata["ocr"] = true
+			}
+		}
+
 		// add the document to the doc list
 		docs = append(docs, schema.Document{
 			PageContent: text,
-			Metadata: map[string]any{
-				"page":        i,
-				"total_pages": numPages,
-			},
+			Metadata:    metadata,
 		})
 	}
 
 	return docs, nil
 }
 
+// pageText returns the text of the page, in reading order with WithLayout.
+func (p PDF) pageText(page pdf.Page, fonts map[string]*pdf.Font) (text string, err error) {
+	if !p.layout {
+		return page.GetPlainText(fonts)
+	}
+
+	// Content panics on malformed content streams, like GetPlainText recovers.
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", errors.New(fmt.Sprint(r))
+		}
+	}()
+	return layoutText(page.Content().Text), nil
+}
+
+// pageHasImages reports whether the resources of a page, or of the forms it
+// draws, have images.
+func pageHasImages(resources pdf.Value, depth int) bool {
+	const maxDepth = 4
+	xobjects := resources.Key("XObject")
+	for _, name := range xobjects.Keys() {
+		xobject := xobjects.Key(name)
+		switch xobject.Key("Subtype").Name() {
+		case "Image":
+			return true
+		case "Form":
+			if depth < maxDepth && pageHasImages(xobject.Key("Resources"), depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // LoadAndSplit reads pdf data from the io.Reader and splits it into multiple
 // documents using a text splitter.
 func (p PDF) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
@@ -0,0 +1,300 @@
+package documentloaders
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/tmc/langchaingo/internal/htmlmd"
+)
+
+const (
+	_defaultPDFFontSize = 10
+	// _pdfSegmentGap is the horizontal gap, in font sizes, that separates two
+	// segments of a line, like two columns or two table cells.
+	_pdfSegmentGap = 1.5
+	// _pdfWordGap is the horizontal gap, in font sizes, that separates two
+	// words of a segment.
+	_pdfWordGap = 0.15
+	// _pdfParagraphGap is the vertical distance, in font sizes, between two
+	// lines of different paragraphs.
+	_pdfParagraphGap = 2
+	// _pdfMaxCellLength is the maximum average length of the cells of a table.
+	// Longer cells are columns of text.
+	_pdfMaxCellLength = 30
+	// _pdfMinColumnLines is the minimum number of lines with text on both sides
+	// of a gutter for the page to have columns.
+	_pdfMinColumnLines = 2
+)
+
+// pdfSegment is a run of text of a line without large gaps.
+type pdfSegment struct {
+	x0, x1 float64
+	text   string
+}
+
+// pdfLine is a line of text made of segments sorted left to right.
+type pdfLine struct {
+	y        float64
+	fontSize float64
+	segments []pdfSegment
+}
+
+// layoutText returns the text of the page in reading order: lines are read
+// top to bottom, columns are read one after the other and tables are rendered
+// as markdown tables.
+func layoutText(texts []pdf.Text) string {
+	blocks := layoutBlocks(pdfLines(texts))
+	return strings.Join(blocks, "\n\n")
+}
+
+// pdfLines groups the glyphs into lines sorted top to bottom.
+func pdfLines(texts []pdf.Text) []pdfLine {
+	glyphs := make([]pdf.Text, 0, len(texts))
+	for _, t := range texts {
+		if t.S == "" {
+			continue
+		}
+		if t.FontSize <= 0 {
+			t.FontSize = _defaultPDFFontSize
+		}
+		glyphs = append(glyphs, t)
+	}
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].Y > glyphs[j].Y
+	})
+
+	rows := make([][]pdf.Text, 0)
+	for _, g := range glyphs {
+		if n := len(rows); n > 0 && math.Abs(rows[n-1][0].Y-g.Y) < rows[n-1][0].FontSize/2 {
+			rows[n-1] = append(rows[n-1], g)
+			continue
+		}
+		rows = append(rows, []pdf.Text{g})
+	}
+
+	lines := make([]pdfLine, 0, len(rows))
+	for _, row := range rows {
+		if line, ok := pdfLineFromGlyphs(row); ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func pdfLineFromGlyphs(glyphs []pdf.Text) (pdfLine, bool) {
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].X < glyphs[j].X
+	})
+
+	line := pdfLine{y: glyphs[0].Y, fontSize: glyphs[0].FontSize}
+	var sb strings.Builder
+	var current pdfSegment
+	end := math.Inf(-1)
+	flush := func() {
+		current.text = strings.Join(strings.Fields(sb.String()), " ")
+		if current.text != "" {
+			line.segments = append(line.segments, current)
+		}
+		sb.Reset()
+	}
+
+	for _, g := range glyphs {
+		gap := g.X - end
+		switch {
+		case sb.Len() == 0:
+			current = pdfSegment{x0: g.X}
+		case gap > _pdfSegmentGap*g.FontSize:
+			flush()
+			current = pdfSegment{x0: g.X}
+		case gap > _pdfWordGap*g.FontSize:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(g.S)
+		end = g.X + g.W
+		if strings.TrimSpace(g.S) != "" {
+			current.x1 = end
+		}
+	}
+	flush()
+
+	return line, len(line.segments) > 0
+}
+
+// layoutBlocks returns the blocks of text of the lines in reading order.
+func layoutBlocks(lines []pdfLine) []string {
+	blocks := make([]string, 0)
+	text := make([]pdfLine, 0)
+	flushText := func() {
+		if len(text) > 0 {
+			blocks = append(blocks, columnBlocks(text)...)
+			text = text[:0]
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		if n := tableLength(lines[i:]); n > 0 {
+			flushText()
+			blocks = append(blocks, tableBlock(lines[i:i+n]))
+			i += n
+			continue
+		}
+		text = append(text, lines[i])
+		i++
+	}
+	flushText()
+
+	return blocks
+}
+
+// tableLength returns the number of lines at the start that form a table: two
+// or more lines with short segments that overlap the two or more segments of
+// the first line.
+func tableLength(lines []pdfLine) int {
+	numColumns := len(lines[0].segments)
+	if numColumns < 2 {
+		return 0
+	}
+
+	n, cells, length := 1, numColumns, 0
+	for _, s := range lines[0].segments {
+		length += utf8.RuneCountInString(s.text)
+	}
+	for ; n < len(lines) && alignedSegments(lines[0], lines[n]); n++ {
+		cells += numColumns
+		for _, s := range lines[n].segments {
+			length += utf8.RuneCountInString(s.text)
+		}
+	}
+
+	if n < 2 || length/cells > _pdfMaxCellLength {
+		return 0
+	}
+	return n
+}
+
+func alignedSegments(a, b pdfLine) bool {
+	if len(a.segments) != len(b.segments) {
+		return false
+	}
+	for i := range a.segments {
+		if b.segments[i].x0 > a.segments[i].x1 || b.segments[i].x1 < a.segments[i].x0 {
+			return false
+		}
+	}
+	return true
+}
+
+func tableBlock(lines []pdfLine) string {
+	rows := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		row := make([]string, 0, len(line.segments))
+		for _, s := range line.segments {
+			row = append(row, s.text)
+		}
+		rows = append(rows, row)
+	}
+	return htmlmd.Table(rows)
+}
+
+// columnBlocks returns the blocks of text of the lines, reading the lines on
+// the left of a gutter before the lines on the right. Lines that cross the
+// gutter, like titles, are read in between.
+func columnBlocks(lines []pdfLine) []string {
+	gutter, ok := findGutter(lines)
+	if !ok {
+		return []string{joinLines(lines)}
+	}
+
+	blocks := make([]string, 0)
+	var left, right []pdfLine
+	flushColumns := func() {
+		if len(left) > 0 {
+			blocks = append(blocks, layoutBlocks(left)...)
+		}
+		if len(right) > 0 {
+			blocks = append(blocks, layoutBlocks(right)...)
+		}
+		left, right = nil, nil
+	}
+
+	for _, line := range lines {
+		if crossesGutter(line, gutter) {
+			flushColumns()
+			blocks = append(blocks, joinLines([]pdfLine{line}))
+			continue
+		}
+
+		l, r := line, line
+		l.segments, r.segments = nil, nil
+		for _, s := range line.segments {
+			if s.x1 <= gutter {
+				l.segments = append(l.segments, s)
+			} else {
+				r.segments = append(r.segments, s)
+			}
+		}
+		if len(l.segments) > 0 {
+			left = append(left, l)
+		}
+		if len(r.segments) > 0 {
+			right = append(right, r)
+		}
+	}
+	flushColumns()
+
+	return blocks
+}
+
+// findGutter returns the x coordinate of the gutter between two columns: the
+// start of a segment that most lines have text on both sides of.
+func findGutter(lines []pdfLine) (float64, bool) {
+	best, bestCount := 0.0, 0
+	for _, line := range lines {
+		for _, s := range line.segments[1:] {
+			count := 0
+			for _, other := range lines {
+				if !crossesGutter(other, s.x0) && other.segments[0].x1 <= s.x0 &&
+					other.segments[len(other.segments)-1].x0 >= s.x0 {
+					count++
+				}
+			}
+			if count > bestCount {
+				best, bestCount = s.x0, count
+			}
+		}
+	}
+	return best, bestCount >= _pdfMinColumnLines
+}
+
+func crossesGutter(line pdfLine, gutter float64) bool {
+	for _, s := range line.segments {
+		if s.x0 < gutter && s.x1 > gutter {
+			return true
+		}
+	}
+	return false
+}
+
+// joinLines returns the text of the lines, with a blank line between lines
+// that are further apart than _pdfParagraphGap font sizes.
+func joinLines(lines []pdfLine) string {
+	var sb strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteString("\n")
+			if lines[i-1].y-line.y > _pdfParagraphGap*line.fontSize {
+				sb.WriteString("\n")
+			}
+		}
+		for j, s := range line.segments {
+			if j > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(s.text)
+		}
+	}
+	return sb.String()
+}
@@ -1,12 +1,17 @@
 package documentloaders
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ledongthuc/pdf"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/textsplitter"
 )
 
@@ -137,3 +142,125 @@ func TestPDFTextSplit(t *testing.T) {
 		}
 	})
 }
+
+// buildPDF returns a pdf with a page for every content stream. The pages use
+// the font F1, with every glyph 500 units wide, and the pages drawing the image
+// Im1 have it in their resources.
+func buildPDF(t *testing.T, contents ...string) []byte {
+	t.Helper()
+
+	widths := strings.TrimSpace(strings.Repeat("500 ", 95))
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // pages
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /FirstChar 32 /LastChar 126 /Widths [" + widths + "] >>",
+		"<< /Type /XObject /Subtype /Image /Width 1 /Height 1 /ColorSpace /DeviceGray /BitsPerComponent 8 /Length 1 >>\nstream\n\x00\nendstream", //nolint:lll
+	}
+	kids := make([]string, 0, len(contents))
+	for _, content := range contents {
+		page := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+		xobjects := ""
+		if strings.Contains(content, "/Im1") {
+			xobjects = "/XObject << /Im1 4 0 R >>"
+		}
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents %d 0 R "+
+				"/Resources << /Font << /F1 3 0 R >> %s >> >>", page+1, xobjects),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(contents))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfText returns a content stream showing the texts at the positions.
+func pdfText(texts ...any) string {
+	var sb strings.Builder
+	for i := 0; i+2 < len(texts); i += 3 {
+		fmt.Fprintf(&sb, "BT /F1 10 Tf 1 0 0 1 %d %d Tm (%s) Tj ET\n", texts[i], texts[i+1], texts[i+2])
+	}
+	return sb.String()
+}
+
+type fakeOCR struct {
+	pages []int
+}
+
+func (o *fakeOCR) RecognizePDFPage(_ context.Context, data []byte, page int) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return "", errors.New("not a pdf")
+	}
+	o.pages = append(o.pages, page)
+	return fmt.Sprintf("scanned page %d", page), nil
+}
+
+func TestPDFLayout(t *testing.T) {
+	t.Parallel()
+
+	data := buildPDF(t, pdfText(
+		72, 740, "Quarterly Report For The Whole Company",
+		72, 700, "The left column starts here and it goes",
+		72, 688, "on until the end of this second line.",
+		320, 700, "The right column is only read after the",
+		320, 688, "whole left column, not line by line.",
+		72, 640, "Name",
+		200, 640, "Units",
+		320, 640, "Price",
+		72, 628, "Apple",
+		200, 628, "10",
+		320, 628, "1.50",
+		72, 616, "Pear",
+		200, 616, "4",
+		320, 616, "2.00",
+	))
+
+	docs, err := NewPDF(bytes.NewReader(data), int64(len(data)), WithLayout()).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "Quarterly Report For The Whole Company\n\n"+
+		"The left column starts here and it goes\non until the end of this second line.\n\n"+
+		"The right column is only read after the\nwhole left column, not line by line.\n\n"+
+		"| Name | Units | Price |\n| --- | --- | --- |\n| Apple | 10 | 1.50 |\n| Pear | 4 | 2.00 |",
+		docs[0].PageContent)
+	assert.Equal(t, map[string]any{"page": 1, "total_pages": 1}, docs[0].Metadata)
+}
+
+func TestPDFOCR(t *testing.T) {
+	t.Parallel()
+
+	data := buildPDF(t,
+		pdfText(72, 700, "Text page"),
+		"q 612 0 0 792 0 0 cm /Im1 Do Q",
+		"",
+	)
+
+	docs, err := NewPDF(bytes.NewReader(data), int64(len(data))).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, map[string]any{"page": 2, "total_pages": 3, "image_only": true}, docs[1].Metadata)
+	assert.Equal(t, map[string]any{"page": 3, "total_pages": 3}, docs[2].Metadata)
+
+	ocr := &fakeOCR{}
+	docs, err = NewPDF(bytes.NewReader(data), int64(len(data)), WithOCR(ocr), WithLayout()).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, ocr.pages)
+	assert.Equal(t, "Text page", docs[0].PageContent)
+	assert.Equal(t, "scanned page 2", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"page": 2, "total_pages": 3, "image_only": true, "ocr": true}, docs[1].Metadata)
+	assert.Equal(t, map[string]any{"page": 3, "total_pages": 3, "ocr": true}, docs[2].Metadata)
+}
@@ -0,0 +1,242 @@
+package documentloaders
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/tmc/langchaingo/internal/htmlmd"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// _pptxSkippedPlaceholders are the placeholders of slides and notes that hold
+// no content, like footers and slide numbers.
+var _pptxSkippedPlaceholders = map[string]bool{ //nolint:gochecknoglobals
+	"dt":     true,
+	"ftr":    true,
+	"hdr":    true,
+	"sldNum": true,
+	"sldImg": true,
+}
+
+// PPTX loads text data from a PowerPoint .pptx file.
+type PPTX struct {
+	r io.ReaderAt
+	s int64
+}
+
+var _ Loader = PPTX{}
+
+// NewPPTX creates a new pptx loader with an io.ReaderAt and the size of the file.
+func NewPPTX(r io.ReaderAt, size int64) PPTX {
+	return PPTX{
+		r: r,
+		s: size,
+	}
+}
+
+// Load reads the pptx file and returns a document per slide with text. Slide
+// titles are formatted as markdown headings and tables as markdown tables.
+// The speaker notes of a slide are added after its content. The documents
+// have the slide number and the total number of slides as metadata.
+func (p PPTX) Load(_ context.Context) ([]schema.Document, error) {
+	f, err := openOfficeFile(p.r, p.s)
+	if err != nil {
+		return nil, err
+	}
+
+	presentationPart, err := f.mainPart("ppt/presentation.xml")
+	if err != nil {
+		return nil, err
+	}
+	slideIDs, err := pptxSlideIDs(f, presentationPart)
+	if err != nil {
+		return nil, err
+	}
+	targets, _, err := f.relationships(presentationPart)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []schema.Document
+	for i, id := range slideIDs {
+		slidePart := targets[id]
+		blocks, err := pptxText(f, slidePart)
+		if err != nil {
+			return nil, err
+		}
+
+		notes, err := pptxNotes(f, slidePart)
+		if err != nil {
+			return nil, err
+		}
+		if len(notes) > 0 {
+			blocks = append(blocks, "Notes:\n"+strings.Join(notes, "\n"))
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+
+		docs = append(docs, schema.Document{
+			PageContent: strings.Join(blocks, "\n\n"),
+			Metadata: map[string]any{
+				"slide":        i + 1,
+				"total_slides": len(slideIDs),
+			},
+		})
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit reads the pptx file and splits it into multiple documents
+// using a text splitter.
+func (p PPTX) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := p.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+// pptxSlideIDs returns the relationship ids of the slides in presentation
+// order. The slide elements have both an id and a relationship id attribute
+// with the same local name, so the relationship id is the one with a namespace.
+func pptxSlideIDs(f *officeFile, presentationPart string) ([]string, error) {
+	rc, err := f.open(presentationPart)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var ids []string
+	err = walkElements(xml.NewDecoder(rc), func(start xml.StartElement) (bool, error) {
+		if start.Name.Local != "sldId" {
+			return false, nil
+		}
+		for _, a := range start.Attr {
+			if a.Name.Local == "id" && a.Name.Space != "" {
+				ids = append(ids, a.Value)
+			}
+		}
+		return false, nil
+	})
+
+	return ids, err
+}
+
+// pptxNotes returns the text of the notes of a slide.
+func pptxNotes(f *officeFile, slidePart string) ([]string, error) {
+	targets, types, err := f.relationships(slidePart)
+	if err != nil {
+		return nil, err
+	}
+	for id, typ := range types {
+		if strings.HasSuffix(typ, "/notesSlide") {
+			return pptxText(f, targets[id])
+		}
+	}
+	return nil, nil
+}
+
+// pptxText returns the text of the shapes and tables of a slide or notes part.
+func pptxText(f *officeFile, part string) ([]string, error) {
+	rc, err := f.open(part)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var blocks []string
+	dec := xml.NewDecoder(rc)
+	err = walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "sp":
+			placeholder, paragraphs, err := parsePPTXShape(dec)
+			if err != nil || len(paragraphs) == 0 || _pptxSkippedPlaceholders[placeholder] {
+				return true, err
+			}
+			text := strings.Join(paragraphs, "\n")
+			if placeholder == "title" || placeholder == "ctrTitle" {
+				text = "# " + strings.ReplaceAll(text, "\n", " ")
+			}
+			blocks = append(blocks, text)
+			return true, nil
+		case "tbl":
+			rows, err := parsePPTXTable(dec)
+			if table := htmlmd.Table(rows); table != "" {
+				blocks = append(blocks, table)
+			}
+			return true, err
+		}
+		return false, nil
+	})
+
+	return blocks, err
+}
+
+// parsePPTXShape reads the placeholder type and the non empty paragraphs of
+// the shape whose start was last read from the decoder.
+func parsePPTXShape(dec *xml.Decoder) (string, []string, error) {
+	var placeholder string
+	var paragraphs []string
+	err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "ph":
+			placeholder = attr(start, "type")
+		case "p":
+			text, err := parsePPTXParagraph(dec)
+			if text = strings.TrimSpace(text); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+			return true, err
+		}
+		return false, nil
+	})
+
+	return placeholder, paragraphs, err
+}
+
+// parsePPTXTable reads the rows of the table whose start was last read from
+// the decoder.
+func parsePPTXTable(dec *xml.Decoder) ([][]string, error) {
+	var rows [][]string
+	err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "tr":
+			rows = append(rows, nil)
+		case "tc":
+			_, paragraphs, err := parsePPTXShape(dec)
+			if len(rows) > 0 {
+				rows[len(rows)-1] = append(rows[len(rows)-1], strings.Join(paragraphs, " "))
+			}
+			return true, err
+		}
+		return false, nil
+	})
+
+	return rows, err
+}
+
+// parsePPTXParagraph reads the text of the paragraph whose start was last
+// read from the decoder.
+func parsePPTXParagraph(dec *xml.Decoder) (string, error) {
+	var sb strings.Builder
+	err := walkElements(dec, func(start xml.StartElement) (bool, error) {
+		switch start.Name.Local {
+		case "t":
+			var text string
+			err := dec.DecodeElement(&text, &start)
+			sb.WriteString(text)
+			return true, err
+		case "br":
+			sb.WriteString("\n")
+		}
+		return false, nil
+	})
+
+	return sb.String(), err
+}
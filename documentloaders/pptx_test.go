@@ -0,0 +1,78 @@
+package documentloaders
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _pptxRelationships = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">%s</Relationships>`
+
+func pptxShape(placeholder string, paragraphs ...string) string {
+	ph := ""
+	if placeholder != "" {
+		ph = fmt.Sprintf(`<p:ph type="%s"/>`, placeholder)
+	}
+	body := ""
+	for _, p := range paragraphs {
+		body += "<a:p><a:r><a:t>" + p + "</a:t></a:r></a:p>"
+	}
+	return `<p:sp><p:nvSpPr><p:nvPr>` + ph + `</p:nvPr></p:nvSpPr><p:txBody>` + body + `</p:txBody></p:sp>`
+}
+
+func pptxSlide(content string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"
+ xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree>` +
+		content + `</p:spTree></p:cSld></p:sld>`
+}
+
+func TestPPTXLoader(t *testing.T) {
+	t.Parallel()
+
+	r, size := officeZip(t, map[string]string{
+		"_rels/.rels": fmt.Sprintf(_officeRootRels, "ppt/presentation.xml"),
+		"ppt/presentation.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+ xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<p:sldIdLst><p:sldId id="257" r:id="rId3"/><p:sldId id="256" r:id="rId2"/><p:sldId id="258" r:id="rId4"/></p:sldIdLst>
+</p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": fmt.Sprintf(_pptxRelationships, `
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide2.xml"/>
+<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide3.xml"/>`),
+		"ppt/slides/slide1.xml": pptxSlide(
+			pptxShape("title", "年假制度") +
+				pptxShape("body", "每年五天", "提前申请") +
+				pptxShape("sldNum", "2") +
+				`<p:graphicFrame><a:graphic><a:graphicData><a:tbl>` +
+				`<a:tr><a:tc><a:txBody><a:p><a:r><a:t>工龄</a:t></a:r></a:p></a:txBody></a:tc>` +
+				`<a:tc><a:txBody><a:p><a:r><a:t>天数</a:t></a:r></a:p></a:txBody></a:tc></a:tr>` +
+				`<a:tr><a:tc><a:txBody><a:p><a:r><a:t>十年</a:t></a:r></a:p></a:txBody></a:tc>` +
+				`<a:tc><a:txBody><a:p><a:r><a:t>10</a:t></a:r></a:p></a:txBody></a:tc></a:tr>` +
+				`</a:tbl></a:graphicData></a:graphic></p:graphicFrame>`),
+		"ppt/slides/_rels/slide1.xml.rels": fmt.Sprintf(_pptxRelationships, `
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>`),
+		"ppt/notesSlides/notesSlide1.xml": pptxSlide(
+			pptxShape("sldImg") + pptxShape("body", "强调法定节假日不计入年假") + pptxShape("sldNum", "2")),
+		"ppt/slides/slide2.xml": pptxSlide(pptxShape("ctrTitle", "员工培训") +
+			`<p:sp><p:txBody><a:p><a:r><a:t>第一行</a:t></a:r><a:br/><a:r><a:t>第二行</a:t></a:r></a:p></p:txBody></p:sp>`),
+		"ppt/slides/slide3.xml": pptxSlide(pptxShape("sldNum", "3")),
+	})
+
+	docs, err := NewPPTX(r, size).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "# 员工培训\n\n第一行\n第二行", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"slide": 1, "total_slides": 3}, docs[0].Metadata)
+
+	assert.Equal(t, "# 年假制度\n\n每年五天\n提前申请\n\n"+
+		"| 工龄 | 天数 |\n| --- | --- |\n| 十年 | 10 |\n\n"+
+		"Notes:\n强调法定节假日不计入年假", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"slide": 2, "total_slides": 3}, docs[1].Metadata)
+}
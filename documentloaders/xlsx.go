@@ -0,0 +1,292 @@
+package documentloaders
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/internal/htmlmd"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"golang.org/x/exp/slices"
+)
+
+// XLSX loads text data from an Excel .xlsx file.
+type XLSX struct {
+	r       io.ReaderAt
+	s       int64
+	rows    bool
+	columns []string
+	sheets  []string
+}
+
+var _ Loader = XLSX{}
+
+// XLSXOptions are options for the XLSX loader.
+type XLSXOptions func(xlsx *XLSX)
+
+// WithXLSXRows loads a document per row instead of a document per sheet. The
+// first row of a sheet is the header, and like with the CSV loader, the
+// content of a row is a "header: value" line per column. If columns are
+// given, only those columns are loaded.
+func WithXLSXRows(columns ...string) XLSXOptions {
+	return func(xlsx *XLSX) {
+		xlsx.rows = true
+		xlsx.columns = columns
+	}
+}
+
+// WithXLSXSheets loads only the sheets with the names.
+func WithXLSXSheets(names ...string) XLSXOptions {
+	return func(xlsx *XLSX) {
+		xlsx.sheets = names
+	}
+}
+
+// NewXLSX creates a new xlsx loader with an io.ReaderAt and the size of the file.
+func NewXLSX(r io.ReaderAt, size int64, opts ...XLSXOptions) XLSX {
+	xlsx := XLSX{
+		r: r,
+		s: size,
+	}
+	for _, opt := range opts {
+		opt(&xlsx)
+	}
+	return xlsx
+}
+
+// Load reads the xlsx file and returns a document per sheet with the sheet as
+// a markdown table, or a document per row with the WithXLSXRows option. The
+// documents have the sheet name and the sheet index as metadata, and in row
+// mode the row number in the sheet. Cells hold their stored values, so dates
+// are loaded as serial numbers and formulas as their last computed results.
+func (x XLSX) Load(_ context.Context) ([]schema.Document, error) {
+	f, err := openOfficeFile(x.r, x.s)
+	if err != nil {
+		return nil, err
+	}
+
+	workbookPart, err := f.mainPart("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	var workbook xlsxWorkbook
+	if err := f.decode(workbookPart, &workbook); err != nil {
+		return nil, err
+	}
+
+	targets, types, err := f.relationships(workbookPart)
+	if err != nil {
+		return nil, err
+	}
+	var sharedStrings []string
+	for id, typ := range types {
+		if strings.HasSuffix(typ, "/sharedStrings") {
+			if sharedStrings, err = xlsxSharedStrings(f, targets[id]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var docs []schema.Document
+	for i, sheet := range workbook.Sheets {
+		if !strings.HasSuffix(types[sheet.RelID], "/worksheet") {
+			continue
+		}
+		if len(x.sheets) > 0 && !slices.Contains(x.sheets, sheet.Name) {
+			continue
+		}
+
+		rows, rowNumbers, err := xlsxRows(f, targets[sheet.RelID], sharedStrings)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		if !x.rows {
+			docs = append(docs, schema.Document{
+				PageContent: htmlmd.Table(rows),
+				Metadata: map[string]any{
+					"sheet":       sheet.Name,
+					"sheet_index": i + 1,
+				},
+			})
+			continue
+		}
+
+		header := rows[0]
+		for j, row := range rows[1:] {
+			content := make([]string, 0, len(row))
+			for k, value := range row {
+				name := xlsxColumnName(k)
+				if k < len(header) && header[k] != "" {
+					name = header[k]
+				}
+				if len(x.columns) > 0 && !slices.Contains(x.columns, name) {
+					continue
+				}
+				content = append(content, fmt.Sprintf("%s: %s", name, value))
+			}
+
+			docs = append(docs, schema.Document{
+				PageContent: strings.Join(content, "\n"),
+				Metadata: map[string]any{
+					"sheet":       sheet.Name,
+					"sheet_index": i + 1,
+					"row":         rowNumbers[j+1],
+				},
+			})
+		}
+	}
+
+	return docs, nil
+}
+
+// LoadAndSplit reads the xlsx file and splits it into multiple documents
+// using a text splitter.
+func (x XLSX) LoadAndSplit(ctx context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	docs, err := x.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textsplitter.SplitDocuments(splitter, docs)
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		RelID string `xml:"id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxText is a shared or inline string, either plain or made of rich text runs.
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.Text)
+	}
+	return sb.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func xlsxSharedStrings(f *officeFile, part string) ([]string, error) {
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := f.decode(part, &sst); err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, 0, len(sst.Items))
+	for _, item := range sst.Items {
+		strs = append(strs, item.String())
+	}
+	return strs, nil
+}
+
+// xlsxRows returns the rows of a worksheet with at least one non empty cell
+// and their row numbers.
+func xlsxRows(f *officeFile, part string, sharedStrings []string) ([][]string, []int, error) {
+	var ws xlsxWorksheet
+	if err := f.decode(part, &ws); err != nil {
+		return nil, nil, err
+	}
+
+	var rows [][]string
+	var rowNumbers []int
+	for i, r := range ws.Rows {
+		var row []string
+		for j, c := range r.Cells {
+			col := xlsxColumnIndex(c.Ref)
+			if col < 0 {
+				col = j
+			}
+
+			value := c.Value
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings) {
+					return nil, nil, fmt.Errorf("%w: shared string %q in cell %s", ErrInvalidOfficeFile, c.Value, c.Ref)
+				}
+				value = sharedStrings[idx]
+			case "inlineStr":
+				value = c.Inline.String()
+			case "b":
+				value = strconv.FormatBool(c.Value == "1")
+			}
+			if value == "" {
+				continue
+			}
+
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			row[col] = value
+		}
+		if len(row) == 0 {
+			continue
+		}
+
+		number := r.Number
+		if number == 0 {
+			number = i + 1
+		}
+		rows = append(rows, row)
+		rowNumbers = append(rowNumbers, number)
+	}
+	return rows, rowNumbers, nil
+}
+
+// xlsxColumnIndex returns the zero based column index of a cell reference like
+// "AB12", or -1 if the reference has no column.
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+// xlsxColumnName returns the name of a zero based column index, like "AB".
+func xlsxColumnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,105 @@
+package documentloaders
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func xlsxTestFile(t *testing.T) (*bytes.Reader, int64) {
+	t.Helper()
+
+	r, size := officeZip(t, map[string]string{
+		"_rels/.rels": fmt.Sprintf(_officeRootRels, "xl/workbook.xml"),
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+ xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="员工" sheetId="1" r:id="rId1"/>
+<sheet name="空白" sheetId="2" r:id="rId2"/>
+<sheet name="部门" sheetId="3" r:id="rId3"/>
+</sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet3.xml"/>
+<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>姓名</t></si>
+<si><t>年假</t></si>
+<si><r><t>张</t></r><r><t>三</t></r></si>
+<si><t>李四</t><rPh><t>リ</t></rPh></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>在职</t></is></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>5</v></c><c r="C2" t="b"><v>1</v></c></row>
+<row r="3"/>
+<row r="4"><c r="A4" t="s"><v>3</v></c><c r="C4" t="b"><v>0</v></c><c r="D4" t="str"><v>备注</v></c></row>
+</sheetData>
+</worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+		"xl/worksheets/sheet3.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>研发</t></is></c></row></sheetData>
+</worksheet>`,
+	})
+
+	return r, size
+}
+
+func TestXLSXLoader(t *testing.T) {
+	t.Parallel()
+
+	r, size := xlsxTestFile(t)
+	docs, err := NewXLSX(r, size).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "| 姓名 | 年假 | 在职 |  |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| 张三 | 5 | true |  |\n"+
+		"| 李四 |  | false | 备注 |", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"sheet": "员工", "sheet_index": 1}, docs[0].Metadata)
+	assert.Equal(t, "| 研发 |\n| --- |", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"sheet": "部门", "sheet_index": 3}, docs[1].Metadata)
+}
+
+func TestXLSXLoaderRows(t *testing.T) {
+	t.Parallel()
+
+	r, size := xlsxTestFile(t)
+	docs, err := NewXLSX(r, size, WithXLSXRows(), WithXLSXSheets("员工")).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "姓名: 张三\n年假: 5\n在职: true", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"sheet": "员工", "sheet_index": 1, "row": 2}, docs[0].Metadata)
+	assert.Equal(t, "姓名: 李四\n年假: \n在职: false\nD: 备注", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"sheet": "员工", "sheet_index": 1, "row": 4}, docs[1].Metadata)
+
+	docs, err = NewXLSX(r, size, WithXLSXRows("姓名", "年假")).Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "姓名: 张三\n年假: 5", docs[0].PageContent)
+}
+
+func TestXLSXColumns(t *testing.T) {
+	t.Parallel()
+
+	for ref, col := range map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "AB2": 27, "": -1} {
+		assert.Equal(t, col, xlsxColumnIndex(ref), ref)
+		if col >= 0 {
+			assert.Equal(t, ref[:len(ref)-len(strings.TrimLeft(ref, "ABCDEFGHIJKLMNOPQRSTUVWXYZ"))], xlsxColumnName(col))
+		}
+	}
+}
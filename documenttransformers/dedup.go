@@ -0,0 +1,340 @@
+package documenttransformers
+
+import (
+	"context"
+	"crypto/sha256"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultShingleSize     = 3
+	_defaultSimHashDistance = 3
+	_defaultMinHashSize     = 128
+	_defaultMinHashJaccard  = 0.8
+	// _minHashBandRows is the number of rows of a band of the locality
+	// sensitive hashing index of MinHash signatures.
+	_minHashBandRows = 4
+)
+
+// DedupMethod is the method used by a Deduplicator to find duplicates.
+type DedupMethod string
+
+const (
+	// DedupExact finds documents with the same content, ignoring whitespace.
+	DedupExact DedupMethod = "exact"
+	// DedupSimHash finds near duplicates whose SimHash fingerprints differ in
+	// at most a few bits.
+	DedupSimHash DedupMethod = "simhash"
+	// DedupMinHash finds near duplicates whose estimated Jaccard similarity of
+	// shingles is above a threshold.
+	DedupMinHash DedupMethod = "minhash"
+)
+
+// Deduplicator is a transformer dropping duplicate documents. The first of
+// several duplicates is kept. Near duplicates are compared on shingles of
+// consecutive words, where every Han, kana and Hangul character is a word.
+type Deduplicator struct {
+	method      DedupMethod
+	shingleSize int
+	maxDistance int
+	numHashes   int
+	threshold   float64
+}
+
+var _ DocumentTransformer = Deduplicator{}
+
+// DeduplicatorOption is a function type that can be used to modify the
+// deduplicator.
+type DeduplicatorOption func(*Deduplicator)
+
+// WithSimHash is an option for finding near duplicates with SimHash. Documents
+// whose 64 bit fingerprints differ in at most maxDistance bits, 3 by default,
+// are duplicates.
+func WithSimHash(maxDistance int) DeduplicatorOption {
+	return func(d *Deduplicator) {
+		d.method = DedupSimHash
+		if maxDistance > 0 {
+			d.maxDistance = maxDistance
+		}
+	}
+}
+
+// WithMinHash is an option for finding near duplicates with MinHash. Documents
+// with an estimated Jaccard similarity of at least threshold, 0.8 by default,
+// are duplicates.
+func WithMinHash(threshold float64) DeduplicatorOption {
+	return func(d *Deduplicator) {
+		d.method = DedupMinHash
+		if threshold > 0 {
+			d.threshold = threshold
+		}
+	}
+}
+
+// WithMinHashSize is an option for setting the number of hashes of MinHash
+// signatures. Defaults to 128.
+func WithMinHashSize(numHashes int) DeduplicatorOption {
+	return func(d *Deduplicator) {
+		d.numHashes = numHashes
+	}
+}
+
+// WithShingleSize is an option for setting the number of words of a shingle
+// for near duplicates. Defaults to 3.
+func WithShingleSize(size int) DeduplicatorOption {
+	return func(d *Deduplicator) {
+		d.shingleSize = size
+	}
+}
+
+// NewDeduplicator creates a new deduplicator. It drops exact duplicates unless
+// WithSimHash or WithMinHash is used.
+func NewDeduplicator(opts ...DeduplicatorOption) Deduplicator {
+	d := Deduplicator{
+		method:      DedupExact,
+		shingleSize: _defaultShingleSize,
+		maxDistance: _defaultSimHashDistance,
+		numHashes:   _defaultMinHashSize,
+		threshold:   _defaultMinHashJaccard,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// TransformDocuments returns the documents without duplicates.
+func (d Deduplicator) TransformDocuments(_ context.Context, docs []schema.Document) ([]schema.Document, error) {
+	var isDuplicate func(text string) bool
+	switch d.method {
+	case DedupSimHash:
+		isDuplicate = d.simHashIndex()
+	case DedupMinHash:
+		isDuplicate = d.minHashIndex()
+	default:
+		seen := make(map[[sha256.Size]byte]bool)
+		isDuplicate = func(text string) bool {
+			sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+			if seen[sum] {
+				return true
+			}
+			seen[sum] = true
+			return false
+		}
+	}
+
+	kept := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if !isDuplicate(doc.PageContent) {
+			kept = append(kept, doc)
+		}
+	}
+	return kept, nil
+}
+
+// simHashIndex returns a function reporting whether a text is a near duplicate
+// of an earlier text, and adding it to the index otherwise. Fingerprints are
+// split into maxDistance+1 blocks, and two fingerprints that differ in at most
+// maxDistance bits have at least one equal block, so only the fingerprints
+// sharing a block are compared.
+func (d Deduplicator) simHashIndex() func(string) bool {
+	type blockKey struct {
+		block int
+		value uint64
+	}
+	numBlocks := minInt(d.maxDistance+1, 64) //nolint:gomnd
+	blockSize := (64 + numBlocks - 1) / numBlocks
+	blocks := make(map[blockKey][]uint64)
+
+	return func(text string) bool {
+		fingerprint := simHash(shingles(text, d.shingleSize))
+		keys := make([]blockKey, 0, numBlocks)
+		for i := 0; i < numBlocks; i++ {
+			shift := i * blockSize
+			if shift >= 64 { //nolint:gomnd
+				break
+			}
+			key := blockKey{block: i, value: (fingerprint >> shift) & (1<<blockSize - 1)}
+			for _, other := range blocks[key] {
+				if bits.OnesCount64(fingerprint^other) <= d.maxDistance {
+					return true
+				}
+			}
+			keys = append(keys, key)
+		}
+		for _, key := range keys {
+			blocks[key] = append(blocks[key], fingerprint)
+		}
+		return false
+	}
+}
+
+// minHashIndex returns a function reporting whether a text is a near
+// duplicate of an earlier text, and adding it to the index otherwise.
+// Signatures are split into bands, and only the signatures sharing a band are
+// compared.
+func (d Deduplicator) minHashIndex() func(string) bool {
+	type bandKey struct {
+		band int
+		hash uint64
+	}
+	rows := minInt(_minHashBandRows, d.numHashes)
+	bands := make(map[bandKey][]int)
+	signatures := make([][]uint64, 0)
+
+	return func(text string) bool {
+		signature := minHash(shingles(text, d.shingleSize), d.numHashes)
+		keys := make([]bandKey, 0, d.numHashes/rows+1)
+		for start, band := 0, 0; start < len(signature); start, band = start+rows, band+1 {
+			h := fnv.New64a()
+			for _, value := range signature[start:minInt(start+rows, len(signature))] {
+				writeUint64(h, value)
+			}
+			key := bandKey{band: band, hash: h.Sum64()}
+			for _, i := range bands[key] {
+				if jaccard(signature, signatures[i]) >= d.threshold {
+					return true
+				}
+			}
+			keys = append(keys, key)
+		}
+
+		for _, key := range keys {
+			bands[key] = append(bands[key], len(signatures))
+		}
+		signatures = append(signatures, signature)
+		return false
+	}
+}
+
+// shingles returns the hashes of the runs of size consecutive words of the
+// text, or of all the words if there are fewer.
+func shingles(text string, size int) []uint64 {
+	words := words(text)
+	if size < 1 {
+		size = 1
+	}
+	if len(words) < size {
+		size = len(words)
+	}
+
+	hashes := make([]uint64, 0, len(words))
+	for i := 0; i+size <= len(words) && size > 0; i++ {
+		h := fnv.New64a()
+		for _, word := range words[i : i+size] {
+			h.Write([]byte(word)) //nolint:errcheck
+			h.Write([]byte{0})    //nolint:errcheck
+		}
+		hashes = append(hashes, h.Sum64())
+	}
+	return hashes
+}
+
+// words returns the lowercased words of the text. Han, kana and Hangul
+// characters are words by themselves.
+func words(text string) []string {
+	result := make([]string, 0)
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			result = append(result, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			result = append(result, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return result
+}
+
+// simHash returns the 64 bit SimHash fingerprint of the shingles.
+func simHash(hashes []uint64) uint64 {
+	var counts [64]int
+	for _, h := range hashes {
+		for i := range counts {
+			if h&(1<<i) != 0 {
+				counts[i]++
+			} else {
+				counts[i]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, count := range counts {
+		if count > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+	return fingerprint
+}
+
+// minHash returns the MinHash signature of the shingles, using a different
+// mix of the shingle hashes for every hash function.
+func minHash(hashes []uint64, numHashes int) []uint64 {
+	signature := make([]uint64, numHashes)
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+	for _, h := range hashes {
+		for i := range signature {
+			if v := mix64(h + uint64(i)*0x9e3779b97f4a7c15); v < signature[i] {
+				signature[i] = v
+			}
+		}
+	}
+	return signature
+}
+
+// mix64 is the finalizer of splitmix64.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// jaccard returns the Jaccard similarity estimated from two signatures.
+func jaccard(a, b []uint64) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+func writeUint64(h interface{ Write([]byte) (int, error) }, v uint64) {
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(v >> (8 * i))
+	}
+	h.Write(b[:]) //nolint:errcheck
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,19 @@
+/*
+Package documenttransformers contains the DocumentTransformer interface, an
+interface for transforming documents between loading and embedding them, and
+its implementations.
+
+The main components of this package are:
+
+- DocumentTransformer interface: a common interface for transforming documents.
+- Pipeline: a transformer applying several transformers in order.
+- Splitter: a transformer splitting documents with a textsplitter.TextSplitter.
+- Deduplicator: a transformer dropping exact duplicates, or near duplicates
+found with SimHash or MinHash.
+- LanguageDetector: a transformer adding the language of every document to its
+metadata.
+- Translator: a transformer translating documents with a chat model.
+- Tagger: a transformer asking a chat model to fill metadata fields described
+by a JSON schema.
+*/
+package documenttransformers
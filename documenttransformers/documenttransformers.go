@@ -0,0 +1,69 @@
+package documenttransformers
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// DocumentTransformer is the interface for transforming documents. Transformers
+// may drop documents, add new ones, or change their content or metadata.
+type DocumentTransformer interface {
+	TransformDocuments(ctx context.Context, docs []schema.Document) ([]schema.Document, error)
+}
+
+// Pipeline is a transformer applying its transformers in order. It stops early
+// when no documents are left.
+type Pipeline []DocumentTransformer
+
+var _ DocumentTransformer = Pipeline{}
+
+// NewPipeline creates a new pipeline of the transformers.
+func NewPipeline(transformers ...DocumentTransformer) Pipeline {
+	return Pipeline(transformers)
+}
+
+// TransformDocuments transforms the documents with every transformer of the
+// pipeline.
+func (p Pipeline) TransformDocuments(ctx context.Context, docs []schema.Document) ([]schema.Document, error) {
+	var err error
+	for _, transformer := range p {
+		if len(docs) == 0 {
+			break
+		}
+		docs, err = transformer.TransformDocuments(ctx, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// Splitter is a transformer splitting the documents with a text splitter, like
+// textsplitter.SplitDocuments.
+type Splitter struct {
+	splitter textsplitter.TextSplitter
+}
+
+var _ DocumentTransformer = Splitter{}
+
+// NewSplitter creates a new transformer splitting documents with the splitter.
+func NewSplitter(splitter textsplitter.TextSplitter) Splitter {
+	return Splitter{splitter: splitter}
+}
+
+// TransformDocuments splits the documents into chunks.
+func (s Splitter) TransformDocuments(_ context.Context, docs []schema.Document) ([]schema.Document, error) {
+	return textsplitter.SplitDocuments(s.splitter, docs)
+}
+
+// copyMetadata returns a copy of the metadata of a document, so transformers
+// don't change the metadata of their input documents.
+func copyMetadata(doc schema.Document) map[string]any {
+	metadata := make(map[string]any, len(doc.Metadata)+1)
+	for key, value := range doc.Metadata {
+		metadata[key] = value
+	}
+	return metadata
+}
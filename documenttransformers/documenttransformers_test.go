@@ -0,0 +1,227 @@
+package documenttransformers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+// funcChatLLM answers the messages with a function of the system and the user
+// message.
+type funcChatLLM func(system, user string, options llms.CallOptions) *schema.AIChatMessage
+
+func (l funcChatLLM) Call(_ context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return l(messages[0].GetContent(), messages[1].GetContent(), opts), nil
+}
+
+func (l funcChatLLM) Generate(ctx context.Context, messages [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	generations := make([]*llms.Generation, 0, len(messages))
+	for _, m := range messages {
+		message, err := l.Call(ctx, m, options...)
+		if err != nil {
+			return nil, err
+		}
+		generations = append(generations, &llms.Generation{Text: message.Content, Message: message})
+	}
+	return generations, nil
+}
+
+func docs(contents ...string) []schema.Document {
+	docs := make([]schema.Document, 0, len(contents))
+	for _, content := range contents {
+		docs = append(docs, schema.Document{PageContent: content, Metadata: map[string]any{"source": content}})
+	}
+	return docs
+}
+
+func contents(docs []schema.Document) []string {
+	contents := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		contents = append(contents, doc.PageContent)
+	}
+	return contents
+}
+
+const (
+	_article = `The quick brown fox jumps over the lazy dog while the farmer watches from the porch
+and drinks his morning coffee before going out to feed the chickens and the goats in the barn`
+	_otherArticle = `Retrieval augmented generation combines a search index with a language model so that
+answers are grounded in documents that were loaded, split, embedded and stored beforehand`
+)
+
+func TestDeduplicatorExact(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewDeduplicator().TransformDocuments(context.Background(), docs(
+		"hello world", "hello   world\n", "Hello world", "hello world",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello world", "Hello world"}, contents(result))
+}
+
+func TestDeduplicatorNearDuplicates(t *testing.T) {
+	t.Parallel()
+
+	nearDuplicate := strings.Replace(_article, "goats", "sheep", 1)
+	input := docs(_article, _otherArticle, nearDuplicate, strings.ToUpper(_article))
+
+	for name, dedup := range map[string]Deduplicator{
+		"simhash": NewDeduplicator(WithSimHash(10)),
+		"minhash": NewDeduplicator(WithMinHash(0.7)),
+	} {
+		result, err := dedup.TransformDocuments(context.Background(), input)
+		require.NoError(t, err, name)
+		assert.Equal(t, []string{_article, _otherArticle}, contents(result), name)
+	}
+
+	result, err := NewDeduplicator().TransformDocuments(context.Background(), input)
+	require.NoError(t, err)
+	assert.Len(t, result, 4)
+
+	result, err = NewDeduplicator(WithMinHash(0.8)).TransformDocuments(context.Background(), docs(
+		"年假需要提前三天申请，由部门经理审批。", "年假需要提前三天申请，由部门经理审批！", "病假需要提供医院证明。",
+	))
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	for text, language := range map[string]string{
+		"The cat is on the mat and it is happy.":                 "en",
+		"Le chat est sur le tapis et il est content.":            "fr",
+		"Die Katze ist auf der Matte und sie ist nicht traurig.": "de",
+		"El gato está en la alfombra y es feliz con los niños.":  "es",
+		"年假需要提前三天申请。":                                            "zh",
+		"年次休暇は三日前までに申請してください。":                                   "ja",
+		"연차 휴가는 사흘 전에 신청해야 합니다.":                                 "ko",
+		"Кошка сидит на ковре.":                                  "ru",
+		"12345 !!!":                                              "",
+		"Kubernetes":                                             "",
+	} {
+		assert.Equal(t, language, DetectLanguage(text), text)
+	}
+}
+
+func TestLanguageDetector(t *testing.T) {
+	t.Parallel()
+
+	input := docs("The cat is on the mat.", "年假需要提前三天申请。", "42")
+	result, err := NewLanguageDetector(WithLanguageKey("lang")).TransformDocuments(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, "en", result[0].Metadata["lang"])
+	assert.Equal(t, "zh", result[1].Metadata["lang"])
+	assert.NotContains(t, result[2].Metadata, "lang")
+	// The input documents are unchanged.
+	assert.NotContains(t, input[0].Metadata, "lang")
+}
+
+func TestTranslator(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	llm := funcChatLLM(func(system, user string, _ llms.CallOptions) *schema.AIChatMessage {
+		calls++
+		assert.Contains(t, system, "into en")
+		return &schema.AIChatMessage{Content: "Annual leave must be requested three days in advance.\n"}
+	})
+
+	result, err := NewPipeline(
+		NewLanguageDetector(),
+		NewTranslator(llm, "en", WithOriginalKey("original")),
+	).TransformDocuments(context.Background(), docs("年假需要提前三天申请。", "The cat is on the mat."))
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{
+		"Annual leave must be requested three days in advance.", "The cat is on the mat.",
+	}, contents(result))
+	assert.Equal(t, "en", result[0].Metadata["language"])
+	assert.Equal(t, "年假需要提前三天申请。", result[0].Metadata["original"])
+	assert.Equal(t, "年假需要提前三天申请。", result[0].Metadata["source"])
+
+	// A custom key and prompt.
+	llm = funcChatLLM(func(system, _ string, _ llms.CallOptions) *schema.AIChatMessage {
+		assert.Equal(t, "Into French.", system)
+		return &schema.AIChatMessage{Content: "Le chat est sur le tapis."}
+	})
+	result, err = NewPipeline(
+		NewLanguageDetector(WithLanguageKey("lang")),
+		NewTranslator(llm, "fr",
+			WithTranslatorLanguageKey("lang"),
+			WithTranslatePrompt(prompts.NewPromptTemplate("Into French.", nil))),
+	).TransformDocuments(context.Background(), docs("The cat is on the mat.", "Le chat est sur le tapis."))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Le chat est sur le tapis.", "Le chat est sur le tapis."}, contents(result))
+	assert.Equal(t, "fr", result[0].Metadata["lang"])
+	assert.NotContains(t, result[0].Metadata, "language")
+}
+
+func TestTagger(t *testing.T) {
+	t.Parallel()
+
+	definition := jsonschema.Definition{
+		Properties: map[string]jsonschema.Definition{
+			"topic":     {Type: jsonschema.String, Enum: []string{"leave", "travel"}},
+			"sentiment": {Type: jsonschema.String},
+		},
+	}
+
+	llm := funcChatLLM(func(_, user string, options llms.CallOptions) *schema.AIChatMessage {
+		require.Len(t, options.Functions, 1)
+		assert.Equal(t, jsonschema.Object, options.Functions[0].Parameters.(jsonschema.Definition).Type) //nolint:forcetypeassert
+		if strings.Contains(user, "年假") {
+			return &schema.AIChatMessage{FunctionCall: &schema.FunctionCall{
+				Name:      options.Functions[0].Name,
+				Arguments: `{"topic": "leave", "sentiment": "neutral", "extra": "dropped"}`,
+			}}
+		}
+		return &schema.AIChatMessage{Content: "```json\n{\"topic\": \"travel\", \"sentiment\": null}\n```"}
+	})
+
+	result, err := NewTagger(llm, definition).TransformDocuments(context.Background(), docs("年假需要提前三天申请。", "出差需要审批。"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"source": "年假需要提前三天申请。", "topic": "leave", "sentiment": "neutral"},
+		result[0].Metadata)
+	assert.Equal(t, map[string]any{"source": "出差需要审批。", "topic": "travel"}, result[1].Metadata)
+
+	llm = funcChatLLM(func(_, _ string, _ llms.CallOptions) *schema.AIChatMessage {
+		return &schema.AIChatMessage{Content: "I don't know."}
+	})
+	_, err = NewTagger(llm, definition).TransformDocuments(context.Background(), docs("text"))
+	require.ErrorIs(t, err, ErrInvalidTags)
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	splitter := textsplitter.NewRecursiveCharacter()
+	splitter.ChunkSize = 30
+	splitter.ChunkOverlap = 0
+	result, err := NewPipeline(
+		NewSplitter(splitter),
+		NewDeduplicator(),
+		NewLanguageDetector(),
+	).TransformDocuments(context.Background(), docs("The cat is on the mat.\n\nThe cat is on the mat."))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "The cat is on the mat.", result[0].PageContent)
+	assert.Equal(t, "en", result[0].Metadata["language"])
+
+	result, err = NewPipeline(NewDeduplicator()).TransformDocuments(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,178 @@
+package documenttransformers
+
+import (
+	"context"
+	"unicode"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultLanguageKey = "language"
+	// _maxDetectRunes is how many letters of a text are used to detect its
+	// language.
+	_maxDetectRunes = 2000
+	// _minStopwords is how many stopwords of a Latin script language a text
+	// must contain to be detected as that language.
+	_minStopwords = 2
+)
+
+// LanguageDetector is a transformer adding the ISO 639-1 code of the language
+// of every document to its metadata, like "en" or "zh". Documents whose
+// language is not detected are unchanged.
+type LanguageDetector struct {
+	key    string
+	detect func(text string) string
+}
+
+var _ DocumentTransformer = LanguageDetector{}
+
+// LanguageDetectorOption is a function type that can be used to modify the
+// language detector.
+type LanguageDetectorOption func(*LanguageDetector)
+
+// WithLanguageKey is an option for setting the metadata key of the language.
+// Defaults to "language".
+func WithLanguageKey(key string) LanguageDetectorOption {
+	return func(d *LanguageDetector) {
+		d.key = key
+	}
+}
+
+// WithDetectFunc is an option for replacing DetectLanguage with another
+// detection function, returning "" for unknown languages.
+func WithDetectFunc(detect func(text string) string) LanguageDetectorOption {
+	return func(d *LanguageDetector) {
+		d.detect = detect
+	}
+}
+
+// NewLanguageDetector creates a new language detector.
+func NewLanguageDetector(opts ...LanguageDetectorOption) LanguageDetector {
+	d := LanguageDetector{
+		key:    _defaultLanguageKey,
+		detect: DetectLanguage,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// TransformDocuments returns the documents with their language in the
+// metadata.
+func (d LanguageDetector) TransformDocuments(_ context.Context, docs []schema.Document) ([]schema.Document, error) {
+	result := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if language := d.detect(doc.PageContent); language != "" {
+			metadata := copyMetadata(doc)
+			metadata[d.key] = language
+			doc.Metadata = metadata
+		}
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// _scripts are the languages detected from their script alone.
+var _scripts = []struct { //nolint:gochecknoglobals
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// _stopwords are frequent words of the languages written in the Latin script.
+var _stopwords = map[string][]string{ //nolint:gochecknoglobals
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "with", "are", "this", "was", "be", "on"},
+	"fr": {"le", "la", "les", "et", "des", "est", "un", "une", "du", "que", "dans", "pour", "pas", "sur", "qui"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "von", "sich", "auf", "ich"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "de", "en", "un", "una", "por", "con", "para", "del"},
+	"pt": {"o", "os", "as", "e", "é", "que", "de", "em", "um", "uma", "não", "com", "para", "do", "da"},
+	"it": {"il", "la", "gli", "e", "è", "che", "di", "in", "un", "una", "non", "per", "con", "del", "sono"},
+	"nl": {"de", "het", "een", "en", "is", "van", "dat", "niet", "op", "te", "zijn", "met", "voor", "ik", "je"},
+}
+
+// _latinLanguages is the order in which ties between Latin script languages
+// are broken.
+var _latinLanguages = []string{"en", "fr", "de", "es", "pt", "it", "nl"} //nolint:gochecknoglobals
+
+// DetectLanguage returns the ISO 639-1 code of the language of the text, or ""
+// if it is unknown. Languages are detected from the script of the letters of
+// the text: text with kana is Japanese and other text with Han characters is
+// Chinese. Latin script text is detected from its stopwords, for English,
+// French, German, Spanish, Portuguese, Italian and Dutch only.
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	latin, kana, letters := 0, 0, 0
+	for _, r := range text {
+		if letters >= _maxDetectRunes {
+			break
+		}
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			for _, script := range _scripts {
+				if unicode.Is(script.table, r) {
+					counts[script.language]++
+					break
+				}
+			}
+		}
+	}
+
+	if kana > 0 && kana+counts["zh"] >= latin {
+		return "ja"
+	}
+	language, count := "", 0
+	for _, script := range _scripts {
+		if counts[script.language] > count {
+			language, count = script.language, counts[script.language]
+		}
+	}
+	if count >= latin && language != "" {
+		return language
+	}
+	if latin == 0 {
+		return ""
+	}
+	return detectLatinLanguage(text)
+}
+
+// detectLatinLanguage returns the Latin script language with the most
+// stopwords in the text.
+func detectLatinLanguage(text string) string {
+	frequencies := make(map[string]int)
+	for _, word := range words(text) {
+		frequencies[word]++
+	}
+
+	language, score := "", 0
+	for _, candidate := range _latinLanguages {
+		count := 0
+		for _, stopword := range _stopwords[candidate] {
+			count += frequencies[stopword]
+		}
+		if count > score {
+			language, score = candidate, count
+		}
+	}
+	if score < _minStopwords {
+		return ""
+	}
+	return language
+}
@@ -0,0 +1,129 @@
+package documenttransformers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_taggerFunctionName = "tag_document"
+	_defaultTagPrompt   = `Extract the properties of the ` + _taggerFunctionName + ` function from the text given by the user.
+Only use the properties defined by the function. If the function can't be called, answer with the properties as a JSON object.`
+)
+
+// ErrInvalidTags is returned when the tags returned by the chat model are not
+// a JSON object.
+var ErrInvalidTags = errors.New("invalid tags")
+
+// Tagger is a transformer asking a chat model to fill metadata fields of the
+// documents, described by the properties of an object JSON schema. The chat
+// model is called with a function taking the properties as arguments, and
+// models without function calling may answer with a JSON object instead.
+// Properties missing from the answer are not set.
+type Tagger struct {
+	llm        llms.ChatLLM
+	definition jsonschema.Definition
+	prompt     string
+	options    []llms.CallOption
+}
+
+var _ DocumentTransformer = Tagger{}
+
+// TaggerOption is a function type that can be used to modify the tagger.
+type TaggerOption func(*Tagger)
+
+// WithTagPrompt is an option for setting the system prompt of the tagger.
+func WithTagPrompt(prompt string) TaggerOption {
+	return func(t *Tagger) {
+		t.prompt = prompt
+	}
+}
+
+// WithTagCallOptions is an option for setting the call options of the chat
+// model, like the model or the temperature.
+func WithTagCallOptions(options ...llms.CallOption) TaggerOption {
+	return func(t *Tagger) {
+		t.options = append(t.options, options...)
+	}
+}
+
+// NewTagger creates a new tagger filling the properties of the definition
+// using the chat model.
+func NewTagger(llm llms.ChatLLM, definition jsonschema.Definition, opts ...TaggerOption) Tagger {
+	t := Tagger{
+		llm:        llm,
+		definition: definition,
+		prompt:     _defaultTagPrompt,
+	}
+	for _, opt := range opts {
+		opt(&t)
+	}
+	return t
+}
+
+// TransformDocuments returns the documents with the tags in the metadata.
+func (t Tagger) TransformDocuments(ctx context.Context, docs []schema.Document) ([]schema.Document, error) {
+	definition := t.definition
+	definition.Type = jsonschema.Object
+	options := append([]llms.CallOption{
+		llms.WithFunctions([]llms.FunctionDefinition{{
+			Name:        _taggerFunctionName,
+			Description: "Tags a document with the given properties.",
+			Parameters:  definition,
+		}}),
+		llms.WithFunctionCallBehavior(llms.FunctionCallBehaviorAuto),
+	}, t.options...)
+
+	result := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		message, err := t.llm.Call(ctx, []schema.ChatMessage{
+			schema.SystemChatMessage{Content: t.prompt},
+			schema.HumanChatMessage{Content: doc.PageContent},
+		}, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		output := message.Content
+		if message.FunctionCall != nil {
+			output = message.FunctionCall.Arguments
+		}
+		tags, err := parseTags(output)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata := copyMetadata(doc)
+		for key := range t.definition.Properties {
+			if value, ok := tags[key]; ok && value != nil {
+				metadata[key] = value
+			}
+		}
+		doc.Metadata = metadata
+		result = append(result, doc)
+	}
+	return result, nil
+}
+
+// parseTags parses the JSON object of the output. Text around the JSON object,
+// like markdown code fences, is ignored.
+func parseTags(output string) (map[string]any, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("%w: no JSON object in %q", ErrInvalidTags, output)
+	}
+
+	var tags map[string]any
+	if err := json.Unmarshal([]byte(output[start:end+1]), &tags); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTags, err)
+	}
+	return tags, nil
+}
@@ -0,0 +1,123 @@
+package documenttransformers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _defaultTranslateTemplate = `Translate the text given by the user into {{.language}}.
+Keep the formatting, like markdown, code and links, unchanged.
+Answer with the translation only, without any explanation.`
+
+// Translator is a transformer translating the content of the documents into a
+// language with a chat model. Documents whose language metadata already is the
+// target language, compared case-insensitively, are not translated. The
+// language metadata of the translated documents is set to the target language.
+type Translator struct {
+	llm         llms.ChatLLM
+	language    string
+	prompt      prompts.PromptTemplate
+	languageKey string
+	originalKey string
+	options     []llms.CallOption
+}
+
+var _ DocumentTransformer = Translator{}
+
+// TranslatorOption is a function type that can be used to modify the
+// translator.
+type TranslatorOption func(*Translator)
+
+// WithTranslatePrompt is an option for setting the system prompt of the
+// translation. The prompt receives the target language as the variable
+// "language".
+func WithTranslatePrompt(prompt prompts.PromptTemplate) TranslatorOption {
+	return func(t *Translator) {
+		t.prompt = prompt
+	}
+}
+
+// WithTranslatorLanguageKey is an option for setting the metadata key of the
+// language, like WithLanguageKey of the language detector. Defaults to
+// "language".
+func WithTranslatorLanguageKey(key string) TranslatorOption {
+	return func(t *Translator) {
+		t.languageKey = key
+	}
+}
+
+// WithOriginalKey is an option for keeping the original content of the
+// translated documents in the metadata under the key.
+func WithOriginalKey(key string) TranslatorOption {
+	return func(t *Translator) {
+		t.originalKey = key
+	}
+}
+
+// WithTranslateCallOptions is an option for setting the call options of the
+// chat model, like the model or the temperature.
+func WithTranslateCallOptions(options ...llms.CallOption) TranslatorOption {
+	return func(t *Translator) {
+		t.options = append(t.options, options...)
+	}
+}
+
+// NewTranslator creates a new translator into the language using the chat
+// model. LanguageDetector writes ISO 639-1 codes, so the language must be a
+// code like "fr", not a name like "French", for the documents already in it to
+// be skipped.
+func NewTranslator(llm llms.ChatLLM, language string, opts ...TranslatorOption) Translator {
+	t := Translator{
+		llm:         llm,
+		language:    language,
+		prompt:      prompts.NewPromptTemplate(_defaultTranslateTemplate, []string{"language"}),
+		languageKey: _defaultLanguageKey,
+	}
+	for _, opt := range opts {
+		opt(&t)
+	}
+	return t
+}
+
+// TransformDocuments returns the translated documents.
+func (t Translator) TransformDocuments(ctx context.Context, docs []schema.Document) ([]schema.Document, error) {
+	system, err := t.prompt.Format(map[string]any{"language": t.language})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if language, ok := doc.Metadata[t.languageKey].(string); ok && strings.EqualFold(language, t.language) {
+			result = append(result, doc)
+			continue
+		}
+		if strings.TrimSpace(doc.PageContent) == "" {
+			result = append(result, doc)
+			continue
+		}
+
+		message, err := t.llm.Call(ctx, []schema.ChatMessage{
+			schema.SystemChatMessage{Content: system},
+			schema.HumanChatMessage{Content: doc.PageContent},
+		}, t.options...)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata := copyMetadata(doc)
+		if t.originalKey != "" {
+			metadata[t.originalKey] = doc.PageContent
+		}
+		metadata[t.languageKey] = t.language
+		result = append(result, schema.Document{
+			PageContent: strings.TrimSpace(message.Content),
+			Metadata:    metadata,
+		})
+	}
+	return result, nil
+}
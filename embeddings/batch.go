@@ -0,0 +1,165 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrEmbedderWrongNumberVectors is returned by EmbedBatched when a batch
+// returns a number of vectors that is not equal to the number of texts in it.
+var ErrEmbedderWrongNumberVectors = errors.New("number of vectors does not match number of texts")
+
+// BatchOptions declares the input limits of an embedding API and how
+// EmbedBatched works within them.
+type BatchOptions struct {
+	// MaxBatchSize is the maximum number of texts per request. Zero means no limit.
+	MaxBatchSize int
+	// MaxTextLength is the maximum number of runes per text. Zero means no limit.
+	MaxTextLength int
+	// Concurrency is the maximum number of requests running at once. Values
+	// below one mean one.
+	Concurrency int
+	// Truncate cuts texts longer than MaxTextLength. By default long texts are
+	// split into chunks and the vectors of the chunks are combined with
+	// CombineVectors, weighted by the chunk lengths.
+	Truncate bool
+}
+
+// piece is a text, or a chunk of a text, sent to the embedding API.
+type piece struct {
+	owner int
+	text  string
+}
+
+// EmbedBatched embeds the texts with embed, a call of the embedding API. The
+// texts are split into batches within the limits of the options, the batches
+// are embedded concurrently and the vectors are returned in the order of the
+// texts. The first error cancels the remaining batches.
+func EmbedBatched(
+	ctx context.Context,
+	texts []string,
+	embed func(ctx context.Context, texts []string) ([][]float64, error),
+	opts BatchOptions,
+) ([][]float64, error) {
+	pieces := splitPieces(texts, opts)
+
+	batchSize := opts.MaxBatchSize
+	if batchSize <= 0 {
+		batchSize = len(pieces)
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	vectors := make([][]float64, len(pieces))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	for start := 0; start < len(pieces); start += batchSize {
+		end := start + batchSize
+		if end > len(pieces) {
+			end = len(pieces)
+		}
+
+		batch := make([]string, 0, end-start)
+		for _, p := range pieces[start:end] {
+			batch = append(batch, p.text)
+		}
+
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(start int, batch []string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			batchVectors, err := embed(ctx, batch)
+			if err == nil && len(batchVectors) != len(batch) {
+				err = ErrEmbedderWrongNumberVectors
+			}
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			copy(vectors[start:], batchVectors)
+		}(start, batch)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return combinePieces(len(texts), pieces, vectors)
+}
+
+// splitPieces truncates or chunks the texts longer than the maximum length.
+func splitPieces(texts []string, opts BatchOptions) []piece {
+	pieces := make([]piece, 0, len(texts))
+	for i, text := range texts {
+		runes := []rune(text)
+		if opts.MaxTextLength <= 0 || len(runes) <= opts.MaxTextLength {
+			pieces = append(pieces, piece{owner: i, text: text})
+			continue
+		}
+
+		if opts.Truncate {
+			pieces = append(pieces, piece{owner: i, text: string(runes[:opts.MaxTextLength])})
+			continue
+		}
+
+		for _, chunk := range BatchTexts([]string{text}, opts.MaxTextLength)[0] {
+			pieces = append(pieces, piece{owner: i, text: chunk})
+		}
+	}
+	return pieces
+}
+
+// combinePieces returns one vector per text, combining the vectors of texts
+// that were split into chunks.
+func combinePieces(numTexts int, pieces []piece, vectors [][]float64) ([][]float64, error) {
+	result := make([][]float64, numTexts)
+	for i := 0; i < len(pieces); {
+		j := i + 1
+		for j < len(pieces) && pieces[j].owner == pieces[i].owner {
+			j++
+		}
+
+		if j-i == 1 {
+			result[pieces[i].owner] = vectors[i]
+			i = j
+			continue
+		}
+
+		weights := make([]int, 0, j-i)
+		for _, p := range pieces[i:j] {
+			weights = append(weights, len([]rune(p.text)))
+		}
+		combined, err := CombineVectors(vectors[i:j], weights)
+		if err != nil {
+			return nil, err
+		}
+		result[pieces[i].owner] = combined
+		i = j
+	}
+	return result, nil
+}
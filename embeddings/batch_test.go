@@ -0,0 +1,114 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lengthEmbed returns a vector holding the rune length of each text and
+// records the batches it was called with.
+type lengthEmbed struct {
+	mu      sync.Mutex
+	batches [][]string
+	running int32
+	maxRun  int32
+}
+
+func (l *lengthEmbed) embed(_ context.Context, texts []string) ([][]float64, error) {
+	running := atomic.AddInt32(&l.running, 1)
+	defer atomic.AddInt32(&l.running, -1)
+	for {
+		maxRun := atomic.LoadInt32(&l.maxRun)
+		if running <= maxRun || atomic.CompareAndSwapInt32(&l.maxRun, maxRun, running) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	l.mu.Lock()
+	l.batches = append(l.batches, texts)
+	l.mu.Unlock()
+
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, []float64{float64(len([]rune(text))), 1})
+	}
+	return vectors, nil
+}
+
+func TestEmbedBatched(t *testing.T) {
+	t.Parallel()
+
+	texts := []string{"一", "二二", "三三三", "四四四四", "五五五五五"}
+	l := &lengthEmbed{}
+	vectors, err := EmbedBatched(context.Background(), texts, l.embed, BatchOptions{
+		MaxBatchSize: 2,
+		Concurrency:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 5)
+	for i, v := range vectors {
+		assert.Equal(t, []float64{float64(i + 1), 1}, v)
+	}
+	assert.Len(t, l.batches, 3)
+	for _, batch := range l.batches {
+		assert.LessOrEqual(t, len(batch), 2)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&l.maxRun), int32(2))
+}
+
+func TestEmbedBatchedLongTexts(t *testing.T) {
+	t.Parallel()
+
+	texts := []string{"短", "长长长长长"}
+
+	l := &lengthEmbed{}
+	vectors, err := EmbedBatched(context.Background(), texts, l.embed, BatchOptions{
+		MaxTextLength: 2,
+		Truncate:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 1}, {2, 1}}, vectors)
+	assert.Equal(t, [][]string{{"短", "长长"}}, l.batches)
+
+	l = &lengthEmbed{}
+	vectors, err = EmbedBatched(context.Background(), texts, l.embed, BatchOptions{
+		MaxTextLength: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"短", "长长", "长长", "长"}}, l.batches)
+	combined, err := CombineVectors([][]float64{{2, 1}, {2, 1}, {1, 1}}, []int{2, 2, 1})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 1}, combined}, vectors)
+}
+
+func TestEmbedBatchedErrors(t *testing.T) {
+	t.Parallel()
+
+	errEmbed := errors.New("embed failed")
+	var calls int32
+	_, err := EmbedBatched(context.Background(), []string{"a", "b", "c"},
+		func(context.Context, []string) ([][]float64, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, errEmbed
+		},
+		BatchOptions{MaxBatchSize: 1},
+	)
+	require.ErrorIs(t, err, errEmbed)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = EmbedBatched(context.Background(), []string{"a", "b"},
+		func(context.Context, []string) ([][]float64, error) {
+			return [][]float64{{1}}, nil
+		},
+		BatchOptions{},
+	)
+	require.ErrorIs(t, err, ErrEmbedderWrongNumberVectors)
+}
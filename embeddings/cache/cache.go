@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+var (
+	// ErrInvalidCachedVector is returned when a value in the store is not an
+	// encoded vector.
+	ErrInvalidCachedVector = errors.New("invalid cached vector")
+	// ErrEmbedderWrongNumberVectors is returned when the underlying embedder
+	// returns a number of vectors that is not equal to the number of texts.
+	ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of texts")
+	// ErrStoreWrongNumberValues is returned when the store returns a number of
+	// values that is not equal to the number of keys.
+	ErrStoreWrongNumberValues = errors.New("number of values from store does not match number of keys")
+	// ErrMissingNamespace is returned by New when the namespace is empty.
+	ErrMissingNamespace = errors.New("missing namespace")
+)
+
+// Embedder is an embedder caching the vectors of another embedder in a store.
+// It is safe for concurrent use if the underlying embedder and store are.
+type Embedder struct {
+	embedder     embeddings.Embedder
+	store        Store
+	namespace    string
+	cacheQueries bool
+}
+
+var _ embeddings.Embedder = Embedder{}
+
+// Option is a function type that can be used to modify the cache-backed embedder.
+type Option func(*Embedder)
+
+// WithCacheQueries is an option for also caching the vectors of EmbedQuery.
+// By default queries are always embedded, because embedders may embed queries
+// differently from documents and queries rarely repeat.
+func WithCacheQueries(cacheQueries bool) Option {
+	return func(e *Embedder) {
+		e.cacheQueries = cacheQueries
+	}
+}
+
+// New creates a new embedder caching the vectors of the embedder in the store.
+// The namespace is hashed with the texts and must identify the embedding
+// model, like "text-embedding-ada-002", so the vectors of different models
+// sharing a store are not mixed up.
+func New(embedder embeddings.Embedder, namespace string, store Store, opts ...Option) (Embedder, error) {
+	if namespace == "" {
+		return Embedder{}, ErrMissingNamespace
+	}
+	e := Embedder{
+		embedder:  embedder,
+		store:     store,
+		namespace: namespace,
+	}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e, nil
+}
+
+// EmbedDocuments returns the vectors of the texts from the store and embeds
+// the texts missing from the store in one batch.
+func (e Embedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return e.embed(ctx, "document", texts, e.embedder.EmbedDocuments)
+}
+
+// EmbedQuery embeds the text, using the store if WithCacheQueries is set.
+func (e Embedder) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	if !e.cacheQueries {
+		return e.embedder.EmbedQuery(ctx, text)
+	}
+
+	vectors, err := e.embed(ctx, "query", []string{text}, func(ctx context.Context, texts []string) ([][]float64, error) {
+		vector, err := e.embedder.EmbedQuery(ctx, texts[0])
+		if err != nil {
+			return nil, err
+		}
+		return [][]float64{vector}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (e Embedder) embed(
+	ctx context.Context,
+	kind string,
+	texts []string,
+	embed func(context.Context, []string) ([][]float64, error),
+) ([][]float64, error) {
+	keys := make([]string, 0, len(texts))
+	for _, text := range texts {
+		keys = append(keys, e.key(kind, text))
+	}
+
+	values, err := e.store.MGet(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(keys) {
+		return nil, ErrStoreWrongNumberValues
+	}
+
+	vectors := make([][]float64, len(texts))
+	// Misses are grouped by key, so duplicate texts are embedded once.
+	missIndexes := make(map[string][]int)
+	missKeys := make([]string, 0)
+	missTexts := make([]string, 0)
+	for i, value := range values {
+		if value != nil {
+			vector, err := decodeVector(value)
+			if err != nil {
+				return nil, err
+			}
+			vectors[i] = vector
+			continue
+		}
+		if _, ok := missIndexes[keys[i]]; !ok {
+			missKeys = append(missKeys, keys[i])
+			missTexts = append(missTexts, texts[i])
+		}
+		missIndexes[keys[i]] = append(missIndexes[keys[i]], i)
+	}
+
+	if len(missTexts) == 0 {
+		return vectors, nil
+	}
+
+	missVectors, err := embed(ctx, missTexts)
+	if err != nil {
+		return nil, err
+	}
+	if len(missVectors) != len(missTexts) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	missValues := make([][]byte, 0, len(missVectors))
+	for i, vector := range missVectors {
+		missValues = append(missValues, encodeVector(vector))
+		for _, j := range missIndexes[missKeys[i]] {
+			vectors[j] = vector
+		}
+	}
+
+	if err := e.store.MSet(ctx, missKeys, missValues); err != nil {
+		return nil, err
+	}
+
+	return vectors, nil
+}
+
+// key returns the hex encoded SHA-256 hash of the namespace, kind and text.
+func (e Embedder) key(kind, text string) string {
+	h := sha256.New()
+	for _, part := range []string{e.namespace, kind, text} {
+		// Length prefixes keep the parts from running into each other.
+		_ = binary.Write(h, binary.LittleEndian, uint64(len(part)))
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func encodeVector(vector []float64) []byte {
+	data := make([]byte, 8*len(vector)) //nolint:gomnd
+	for i, f := range vector {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(f))
+	}
+	return data
+}
+
+func decodeVector(data []byte) ([]float64, error) {
+	if len(data)%8 != 0 {
+		return nil, ErrInvalidCachedVector
+	}
+	vector := make([]float64, len(data)/8) //nolint:gomnd
+	for i := range vector {
+		vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return vector, nil
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEmbedder embeds texts as their rune count and records the embedded texts.
+type countingEmbedder struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (e *countingEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.texts = append(e.texts, texts...)
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, []float64{float64(len([]rune(text))), 0.5})
+	}
+	return vectors, nil
+}
+
+func (e *countingEmbedder) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	vectors, err := e.EmbedDocuments(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func testEmbedder(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	base := &countingEmbedder{}
+	embedder, err := New(base, "embedding-v1", store)
+	require.NoError(t, err)
+
+	vectors, err := embedder.EmbedDocuments(ctx, []string{"年假", "病假规定", "年假"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{2, 0.5}, {4, 0.5}, {2, 0.5}}, vectors)
+	assert.Equal(t, []string{"年假", "病假规定"}, base.texts)
+
+	vectors, err = embedder.EmbedDocuments(ctx, []string{"病假规定", "加班", "年假"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{4, 0.5}, {2, 0.5}, {2, 0.5}}, vectors)
+	assert.Equal(t, []string{"年假", "病假规定", "加班"}, base.texts)
+
+	other, err := New(base, "embedding-v2", store)
+	require.NoError(t, err)
+	_, err = other.EmbedDocuments(ctx, []string{"年假"})
+	require.NoError(t, err)
+	assert.Len(t, base.texts, 4)
+
+	for i := 0; i < 2; i++ {
+		_, err = embedder.EmbedQuery(ctx, "年假")
+		require.NoError(t, err)
+	}
+	assert.Len(t, base.texts, 6)
+
+	embedder, err = New(base, "embedding-v1", store, WithCacheQueries(true))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		vector, err := embedder.EmbedQuery(ctx, "年假")
+		require.NoError(t, err)
+		assert.Equal(t, []float64{2, 0.5}, vector)
+	}
+	assert.Len(t, base.texts, 7)
+}
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+	testEmbedder(t, NewInMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	testEmbedder(t, store)
+}
+
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		t.Skip("REDIS_URL not set")
+	}
+	opts, err := redis.ParseURL(url)
+	require.NoError(t, err)
+
+	testEmbedder(t, NewRedisStore(redis.NewClient(opts), WithKeyPrefix(uuid.NewString()+":")))
+}
+
+func TestConcurrentUse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	embedder, err := New(&countingEmbedder{}, "counting", NewInMemoryStore())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vectors, err := embedder.EmbedDocuments(ctx, []string{"a", "bb", "ccc"})
+			assert.NoError(t, err)
+			assert.Equal(t, [][]float64{{1, 0.5}, {2, 0.5}, {3, 0.5}}, vectors)
+		}()
+	}
+	wg.Wait()
+}
+
+// shortStore is a store losing values.
+type shortStore struct{}
+
+func (shortStore) MGet(context.Context, []string) ([][]byte, error) { return nil, nil }
+
+func (shortStore) MSet(context.Context, []string, [][]byte) error { return nil }
+
+func TestEmbedderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(&countingEmbedder{}, "", NewInMemoryStore())
+	require.ErrorIs(t, err, ErrMissingNamespace)
+
+	embedder, err := New(&countingEmbedder{}, "counting", shortStore{})
+	require.NoError(t, err)
+	_, err = embedder.EmbedDocuments(context.Background(), []string{"a"})
+	require.ErrorIs(t, err, ErrStoreWrongNumberValues)
+}
@@ -0,0 +1,15 @@
+/*
+Package cache contains an embeddings.Embedder that caches the vectors of
+another embedder, so identical texts are embedded only once.
+
+Vectors are stored in a Store by a hash of the namespace, which identifies the
+embedding model, and the text. Only the texts missing from the store are sent to the
+underlying embedder, in a single batch.
+
+The package provides the stores:
+
+- InMemoryStore: keeps the vectors in a map.
+- FileStore: keeps each vector in a file in a directory.
+- RedisStore: keeps the vectors in Redis.
+*/
+package cache
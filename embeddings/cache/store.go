@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrMismatchKeysAndValues is returned when the number of keys and values
+// given to MSet does not match.
+var ErrMismatchKeysAndValues = errors.New("number of keys and values does not match")
+
+// Store is the interface for storing byte values by key.
+type Store interface {
+	// MGet returns the values of the keys, nil for missing keys.
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	// MSet stores the values under the keys.
+	MSet(ctx context.Context, keys []string, values [][]byte) error
+}
+
+// InMemoryStore is a store keeping the values in a map. It is safe for
+// concurrent use.
+type InMemoryStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new empty in memory store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{values: make(map[string][]byte)}
+}
+
+// MGet returns the values of the keys.
+func (s *InMemoryStore) MGet(_ context.Context, keys []string) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, s.values[key])
+	}
+	return values, nil
+}
+
+// MSet stores the values under the keys.
+func (s *InMemoryStore) MSet(_ context.Context, keys []string, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrMismatchKeysAndValues
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, key := range keys {
+		s.values[key] = values[i]
+	}
+	return nil
+}
+
+// FileStore is a store keeping each value in a file in a directory. Keys must
+// be valid file names, which the hex encoded hashes of Embedder are. Values are
+// written to a temporary file and renamed, so it is safe for concurrent use,
+// also by several processes.
+type FileStore struct {
+	dir string
+}
+
+var _ Store = FileStore{}
+
+// NewFileStore creates a new file store in the directory, creating the
+// directory if it does not exist.
+func NewFileStore(dir string) (FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+		return FileStore{}, err
+	}
+	return FileStore{dir: dir}, nil
+}
+
+// MGet returns the values of the keys.
+func (s FileStore) MGet(_ context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		value, err := os.ReadFile(filepath.Join(s.dir, key))
+		if errors.Is(err, os.ErrNotExist) {
+			values = append(values, nil)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// MSet stores the values under the keys.
+func (s FileStore) MSet(_ context.Context, keys []string, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrMismatchKeysAndValues
+	}
+
+	for i, key := range keys {
+		if err := s.write(key, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s FileStore) write(key string, value []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, key))
+}
+
+const _defaultRedisKeyPrefix = "embeddings:"
+
+// RedisStore is a store keeping the values in Redis.
+type RedisStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+var _ Store = RedisStore{}
+
+// RedisOption is a function type that can be used to modify the Redis store.
+type RedisOption func(*RedisStore)
+
+// WithKeyPrefix is an option for setting the prefix added to all keys in
+// Redis. Defaults to "embeddings:".
+func WithKeyPrefix(keyPrefix string) RedisOption {
+	return func(s *RedisStore) {
+		s.keyPrefix = keyPrefix
+	}
+}
+
+// WithTTL is an option for setting the expiration of stored values. By
+// default values do not expire.
+func WithTTL(ttl time.Duration) RedisOption {
+	return func(s *RedisStore) {
+		s.ttl = ttl
+	}
+}
+
+// NewRedisStore creates a new Redis store using the client.
+func NewRedisStore(client redis.UniversalClient, opts ...RedisOption) RedisStore {
+	s := RedisStore{
+		client:    client,
+		keyPrefix: _defaultRedisKeyPrefix,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// MGet returns the values of the keys.
+func (s RedisStore) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, 0, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	redisKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		redisKeys = append(redisKeys, s.keyPrefix+key)
+	}
+
+	results, err := s.client.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		value, ok := result.(string)
+		if !ok {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, []byte(value))
+	}
+	return values, nil
+}
+
+// MSet stores the values under the keys.
+func (s RedisStore) MSet(ctx context.Context, keys []string, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrMismatchKeysAndValues
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := s.client.Pipeline()
+	for i, key := range keys {
+		pipe.Set(ctx, s.keyPrefix+key, values[i], s.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -8,6 +8,7 @@ The main components of this package are:
 - OpenAI: an Embedder implementation using the OpenAI API.
 - VertexAIPaLM: an Embedder implementation using Google PaLM (VertexAI) API.
 - Helper functions: utility functions for embedding, such as `batchTexts` and `maybeRemoveNewLines`.
+- EmbedBatched: embeds texts concurrently in batches within the limits of an API.
 
 The package provides a flexible way to handle different APIs for generating
 embeddings by using the Embedder interface as an abstraction.
@@ -2,6 +2,8 @@ package emb_chatglm
 
 import (
 	"context"
+	"sync"
+
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/llms/chatglm"
 )
@@ -9,7 +11,13 @@ import (
 type Chatglm struct {
 	client        *chatglm.LLM
 	batchSize     int // 超过256个字符，超过会只截取前256个字符进行向量化
+	batchCount    int // 每次请求只向量化一个文本
+	concurrency   int
+	truncate      bool
 	stripNewLines bool
+
+	mu    sync.Mutex
+	usage []chatglm.Usage
 }
 
 var _ embeddings.Embedder = &Chatglm{}
@@ -18,6 +26,8 @@ func NewChatglm(opts ...Option) (*Chatglm, error) {
 	v := &Chatglm{
 		stripNewLines: defaultStripNewLines,
 		batchSize:     defaultBatchSize,
+		batchCount:    defaultBatchCount,
+		concurrency:   defaultConcurrency,
 	}
 
 	for _, opt := range opts {
@@ -34,37 +44,46 @@ func NewChatglm(opts ...Option) (*Chatglm, error) {
 	return v, nil
 }
 
-// EmbedDocuments use ernie Embedding-V1.
-func (e *Chatglm) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
-	batchedTexts := embeddings.BatchTexts(
-		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
-		e.batchSize,
-	)
-
-	emb := make([][]float64, 0, len(texts))
-	for _, texts := range batchedTexts {
-		curTextEmbeddings, err := e.client.CreateEmbedding(ctx, texts)
-		if err != nil {
-			return nil, err
-		}
-
-		textLengths := make([]int, 0, len(texts))
-		for _, text := range texts {
-			textLengths = append(textLengths, len(text))
-		}
+// createEmbedding embeds one batch and records its usage.
+func (e *Chatglm) createEmbedding(ctx context.Context, texts []string) ([][]float64, error) {
+	emb, usage, err := e.client.CreateEmbeddingWithUsage(ctx, texts)
+	e.mu.Lock()
+	e.usage = append(e.usage, usage...)
+	e.mu.Unlock()
+	return emb, err
+}
 
-		combined, err := embeddings.CombineVectors(curTextEmbeddings, textLengths)
-		if err != nil {
-			return nil, err
-		}
+// GetUsage returns the token usage of the embeddings created since the
+// embedder was created or ResetUsage was called.
+func (e *Chatglm) GetUsage() []chatglm.Usage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]chatglm.Usage(nil), e.usage...)
+}
 
-		emb = append(emb, combined)
-	}
+// ResetUsage clears the recorded token usage.
+func (e *Chatglm) ResetUsage() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.usage = nil
+}
 
-	return emb, nil
+// EmbedDocuments use chatglm embedding.
+func (e *Chatglm) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return embeddings.EmbedBatched(
+		ctx,
+		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
+		e.createEmbedding,
+		embeddings.BatchOptions{
+			MaxBatchSize:  e.batchCount,
+			MaxTextLength: e.batchSize,
+			Concurrency:   e.concurrency,
+			Truncate:      e.truncate,
+		},
+	)
 }
 
-// EmbedQuery use ernie Embedding-V1.
+// EmbedQuery use chatglm embedding.
 func (e *Chatglm) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
 	emb, err := e.EmbedDocuments(ctx, []string{text})
 	if err != nil {
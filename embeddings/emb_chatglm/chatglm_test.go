@@ -0,0 +1,74 @@
+package emb_chatglm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms/chatglm"
+)
+
+// newServer returns a server embedding every prompt as its length.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		var payload struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":    http.StatusOK,
+			"success": true,
+			"data": map[string]any{
+				"embedding": []float64{float64(len(payload.Prompt))},
+				"usage":     map[string]any{"prompt_tokens": 1, "total_tokens": 1},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChatglmConcurrentBatches(t *testing.T) {
+	t.Parallel()
+	server := newServer(t)
+
+	llm, err := chatglm.New(
+		chatglm.WithId("id"),
+		chatglm.WithSecret("secret"),
+		chatglm.WithBaseURL(server.URL+"/%s/%s"),
+	)
+	require.NoError(t, err)
+	embedder, err := NewChatglm(WithClient(*llm), WithConcurrency(4))
+	require.NoError(t, err)
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee", "ffffff", "ggggggg", "hhhhhhhh"}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vectors, err := embedder.EmbedDocuments(context.Background(), texts)
+			assert.NoError(t, err)
+			if assert.Len(t, vectors, len(texts)) {
+				for j, text := range texts {
+					assert.InDelta(t, float64(len(text)), vectors[j][0], 0)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, embedder.GetUsage(), 4*len(texts))
+	embedder.ResetUsage()
+	assert.Empty(t, embedder.GetUsage())
+}
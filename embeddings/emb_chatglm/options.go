@@ -4,6 +4,8 @@ import "github.com/tmc/langchaingo/llms/chatglm"
 
 const (
 	defaultBatchSize     = 256
+	defaultBatchCount    = 1
+	defaultConcurrency   = 4
 	defaultStripNewLines = true
 )
 
@@ -15,12 +17,35 @@ func WithClient(client chatglm.LLM) Option {
 	}
 }
 
+// WithBatchSize is an option for specifying the maximum length of a text.
 func WithBatchSize(batchSize int) Option {
 	return func(c *Chatglm) {
 		c.batchSize = batchSize
 	}
 }
 
+// WithBatchCount is an option for specifying the maximum number of texts per request.
+func WithBatchCount(batchCount int) Option {
+	return func(c *Chatglm) {
+		c.batchCount = batchCount
+	}
+}
+
+// WithConcurrency is an option for specifying the maximum number of concurrent requests.
+func WithConcurrency(concurrency int) Option {
+	return func(c *Chatglm) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithTruncate is an option for truncating long texts instead of embedding
+// them in chunks and averaging the vectors.
+func WithTruncate(truncate bool) Option {
+	return func(c *Chatglm) {
+		c.truncate = truncate
+	}
+}
+
 func WithStripNewLines(stripNewLines bool) Option {
 	return func(c *Chatglm) {
 		c.stripNewLines = stripNewLines
@@ -4,6 +4,8 @@ import "github.com/tmc/langchaingo/llms/qwen"
 
 const (
 	defaultBatchSize     = 2048
+	defaultBatchCount    = 25
+	defaultConcurrency   = 4
 	defaultStripNewLines = true
 )
 
@@ -15,12 +17,35 @@ func WithClient(client qwen.LLM) Option {
 	}
 }
 
+// WithBatchSize is an option for specifying the maximum length of a text.
 func WithBatchSize(batchSize int) Option {
 	return func(c *Qwen) {
 		c.batchSize = batchSize
 	}
 }
 
+// WithBatchCount is an option for specifying the maximum number of texts per request.
+func WithBatchCount(batchCount int) Option {
+	return func(c *Qwen) {
+		c.batchCount = batchCount
+	}
+}
+
+// WithConcurrency is an option for specifying the maximum number of concurrent requests.
+func WithConcurrency(concurrency int) Option {
+	return func(c *Qwen) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithTruncate is an option for truncating long texts instead of embedding
+// them in chunks and averaging the vectors.
+func WithTruncate(truncate bool) Option {
+	return func(c *Qwen) {
+		c.truncate = truncate
+	}
+}
+
 func WithStripNewLines(stripNewLines bool) Option {
 	return func(c *Qwen) {
 		c.stripNewLines = stripNewLines
@@ -2,14 +2,22 @@ package emb_qwen
 
 import (
 	"context"
+	"sync"
+
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/llms/qwen"
 )
 
 type Qwen struct {
 	client        *qwen.LLM
-	batchSize     int // 超过2048个字符，超过会只截取前2048个字符进行向量化
+	batchSize     int // 每个文本不超过2048个token
+	batchCount    int // 文本数量不超过25
+	concurrency   int
+	truncate      bool
 	stripNewLines bool
+
+	mu    sync.Mutex
+	usage []qwen.CompletionUsage
 }
 
 var _ embeddings.Embedder = &Qwen{}
@@ -18,6 +26,8 @@ func NewQwen(opts ...Option) (*Qwen, error) {
 	v := &Qwen{
 		stripNewLines: defaultStripNewLines,
 		batchSize:     defaultBatchSize,
+		batchCount:    defaultBatchCount,
+		concurrency:   defaultConcurrency,
 	}
 
 	for _, opt := range opts {
@@ -34,37 +44,49 @@ func NewQwen(opts ...Option) (*Qwen, error) {
 	return v, nil
 }
 
-// EmbedDocuments use ernie Embedding-V1.
-func (e *Qwen) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
-	batchedTexts := embeddings.BatchTexts(
-		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
-		e.batchSize,
-	)
-
-	emb := make([][]float64, 0, len(texts))
-	for _, texts := range batchedTexts {
-		curTextEmbeddings, err := e.client.CreateEmbedding(ctx, texts)
-		if err != nil {
-			return nil, err
-		}
-
-		textLengths := make([]int, 0, len(texts))
-		for _, text := range texts {
-			textLengths = append(textLengths, len(text))
-		}
+// createEmbedding embeds one batch and records its usage.
+func (e *Qwen) createEmbedding(ctx context.Context, texts []string) ([][]float64, error) {
+	emb, usage, err := e.client.CreateEmbeddingWithUsage(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	e.usage = append(e.usage, usage)
+	e.mu.Unlock()
+	return emb, nil
+}
 
-		combined, err := embeddings.CombineVectors(curTextEmbeddings, textLengths)
-		if err != nil {
-			return nil, err
-		}
+// GetUsage returns the token usage of the embeddings created since the
+// embedder was created or ResetUsage was called.
+func (e *Qwen) GetUsage() []qwen.CompletionUsage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]qwen.CompletionUsage(nil), e.usage...)
+}
 
-		emb = append(emb, combined)
-	}
+// ResetUsage clears the recorded token usage.
+func (e *Qwen) ResetUsage() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.usage = nil
+}
 
-	return emb, nil
+// EmbedDocuments use qwen text-embedding-v1.
+func (e *Qwen) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return embeddings.EmbedBatched(
+		ctx,
+		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
+		e.createEmbedding,
+		embeddings.BatchOptions{
+			MaxBatchSize:  e.batchCount,
+			MaxTextLength: e.batchSize,
+			Concurrency:   e.concurrency,
+			Truncate:      e.truncate,
+		},
+	)
 }
 
-// EmbedQuery use ernie Embedding-V1.
+// EmbedQuery use qwen text-embedding-v1.
 func (e *Qwen) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
 	emb, err := e.EmbedDocuments(ctx, []string{text})
 	if err != nil {
@@ -2,14 +2,22 @@ package emb_spark
 
 import (
 	"context"
+	"sync"
+
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/llms/spark"
 )
 
 type Spark struct {
 	client        *spark.LLM
-	batchSize     int
+	batchSize     int // 每个文本不超过256个字符
+	batchCount    int // 每次请求只向量化一个文本
+	concurrency   int
+	truncate      bool
 	stripNewLines bool
+
+	mu    sync.Mutex
+	usage []spark.Usage
 }
 
 var _ embeddings.Embedder = &Spark{}
@@ -18,6 +26,8 @@ func NewSpark(opts ...Option) (*Spark, error) {
 	v := &Spark{
 		stripNewLines: defaultStripNewLines,
 		batchSize:     defaultBatchSize,
+		batchCount:    defaultBatchCount,
+		concurrency:   defaultConcurrency,
 	}
 
 	for _, opt := range opts {
@@ -34,37 +44,46 @@ func NewSpark(opts ...Option) (*Spark, error) {
 	return v, nil
 }
 
-// EmbedDocuments use ernie Embedding-V1.
-func (e *Spark) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
-	batchedTexts := embeddings.BatchTexts(
-		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
-		e.batchSize,
-	)
-
-	emb := make([][]float64, 0, len(texts))
-	for _, texts := range batchedTexts {
-		curTextEmbeddings, err := e.client.CreateEmbedding(ctx, texts)
-		if err != nil {
-			return nil, err
-		}
-
-		textLengths := make([]int, 0, len(texts))
-		for _, text := range texts {
-			textLengths = append(textLengths, len(text))
-		}
+// createEmbedding embeds one batch and records its usage.
+func (e *Spark) createEmbedding(ctx context.Context, texts []string) ([][]float64, error) {
+	emb, usage, err := e.client.CreateEmbeddingWithUsage(ctx, texts)
+	e.mu.Lock()
+	e.usage = append(e.usage, usage...)
+	e.mu.Unlock()
+	return emb, err
+}
 
-		combined, err := embeddings.CombineVectors(curTextEmbeddings, textLengths)
-		if err != nil {
-			return nil, err
-		}
+// GetUsage returns the token usage of the embeddings created since the
+// embedder was created or ResetUsage was called.
+func (e *Spark) GetUsage() []spark.Usage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]spark.Usage(nil), e.usage...)
+}
 
-		emb = append(emb, combined)
-	}
+// ResetUsage clears the recorded token usage.
+func (e *Spark) ResetUsage() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.usage = nil
+}
 
-	return emb, nil
+// EmbedDocuments use spark embedding.
+func (e *Spark) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return embeddings.EmbedBatched(
+		ctx,
+		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
+		e.createEmbedding,
+		embeddings.BatchOptions{
+			MaxBatchSize:  e.batchCount,
+			MaxTextLength: e.batchSize,
+			Concurrency:   e.concurrency,
+			Truncate:      e.truncate,
+		},
+	)
 }
 
-// EmbedQuery use ernie Embedding-V1.
+// EmbedQuery use spark embedding.
 func (e *Spark) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
 	emb, err := e.EmbedDocuments(ctx, []string{text})
 	if err != nil {
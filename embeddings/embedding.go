@@ -13,16 +13,19 @@ type Embedder interface {
 	EmbedQuery(ctx context.Context, text string) ([]float64, error)
 }
 
+// MaybeRemoveNewLines returns the texts with new lines replaced by spaces if
+// removeNewLines is true. The texts passed in are not modified.
 func MaybeRemoveNewLines(texts []string, removeNewLines bool) []string {
 	if !removeNewLines {
 		return texts
 	}
 
-	for i := 0; i < len(texts); i++ {
-		texts[i] = strings.ReplaceAll(texts[i], "\n", " ")
+	result := make([]string, len(texts))
+	for i, text := range texts {
+		result[i] = strings.ReplaceAll(text, "\n", " ")
 	}
 
-	return texts
+	return result
 }
 
 // BatchTexts splits strings by the length batchSize.
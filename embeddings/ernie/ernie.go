@@ -2,7 +2,7 @@ package ernie
 
 import (
 	"context"
-	"fmt"
+	"sync"
 
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/llms/ernie"
@@ -13,7 +13,12 @@ type Ernie struct {
 	client        *ernie.LLM
 	batchSize     int // 每个文本长度不超过 384个token
 	batchCount    int // 文本数量不超过16
+	concurrency   int
+	truncate      bool
 	stripNewLines bool
+
+	mu    sync.Mutex
+	usage []ernie.Usage
 }
 
 var _ embeddings.Embedder = &Ernie{}
@@ -24,6 +29,7 @@ func NewErnie(opts ...Option) (*Ernie, error) {
 		stripNewLines: defaultStripNewLines,
 		batchSize:     defaultBatchSize,
 		batchCount:    defaultBatchCount,
+		concurrency:   defaultConcurrency,
 	}
 
 	for _, opt := range opts {
@@ -41,65 +47,47 @@ func NewErnie(opts ...Option) (*Ernie, error) {
 	return v, nil
 }
 
-// split texts with batchCount.
+// embed embeds the texts in batches within the limits of Embedding-V1.
 func (e *Ernie) embed(ctx context.Context, texts []string) ([][]float64, error) {
-	emb := make([][]float64, 0, len(texts))
-
-	offsetLen := len(texts) / e.batchCount
-	for i := 0; i <= offsetLen; i++ {
-		start := i * e.batchCount
-		end := i*e.batchCount + e.batchCount
+	return embeddings.EmbedBatched(ctx, texts, e.createEmbedding, embeddings.BatchOptions{
+		MaxBatchSize:  e.batchCount,
+		MaxTextLength: e.batchSize,
+		Concurrency:   e.concurrency,
+		Truncate:      e.truncate,
+	})
+}
 
-		if end > len(texts) {
-			end = len(texts)
-		}
+// createEmbedding embeds one batch and records its usage.
+func (e *Ernie) createEmbedding(ctx context.Context, texts []string) ([][]float64, error) {
+	emb, usage, err := e.client.CreateEmbeddingWithUsage(ctx, texts)
+	e.mu.Lock()
+	e.usage = append(e.usage, usage...)
+	e.mu.Unlock()
+	return emb, err
+}
 
-		curTextEmbeddings, err := e.client.CreateEmbedding(ctx, texts[start:end])
-		if err != nil {
-			return nil, err
-		}
+// GetUsage returns the token usage of the embeddings created since the
+// embedder was created or ResetUsage was called.
+func (e *Ernie) GetUsage() []ernie.Usage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]ernie.Usage(nil), e.usage...)
+}
 
-		emb = append(emb, curTextEmbeddings...)
-	}
-	return emb, nil
+// ResetUsage clears the recorded token usage.
+func (e *Ernie) ResetUsage() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.usage = nil
 }
 
 // EmbedDocuments use ernie Embedding-V1.
 func (e *Ernie) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
-	batchedTexts := embeddings.BatchTexts(
-		embeddings.MaybeRemoveNewLines(texts, e.stripNewLines),
-		e.batchSize,
-	)
-
-	fmt.Println("文本分解")
-	fmt.Println(batchedTexts)
-
-	emb := make([][]float64, 0, len(texts))
-	for _, texts := range batchedTexts {
-		curTextEmbeddings, err := e.embed(ctx, texts)
-		if err != nil {
-			return nil, err
-		}
-
-		textLengths := make([]int, 0, len(texts))
-		for _, text := range texts {
-			textLengths = append(textLengths, len(text))
-		}
-
-		combined, err := embeddings.CombineVectors(curTextEmbeddings, textLengths)
-		if err != nil {
-			return nil, err
-		}
-
-		emb = append(emb, combined)
-	}
-
-	return emb, nil
+	return e.embed(ctx, embeddings.MaybeRemoveNewLines(texts, e.stripNewLines))
 }
 
 // EmbedCombine 合并文档
 func (e *Ernie) EmbedCombine(ctx context.Context, texts []string) ([]float64, error) {
-
 	curTextEmbeddings, err := e.embed(ctx, texts)
 	if err != nil {
 		return nil, err
@@ -110,12 +98,7 @@ func (e *Ernie) EmbedCombine(ctx context.Context, texts []string) ([]float64, er
 		textLengths = append(textLengths, len(text))
 	}
 
-	combined, err := embeddings.CombineVectors(curTextEmbeddings, textLengths)
-	if err != nil {
-		return nil, err
-	}
-
-	return combined, nil
+	return embeddings.CombineVectors(curTextEmbeddings, textLengths)
 }
 
 // EmbedQuery use ernie Embedding-V1.
@@ -6,6 +6,7 @@ const (
 	// see: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/alj562vvu#body%E5%8F%82%E6%95%B0
 	defaultBatchCount    = 16
 	defaultBatchSize     = 384
+	defaultConcurrency   = 4
 	defaultStripNewLines = true
 )
 
@@ -19,13 +20,35 @@ func WithClient(client ernie.LLM) Option {
 	}
 }
 
-// WithBatchSize is an option for specifying the batch size.
+// WithBatchSize is an option for specifying the maximum length of a text.
 func WithBatchSize(batchSize int) Option {
 	return func(e *Ernie) {
 		e.batchSize = batchSize
 	}
 }
 
+// WithBatchCount is an option for specifying the maximum number of texts per request.
+func WithBatchCount(batchCount int) Option {
+	return func(e *Ernie) {
+		e.batchCount = batchCount
+	}
+}
+
+// WithConcurrency is an option for specifying the maximum number of concurrent requests.
+func WithConcurrency(concurrency int) Option {
+	return func(e *Ernie) {
+		e.concurrency = concurrency
+	}
+}
+
+// WithTruncate is an option for truncating long texts instead of embedding
+// them in chunks and averaging the vectors.
+func WithTruncate(truncate bool) Option {
+	return func(e *Ernie) {
+		e.truncate = truncate
+	}
+}
+
 // WithStripNewLines is an option for specifying the should it strip new lines.
 func WithStripNewLines(stripNewLines bool) Option {
 	return func(e *Ernie) {
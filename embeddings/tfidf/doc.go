@@ -0,0 +1,16 @@
+/*
+Package tfidf contains an embeddings.Embedder that creates sparse TF-IDF
+vectors locally, without calling any API.
+
+Words are mapped to the dimensions of the vector with the hashing trick, so
+there is no fixed vocabulary and any dimension can be used. Term frequencies
+are weighted by the inverse document frequencies of the words in the texts the
+embedder was fitted on. Fit the embedder on the corpus with Fit, or load saved
+statistics with Load, before embedding; an embedder that was never fitted
+weights all words equally. The statistics can be saved and loaded, so documents
+embedded at indexing time and queries embedded later use the same weights.
+
+The vectors are deterministic, which makes the embedder useful for tests,
+continuous integration and air-gapped deployments with small corpora.
+*/
+package tfidf
@@ -0,0 +1,34 @@
+package tfidf
+
+import "github.com/tmc/langchaingo/wordsegmenter"
+
+const _defaultDimension = 1024
+
+// Option is a function type that can be used to modify the embedder.
+type Option func(*TFIDF)
+
+// WithSegmenter is an option for setting the segmenter used to split texts into
+// words. Defaults to a wordsegmenter.Chinese with the built in dictionary.
+func WithSegmenter(segmenter wordsegmenter.Segmenter) Option {
+	return func(t *TFIDF) {
+		t.segmenter = segmenter
+	}
+}
+
+// WithDimension is an option for setting the length of the vectors. Defaults
+// to 1024. Larger dimensions have fewer hash collisions between words.
+func WithDimension(dimension int) Option {
+	return func(t *TFIDF) {
+		t.dimension = dimension
+	}
+}
+
+// WithAutoFit is an option for fitting the embedder on the texts given to
+// EmbedDocuments before embedding them. Defaults to false. Texts embedded more
+// than once are counted every time, so only enable it when every document is
+// embedded once.
+func WithAutoFit(autoFit bool) Option {
+	return func(t *TFIDF) {
+		t.autoFit = autoFit
+	}
+}
@@ -0,0 +1,210 @@
+package tfidf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/wordsegmenter"
+)
+
+// ErrInvalidDimension is returned when the dimension of the vectors is not positive.
+var ErrInvalidDimension = errors.New("dimension must be positive")
+
+// TFIDF is an embedder creating hashed TF-IDF vectors. A TFIDF is safe for
+// concurrent use.
+type TFIDF struct {
+	segmenter wordsegmenter.Segmenter
+	dimension int
+	autoFit   bool
+
+	mu       sync.RWMutex
+	numDocs  int
+	docFreqs map[string]int
+}
+
+var _ embeddings.Embedder = &TFIDF{}
+
+// New creates a new TFIDF embedder with options.
+func New(opts ...Option) (*TFIDF, error) {
+	t := &TFIDF{
+		dimension: _defaultDimension,
+		docFreqs:  make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.dimension <= 0 {
+		return nil, ErrInvalidDimension
+	}
+
+	if t.segmenter == nil {
+		segmenter, err := wordsegmenter.NewChinese()
+		if err != nil {
+			return nil, err
+		}
+		t.segmenter = segmenter
+	}
+
+	return t, nil
+}
+
+// Fit adds the texts to the document frequency statistics.
+func (t *TFIDF) Fit(texts []string) {
+	words := make([][]string, 0, len(texts))
+	for _, text := range texts {
+		words = append(words, unique(t.segmenter.Segment(text)))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, w := range words {
+		t.numDocs++
+		for _, word := range w {
+			t.docFreqs[word]++
+		}
+	}
+}
+
+// NumDocuments returns the number of texts the embedder was fitted on.
+func (t *TFIDF) NumDocuments() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.numDocs
+}
+
+// EmbedDocuments creates a vector for every text. If auto fit is enabled the
+// embedder is fitted on the texts first.
+func (t *TFIDF) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	if t.autoFit {
+		t.Fit(texts)
+	}
+
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, t.vector(text))
+	}
+	return vectors, nil
+}
+
+// EmbedQuery creates a vector for the text. The embedder is never fitted on
+// queries.
+func (t *TFIDF) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	return t.vector(text), nil
+}
+
+// vector returns the L2 normalized hashed TF-IDF vector of the text. Every word
+// is added to the dimension given by its hash, with a sign given by another bit
+// of the hash, so collisions tend to cancel out instead of adding up. A text
+// without words gets the zero vector.
+func (t *TFIDF) vector(text string) []float64 {
+	words := t.segmenter.Segment(text)
+	tf := make(map[string]int, len(words))
+	for _, w := range words {
+		tf[w]++
+	}
+
+	vector := make([]float64, t.dimension)
+
+	t.mu.RLock()
+	// Words are added in order of first appearance, so the floating point
+	// sums, and with them the vectors, are deterministic.
+	for _, w := range unique(words) {
+		h := hash(w)
+		weight := (1 + math.Log(float64(tf[w]))) * t.idf(w)
+		if h&(1<<63) != 0 {
+			weight = -weight
+		}
+		vector[h%uint64(t.dimension)] += weight
+	}
+	t.mu.RUnlock()
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
+
+// idf returns the smoothed inverse document frequency of a word. Words that
+// were never seen get the highest weight.
+func (t *TFIDF) idf(word string) float64 {
+	n := float64(t.numDocs)
+	df := float64(t.docFreqs[word])
+	return math.Log((1+n)/(1+df)) + 1
+}
+
+type serializedTFIDF struct {
+	Dimension    int            `json:"dimension"`
+	NumDocuments int            `json:"num_documents"`
+	DocFreqs     map[string]int `json:"doc_freqs"`
+}
+
+// Save writes the dimension and the document frequency statistics as JSON to
+// the writer.
+func (t *TFIDF) Save(w io.Writer) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(serializedTFIDF{
+		Dimension:    t.dimension,
+		NumDocuments: t.numDocs,
+		DocFreqs:     t.docFreqs,
+	})
+}
+
+// Load reads an embedder written by Save. The segmenter is not stored, so the
+// same segmenter used to fit the embedder should be given as an option. The
+// stored dimension overrides the dimension option, so loaded vectors match the
+// saved ones.
+func Load(r io.Reader, opts ...Option) (*TFIDF, error) {
+	var s serializedTFIDF
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	t, err := New(append(opts, WithDimension(s.Dimension))...)
+	if err != nil {
+		return nil, err
+	}
+
+	t.numDocs = s.NumDocuments
+	if s.DocFreqs != nil {
+		t.docFreqs = s.DocFreqs
+	}
+	return t, nil
+}
+
+func hash(word string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(word))
+	return h.Sum64()
+}
+
+func unique(words []string) []string {
+	seen := make(map[string]struct{}, len(words))
+	result := make([]string, 0, len(words))
+	for _, w := range words {
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		result = append(result, w)
+	}
+	return result
+}
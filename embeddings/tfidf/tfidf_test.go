@@ -0,0 +1,126 @@
+package tfidf
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+var _corpus = []string{
+	"员工每年享有带薪年假，工作满一年可休五天。",
+	"报销差旅费用需要提交发票和审批单。",
+	"公司食堂每天中午提供免费午餐。",
+	"The VPN client must be installed before remote work.",
+}
+
+func TestTFIDFRetrieval(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e, err := New()
+	require.NoError(t, err)
+	e.Fit(_corpus)
+
+	docs, err := e.EmbedDocuments(ctx, _corpus)
+	require.NoError(t, err)
+	require.Len(t, docs, len(_corpus))
+	assert.Len(t, docs[0], 1024)
+	assert.Equal(t, len(_corpus), e.NumDocuments())
+
+	cases := map[string]int{
+		"年假有几天？":          0,
+		"差旅报销要什么发票":       1,
+		"食堂午餐":            2,
+		"remote work vpn": 3,
+	}
+	for query, expected := range cases {
+		q, err := e.EmbedQuery(ctx, query)
+		require.NoError(t, err)
+
+		best, bestScore := -1, -1.0
+		for i, doc := range docs {
+			score, err := embeddings.CosineSimilarity(q, doc)
+			require.NoError(t, err)
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		assert.Equal(t, expected, best, query)
+	}
+	assert.Equal(t, len(_corpus), e.NumDocuments())
+}
+
+func TestTFIDFDeterministic(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	a, err := New(WithDimension(64))
+	require.NoError(t, err)
+	b, err := New(WithDimension(64))
+	require.NoError(t, err)
+
+	va, err := a.EmbedDocuments(ctx, _corpus)
+	require.NoError(t, err)
+	vb, err := b.EmbedDocuments(ctx, _corpus)
+	require.NoError(t, err)
+	assert.Equal(t, va, vb)
+	assert.Len(t, va[0], 64)
+
+	empty, err := a.EmbedQuery(ctx, "，。")
+	require.NoError(t, err)
+	assert.Equal(t, make([]float64, 64), empty)
+
+	_, err = New(WithDimension(0))
+	require.ErrorIs(t, err, ErrInvalidDimension)
+}
+
+func TestTFIDFSaveLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e, err := New(WithDimension(128))
+	require.NoError(t, err)
+	e.Fit(_corpus)
+
+	docs, err := e.EmbedDocuments(ctx, _corpus[:1])
+	require.NoError(t, err)
+	assert.Equal(t, len(_corpus), e.NumDocuments())
+
+	var buf bytes.Buffer
+	require.NoError(t, e.Save(&buf))
+
+	loaded, err := Load(&buf, WithDimension(16))
+	require.NoError(t, err)
+	assert.Equal(t, len(_corpus), loaded.NumDocuments())
+
+	loadedDocs, err := loaded.EmbedDocuments(ctx, _corpus[:1])
+	require.NoError(t, err)
+	assert.Equal(t, docs, loadedDocs)
+
+	q, err := e.EmbedQuery(ctx, "年假")
+	require.NoError(t, err)
+	loadedQ, err := loaded.EmbedQuery(ctx, "年假")
+	require.NoError(t, err)
+	assert.Equal(t, q, loadedQ)
+}
+
+func TestTFIDFAutoFit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e, err := New()
+	require.NoError(t, err)
+	_, err = e.EmbedDocuments(ctx, _corpus)
+	require.NoError(t, err)
+	assert.Equal(t, 0, e.NumDocuments())
+
+	e, err = New(WithAutoFit(true))
+	require.NoError(t, err)
+	_, err = e.EmbedDocuments(ctx, _corpus)
+	require.NoError(t, err)
+	assert.Equal(t, len(_corpus), e.NumDocuments())
+}
@@ -81,3 +81,23 @@ func getNorm(v []float64) float64 {
 
 	return math.Sqrt(sum)
 }
+
+// CosineSimilarity returns the cosine similarity of two vectors. It returns 0
+// if one of the vectors has zero length.
+func CosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrVectorsNotSameSize
+	}
+
+	var dot float64
+	for i := 0; i < len(a); i++ {
+		dot += a[i] * b[i]
+	}
+
+	norm := getNorm(a) * getNorm(b)
+	if norm == 0 {
+		return 0, nil
+	}
+
+	return dot / norm, nil
+}
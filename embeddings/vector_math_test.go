@@ -81,3 +81,18 @@ func TestGetNorm(t *testing.T) {
 		assert.Equal(t, tc.expected, getNorm(tc.vector))
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	similarity, err := CosineSimilarity([]float64{1, 0}, []float64{1, 1})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.7071067811865475, similarity, 1e-9)
+
+	similarity, err = CosineSimilarity([]float64{0, 0}, []float64{1, 1})
+	assert.NoError(t, err)
+	assert.Zero(t, similarity)
+
+	_, err = CosineSimilarity([]float64{1}, []float64{1, 1})
+	assert.ErrorIs(t, err, ErrVectorsNotSameSize)
+}
@@ -0,0 +1,22 @@
+/*
+Package indexing contains an indexing API that adds documents to a vector
+store incrementally.
+
+A RecordManager keeps a record of the documents written to a vector store: a
+hash of their content and metadata, the source they were loaded from and the
+time they were last indexed. When the same documents are indexed again,
+unchanged documents are skipped instead of being embedded and uploaded again.
+Changed documents get a new hash and are added, and the outdated documents
+are deleted depending on the cleanup mode:
+
+- CleanupNone: outdated documents are never deleted.
+- CleanupIncremental: documents of the sources in the indexed batch that were
+not indexed again are deleted after each batch.
+- CleanupFull: all documents that were not indexed again are deleted after
+all documents are indexed. Only use it when indexing the full data set.
+
+The vector store must implement vectorstores.Deleter for cleanup and support
+vectorstores.WithIDs. The package provides an in memory record manager, a
+SQLite record manager lives in the sqlite3 sub package.
+*/
+package indexing
@@ -0,0 +1,309 @@
+package indexing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/documentloaders"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	// ErrMismatchKeysAndGroupIDs is returned by record managers when the number
+	// of keys and group ids given to Update does not match.
+	ErrMismatchKeysAndGroupIDs = errors.New("number of keys and group ids does not match")
+	// ErrDeleteNotSupported is returned when cleanup is requested for a vector
+	// store that does not implement vectorstores.Deleter.
+	ErrDeleteNotSupported = errors.New("vector store does not support deleting documents")
+	// ErrMissingSourceID is returned by incremental cleanup when a document has
+	// no source id in its metadata.
+	ErrMissingSourceID = errors.New("document has no source id")
+	// ErrInvalidCleanupMode is returned for unknown cleanup modes.
+	ErrInvalidCleanupMode = errors.New("invalid cleanup mode")
+)
+
+// _namespace is the namespace of the UUIDs generated from document hashes.
+var _namespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/tmc/langchaingo/indexing")) //nolint:gochecknoglobals,lll
+
+// Result holds the counts of an indexing run.
+type Result struct {
+	// NumAdded is the number of new documents of new sources written to the
+	// vector store.
+	NumAdded int
+	// NumUpdated is the number of documents of already indexed sources written
+	// to the vector store, because they changed or because of WithForceUpdate.
+	// The outdated documents they replace are counted in NumDeleted.
+	NumUpdated int
+	// NumSkipped is the number of unchanged or duplicate documents.
+	NumSkipped int
+	// NumDeleted is the number of outdated documents deleted.
+	NumDeleted int
+}
+
+// IndexLoader loads documents with the loader, splits them with the splitter
+// and indexes the chunks. If the splitter is nil the documents are not split.
+func IndexLoader(
+	ctx context.Context,
+	loader documentloaders.Loader,
+	splitter textsplitter.TextSplitter,
+	recordManager RecordManager,
+	store vectorstores.VectorStore,
+	options ...Option,
+) (Result, error) {
+	var docs []schema.Document
+	var err error
+	if splitter != nil {
+		docs, err = loader.LoadAndSplit(ctx, splitter)
+	} else {
+		docs, err = loader.Load(ctx)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Index(ctx, docs, recordManager, store, options...)
+}
+
+// Index adds the documents that are not in the record manager yet to the vector
+// store and deletes outdated documents depending on the cleanup mode. Documents
+// are identified by a hash of their content and metadata.
+func Index( //nolint:cyclop
+	ctx context.Context,
+	docs []schema.Document,
+	recordManager RecordManager,
+	store vectorstores.VectorStore,
+	options ...Option,
+) (Result, error) {
+	opts := getOptions(options...)
+
+	var deleter vectorstores.Deleter
+	switch opts.Cleanup {
+	case CleanupNone:
+	case CleanupIncremental, CleanupFull:
+		var ok bool
+		if deleter, ok = store.(vectorstores.Deleter); !ok {
+			return Result{}, ErrDeleteNotSupported
+		}
+	default:
+		return Result{}, fmt.Errorf("%w: %q", ErrInvalidCleanupMode, opts.Cleanup)
+	}
+
+	start, err := recordManager.Now(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{}
+	sources := sourceIndex{recordManager: recordManager, before: start, indexed: make(map[string]bool)}
+	for i := 0; i < len(docs); i += opts.BatchSize {
+		batch := docs[i:minInt(i+opts.BatchSize, len(docs))]
+
+		sourceIDs, err := indexBatch(ctx, batch, recordManager, store, opts, sources, &result)
+		if err != nil {
+			return result, err
+		}
+
+		if opts.Cleanup == CleanupIncremental {
+			numDeleted, err := cleanup(ctx, recordManager, deleter, ListFilter{Before: start, GroupIDs: sourceIDs}, opts)
+			result.NumDeleted += numDeleted
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if opts.Cleanup == CleanupFull {
+		numDeleted, err := cleanup(ctx, recordManager, deleter, ListFilter{Before: start}, opts)
+		result.NumDeleted += numDeleted
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// indexBatch writes the new documents of the batch to the vector store, updates
+// their records and returns the source ids of the batch.
+func indexBatch(
+	ctx context.Context,
+	batch []schema.Document,
+	recordManager RecordManager,
+	store vectorstores.VectorStore,
+	opts Options,
+	sources sourceIndex,
+	result *Result,
+) ([]string, error) {
+	keys := make([]string, 0, len(batch))
+	groupIDs := make([]string, 0, len(batch))
+	uniqueDocs := make([]schema.Document, 0, len(batch))
+	seenKeys := make(map[string]struct{}, len(batch))
+	sourceIDs := make([]string, 0)
+	seenSourceIDs := make(map[string]struct{})
+
+	for _, doc := range batch {
+		key, err := hashDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seenKeys[key]; ok {
+			result.NumSkipped++
+			continue
+		}
+		seenKeys[key] = struct{}{}
+
+		sourceID, _ := doc.Metadata[opts.SourceIDKey].(string)
+		if sourceID == "" && opts.Cleanup == CleanupIncremental {
+			return nil, fmt.Errorf("%w: metadata key %q", ErrMissingSourceID, opts.SourceIDKey)
+		}
+		if _, ok := seenSourceIDs[sourceID]; !ok && sourceID != "" {
+			seenSourceIDs[sourceID] = struct{}{}
+			sourceIDs = append(sourceIDs, sourceID)
+		}
+
+		keys = append(keys, key)
+		groupIDs = append(groupIDs, sourceID)
+		uniqueDocs = append(uniqueDocs, doc)
+	}
+
+	exists, err := recordManager.Exists(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	addKeys := make([]string, 0, len(keys))
+	addDocs := make([]schema.Document, 0, len(keys))
+	numAdded, numUpdated := 0, 0
+	for i, key := range keys {
+		if exists[i] && !opts.ForceUpdate {
+			result.NumSkipped++
+			continue
+		}
+		indexed := exists[i]
+		if !indexed {
+			if indexed, err = sources.isIndexed(ctx, groupIDs[i]); err != nil {
+				return nil, err
+			}
+		}
+		if indexed {
+			numUpdated++
+		} else {
+			numAdded++
+		}
+		addKeys = append(addKeys, key)
+		addDocs = append(addDocs, uniqueDocs[i])
+	}
+
+	if len(addDocs) > 0 {
+		storeOptions := append([]vectorstores.Option{vectorstores.WithIDs(addKeys)}, opts.StoreOptions...)
+		if err := store.AddDocuments(ctx, addDocs, storeOptions...); err != nil {
+			return nil, err
+		}
+	}
+	result.NumAdded += numAdded
+	result.NumUpdated += numUpdated
+
+	// Skipped documents are updated too, so cleanup knows they are still current.
+	if err := recordManager.Update(ctx, keys, groupIDs); err != nil {
+		return nil, err
+	}
+
+	return sourceIDs, nil
+}
+
+// sourceIndex tells whether sources had documents indexed before the start of
+// an indexing run. Every source is looked up once per run, before the run
+// updates its records.
+type sourceIndex struct {
+	recordManager RecordManager
+	before        time.Time
+	indexed       map[string]bool
+}
+
+func (s sourceIndex) isIndexed(ctx context.Context, sourceID string) (bool, error) {
+	if sourceID == "" {
+		return false, nil
+	}
+	if indexed, ok := s.indexed[sourceID]; ok {
+		return indexed, nil
+	}
+	keys, err := s.recordManager.ListKeys(ctx, ListFilter{Before: s.before, GroupIDs: []string{sourceID}})
+	if err != nil {
+		return false, err
+	}
+	s.indexed[sourceID] = len(keys) > 0
+	return len(keys) > 0, nil
+}
+
+// cleanup deletes the documents of the records matching the filter from the
+// vector store and the record manager and returns the number deleted.
+func cleanup(
+	ctx context.Context,
+	recordManager RecordManager,
+	deleter vectorstores.Deleter,
+	filter ListFilter,
+	opts Options,
+) (int, error) {
+	keys, err := recordManager.ListKeys(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	numDeleted := 0
+	for i := 0; i < len(keys); i += opts.BatchSize {
+		batch := keys[i:minInt(i+opts.BatchSize, len(keys))]
+		if err := deleter.Delete(ctx, batch, opts.StoreOptions...); err != nil {
+			return numDeleted, err
+		}
+		if err := recordManager.DeleteKeys(ctx, batch); err != nil {
+			return numDeleted, err
+		}
+		numDeleted += len(batch)
+	}
+	return numDeleted, nil
+}
+
+// hashDocument returns a UUID derived from the content and metadata of the
+// document, so it can be used as id by all vector stores.
+func hashDocument(doc schema.Document) (string, error) {
+	metadata := doc.Metadata
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	data, err := json.Marshal(struct {
+		PageContent string         `json:"page_content"`
+		Metadata    map[string]any `json:"metadata"`
+	}{doc.PageContent, metadata})
+	if err != nil {
+		return "", err
+	}
+	return uuid.NewSHA1(_namespace, data).String(), nil
+}
+
+func getOptions(options ...Option) Options {
+	opts := Options{
+		Cleanup:     CleanupNone,
+		SourceIDKey: _defaultSourceIDKey,
+		BatchSize:   _defaultBatchSize,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = _defaultBatchSize
+	}
+	return opts
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package indexing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// mapStore is a vector store keeping documents by id.
+type mapStore struct {
+	docs    map[string]schema.Document
+	numAdds int
+}
+
+func newMapStore() *mapStore {
+	return &mapStore{docs: map[string]schema.Document{}}
+}
+
+func (s *mapStore) AddDocuments(_ context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if len(opts.IDs) != len(docs) {
+		return vectorstores.ErrMismatchIDsAndDocuments
+	}
+	for i, doc := range docs {
+		s.docs[opts.IDs[i]] = doc
+	}
+	s.numAdds += len(docs)
+	return nil
+}
+
+func (s *mapStore) SimilaritySearch(context.Context, string, int, ...vectorstores.Option) ([]schema.Document, error) {
+	return nil, nil
+}
+
+func (s *mapStore) Delete(_ context.Context, ids []string, _ ...vectorstores.Option) error {
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+	return nil
+}
+
+// addOnlyStore is a vector store without delete support.
+type addOnlyStore struct{}
+
+func (addOnlyStore) AddDocuments(context.Context, []schema.Document, ...vectorstores.Option) error {
+	return nil
+}
+
+func (addOnlyStore) SimilaritySearch(context.Context, string, int, ...vectorstores.Option) ([]schema.Document, error) {
+	return nil, nil
+}
+
+type staticLoader []schema.Document
+
+func (l staticLoader) Load(context.Context) ([]schema.Document, error) {
+	return l, nil
+}
+
+func (l staticLoader) LoadAndSplit(_ context.Context, splitter textsplitter.TextSplitter) ([]schema.Document, error) {
+	return textsplitter.SplitDocuments(splitter, l)
+}
+
+func doc(content, source string) schema.Document {
+	return schema.Document{PageContent: content, Metadata: map[string]any{"source": source}}
+}
+
+func storeContents(s *mapStore) []string {
+	contents := make([]string, 0, len(s.docs))
+	for _, doc := range s.docs {
+		contents = append(contents, doc.PageContent)
+	}
+	return contents
+}
+
+func TestIndexIncremental(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newMapStore()
+	records := NewInMemory()
+
+	docs := []schema.Document{doc("年假五天", "a.txt"), doc("病假十天", "a.txt"), doc("加班调休", "b.txt"), doc("加班调休", "b.txt")}
+	result, err := Index(ctx, docs, records, store, WithCleanup(CleanupIncremental), WithBatchSize(2))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumAdded: 3, NumSkipped: 1}, result)
+
+	result, err = Index(ctx, docs, records, store, WithCleanup(CleanupIncremental))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumSkipped: 4}, result)
+	assert.Equal(t, 3, store.numAdds)
+
+	result, err = Index(ctx, []schema.Document{doc("年假十五天", "a.txt"), doc("病假十天", "a.txt")},
+		records, store, WithCleanup(CleanupIncremental))
+	require.NoError(t, err)
+	// The changed chunk of a.txt replaces the outdated one.
+	assert.Equal(t, Result{NumUpdated: 1, NumSkipped: 1, NumDeleted: 1}, result)
+	assert.ElementsMatch(t, []string{"年假十五天", "病假十天", "加班调休"}, storeContents(store))
+
+	result, err = Index(ctx, docs[:1], records, store, WithCleanup(CleanupNone), WithForceUpdate(true))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumUpdated: 1}, result)
+
+	// The chunks of a new source are added, even when split across batches.
+	result, err = Index(ctx, []schema.Document{doc("调休一天", "c.txt"), doc("调休两天", "c.txt")},
+		records, store, WithCleanup(CleanupIncremental), WithBatchSize(1))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumAdded: 2}, result)
+
+	result, err = Index(ctx, docs[:1], records, store, WithForceUpdate(true))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumUpdated: 1}, result)
+
+	_, err = Index(ctx, []schema.Document{{PageContent: "无来源"}}, records, store, WithCleanup(CleanupIncremental))
+	require.ErrorIs(t, err, ErrMissingSourceID)
+}
+
+func TestIndexFull(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newMapStore()
+	records := NewInMemory()
+
+	loader := staticLoader{doc("年假五天\n\n病假十天", "a.txt"), doc("加班调休", "b.txt")}
+	splitter := textsplitter.NewRecursiveCharacter()
+	splitter.ChunkSize = 15
+	splitter.ChunkOverlap = 0
+
+	result, err := IndexLoader(ctx, loader, splitter, records, store, WithCleanup(CleanupFull))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumAdded: 3}, result)
+
+	result, err = IndexLoader(ctx, loader[1:], nil, records, store, WithCleanup(CleanupFull))
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumSkipped: 1, NumDeleted: 2}, result)
+	assert.Equal(t, []string{"加班调休"}, storeContents(store))
+
+	keys, err := records.ListKeys(ctx, ListFilter{})
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestIndexErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := Index(ctx, nil, NewInMemory(), addOnlyStore{}, WithCleanup(CleanupFull))
+	require.ErrorIs(t, err, ErrDeleteNotSupported)
+
+	_, err = Index(ctx, nil, NewInMemory(), addOnlyStore{}, WithCleanup("partial"))
+	require.ErrorIs(t, err, ErrInvalidCleanupMode)
+
+	result, err := Index(ctx, []schema.Document{{PageContent: "a"}}, NewInMemory(), addOnlyStore{})
+	require.NoError(t, err)
+	assert.Equal(t, Result{NumAdded: 1}, result)
+}
@@ -0,0 +1,80 @@
+package indexing
+
+import (
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	_defaultSourceIDKey = "source"
+	_defaultBatchSize   = 100
+)
+
+// CleanupMode selects which outdated documents are deleted from the vector store.
+type CleanupMode string
+
+// The cleanup modes of indexing.
+const (
+	CleanupNone        CleanupMode = "none"
+	CleanupIncremental CleanupMode = "incremental"
+	CleanupFull        CleanupMode = "full"
+)
+
+// Options is a set of options for indexing.
+type Options struct {
+	Cleanup      CleanupMode
+	SourceIDKey  string
+	BatchSize    int
+	ForceUpdate  bool
+	StoreOptions []vectorstores.Option
+}
+
+// Option is a function type that can be used to modify the indexing options.
+type Option func(*Options)
+
+// WithCleanup is an option for setting the cleanup mode. Defaults to CleanupNone.
+func WithCleanup(cleanup CleanupMode) Option {
+	return func(o *Options) {
+		o.Cleanup = cleanup
+	}
+}
+
+// WithSourceIDKey is an option for setting the metadata key holding the source
+// of a document, like the file path. Defaults to "source".
+func WithSourceIDKey(sourceIDKey string) Option {
+	return func(o *Options) {
+		o.SourceIDKey = sourceIDKey
+	}
+}
+
+// WithBatchSize is an option for setting the number of documents added to the
+// vector store at once. Defaults to 100.
+func WithBatchSize(batchSize int) Option {
+	return func(o *Options) {
+		o.BatchSize = batchSize
+	}
+}
+
+// WithForceUpdate is an option for writing documents to the vector store even
+// if they are unchanged, e.g. after changing the embedder.
+func WithForceUpdate(forceUpdate bool) Option {
+	return func(o *Options) {
+		o.ForceUpdate = forceUpdate
+	}
+}
+
+// WithEmbedder is an option for setting the embedder used by the vector store
+// instead of its own embedder.
+func WithEmbedder(embedder embeddings.Embedder) Option {
+	return func(o *Options) {
+		o.StoreOptions = append(o.StoreOptions, vectorstores.WithEmbedder(embedder))
+	}
+}
+
+// WithStoreOptions is an option for setting options passed to the vector store
+// when adding and deleting documents, like a name space.
+func WithStoreOptions(opts ...vectorstores.Option) Option {
+	return func(o *Options) {
+		o.StoreOptions = append(o.StoreOptions, opts...)
+	}
+}
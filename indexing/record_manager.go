@@ -0,0 +1,120 @@
+package indexing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RecordManager is the interface for keeping track of the documents written to
+// a vector store.
+type RecordManager interface {
+	// Now returns the current time of the record manager. Records updated after
+	// a call to Now must have a time at or after the returned time.
+	Now(ctx context.Context) (time.Time, error)
+	// Update creates or updates the records of the keys with the group ids and
+	// the current time.
+	Update(ctx context.Context, keys []string, groupIDs []string) error
+	// Exists returns for each key whether a record of it exists.
+	Exists(ctx context.Context, keys []string) ([]bool, error)
+	// ListKeys returns the keys of the records matching the filter.
+	ListKeys(ctx context.Context, filter ListFilter) ([]string, error)
+	// DeleteKeys deletes the records of the keys.
+	DeleteKeys(ctx context.Context, keys []string) error
+}
+
+// ListFilter is a filter of the records listed by a record manager. Zero
+// values do not filter.
+type ListFilter struct {
+	// Before only matches records updated before the time.
+	Before time.Time
+	// GroupIDs only matches records with one of the group ids.
+	GroupIDs []string
+}
+
+type record struct {
+	groupID   string
+	updatedAt time.Time
+}
+
+// InMemory is a record manager keeping the records in a map. It is safe for
+// concurrent use.
+type InMemory struct {
+	mu      sync.RWMutex
+	records map[string]record
+}
+
+var _ RecordManager = &InMemory{}
+
+// NewInMemory creates a new empty in memory record manager.
+func NewInMemory() *InMemory {
+	return &InMemory{records: make(map[string]record)}
+}
+
+// Now returns the current time.
+func (m *InMemory) Now(context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+// Update creates or updates the records of the keys.
+func (m *InMemory) Update(_ context.Context, keys []string, groupIDs []string) error {
+	if len(keys) != len(groupIDs) {
+		return ErrMismatchKeysAndGroupIDs
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for i, key := range keys {
+		m.records[key] = record{groupID: groupIDs[i], updatedAt: now}
+	}
+	return nil
+}
+
+// Exists returns for each key whether a record of it exists.
+func (m *InMemory) Exists(_ context.Context, keys []string) ([]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exists := make([]bool, 0, len(keys))
+	for _, key := range keys {
+		_, ok := m.records[key]
+		exists = append(exists, ok)
+	}
+	return exists, nil
+}
+
+// ListKeys returns the keys of the records matching the filter.
+func (m *InMemory) ListKeys(_ context.Context, filter ListFilter) ([]string, error) {
+	groupIDs := make(map[string]struct{}, len(filter.GroupIDs))
+	for _, groupID := range filter.GroupIDs {
+		groupIDs[groupID] = struct{}{}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for key, r := range m.records {
+		if !filter.Before.IsZero() && !r.updatedAt.Before(filter.Before) {
+			continue
+		}
+		if _, ok := groupIDs[r.groupID]; len(groupIDs) > 0 && !ok {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// DeleteKeys deletes the records of the keys.
+func (m *InMemory) DeleteKeys(_ context.Context, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.records, key)
+	}
+	return nil
+}
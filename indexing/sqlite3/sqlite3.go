@@ -0,0 +1,221 @@
+// Package sqlite3 contains a record manager for the indexing package keeping
+// its records in a SQLite database.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
+	"github.com/tmc/langchaingo/indexing"
+)
+
+const (
+	_driverName = "sqlite3"
+	// _maxParams is kept below the default SQLite limit of 999 parameters.
+	_maxParams = 500
+
+	_createTable = `CREATE TABLE IF NOT EXISTS upsertion_records (
+	key TEXT NOT NULL,
+	namespace TEXT NOT NULL,
+	group_id TEXT NOT NULL,
+	updated_at INTEGER NOT NULL,
+	PRIMARY KEY (key, namespace)
+);
+CREATE INDEX IF NOT EXISTS upsertion_records_group_id ON upsertion_records (namespace, group_id);
+CREATE INDEX IF NOT EXISTS upsertion_records_updated_at ON upsertion_records (namespace, updated_at);`
+)
+
+// RecordManager is a record manager keeping its records in a SQLite table.
+// Several record managers can share a database by using different namespaces.
+type RecordManager struct {
+	db        *sql.DB
+	namespace string
+}
+
+var _ indexing.RecordManager = RecordManager{}
+
+// New opens the SQLite database of the dsn, e.g. "records.sqlite", and creates
+// a record manager for the namespace in it.
+func New(dsn string, namespace string) (RecordManager, error) {
+	db, err := sql.Open(_driverName, dsn)
+	if err != nil {
+		return RecordManager{}, err
+	}
+	db.SetMaxOpenConns(1)
+
+	return NewFromDB(db, namespace)
+}
+
+// NewFromDB creates a record manager for the namespace in an open SQLite
+// database. The records table is created if it does not exist.
+func NewFromDB(db *sql.DB, namespace string) (RecordManager, error) {
+	if _, err := db.Exec(_createTable); err != nil {
+		return RecordManager{}, err
+	}
+	return RecordManager{db: db, namespace: namespace}, nil
+}
+
+// Close closes the database.
+func (m RecordManager) Close() error {
+	return m.db.Close()
+}
+
+// Now returns the current time.
+func (m RecordManager) Now(context.Context) (time.Time, error) {
+	return time.Now(), nil
+}
+
+// Update creates or updates the records of the keys.
+func (m RecordManager) Update(ctx context.Context, keys []string, groupIDs []string) error {
+	if len(keys) != len(groupIDs) {
+		return indexing.ErrMismatchKeysAndGroupIDs
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO upsertion_records (key, namespace, group_id, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (key, namespace) DO UPDATE SET group_id = excluded.group_id, updated_at = excluded.updated_at`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now().UnixNano()
+	for i, key := range keys {
+		if _, err := stmt.ExecContext(ctx, key, m.namespace, groupIDs[i], now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Exists returns for each key whether a record of it exists.
+func (m RecordManager) Exists(ctx context.Context, keys []string) ([]bool, error) {
+	found := make(map[string]struct{}, len(keys))
+	for i := 0; i < len(keys); i += _maxParams {
+		batch := keys[i:minInt(i+_maxParams, len(keys))]
+
+		args := make([]any, 0, len(batch)+1)
+		args = append(args, m.namespace)
+		for _, key := range batch {
+			args = append(args, key)
+		}
+
+		rows, err := m.db.QueryContext(ctx,
+			"SELECT key FROM upsertion_records WHERE namespace = ? AND key IN ("+placeholders(len(batch))+")",
+			args...)
+		if err != nil {
+			return nil, err
+		}
+		foundKeys, err := scanKeys(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range foundKeys {
+			found[key] = struct{}{}
+		}
+	}
+
+	exists := make([]bool, 0, len(keys))
+	for _, key := range keys {
+		_, ok := found[key]
+		exists = append(exists, ok)
+	}
+	return exists, nil
+}
+
+// ListKeys returns the keys of the records matching the filter. Group IDs are
+// queried in batches, like the keys of Exists and DeleteKeys.
+func (m RecordManager) ListKeys(ctx context.Context, filter indexing.ListFilter) ([]string, error) {
+	query := "SELECT key FROM upsertion_records WHERE namespace = ?"
+	args := []any{m.namespace}
+
+	if !filter.Before.IsZero() {
+		query += " AND updated_at < ?"
+		args = append(args, filter.Before.UnixNano())
+	}
+
+	if len(filter.GroupIDs) == 0 {
+		rows, err := m.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		return scanKeys(rows)
+	}
+
+	keys := make([]string, 0)
+	for i := 0; i < len(filter.GroupIDs); i += _maxParams {
+		batch := filter.GroupIDs[i:minInt(i+_maxParams, len(filter.GroupIDs))]
+
+		batchArgs := append(make([]any, 0, len(args)+len(batch)), args...)
+		for _, groupID := range batch {
+			batchArgs = append(batchArgs, groupID)
+		}
+
+		rows, err := m.db.QueryContext(ctx, query+" AND group_id IN ("+placeholders(len(batch))+")", batchArgs...)
+		if err != nil {
+			return nil, err
+		}
+		batchKeys, err := scanKeys(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batchKeys...)
+	}
+	return keys, nil
+}
+
+// DeleteKeys deletes the records of the keys.
+func (m RecordManager) DeleteKeys(ctx context.Context, keys []string) error {
+	for i := 0; i < len(keys); i += _maxParams {
+		batch := keys[i:minInt(i+_maxParams, len(keys))]
+
+		args := make([]any, 0, len(batch)+1)
+		args = append(args, m.namespace)
+		for _, key := range batch {
+			args = append(args, key)
+		}
+
+		_, err := m.db.ExecContext(ctx,
+			"DELETE FROM upsertion_records WHERE namespace = ? AND key IN ("+placeholders(len(batch))+")",
+			args...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanKeys(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
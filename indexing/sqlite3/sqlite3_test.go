@@ -0,0 +1,71 @@
+package sqlite3_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/indexing"
+	"github.com/tmc/langchaingo/indexing/sqlite3"
+)
+
+func TestRecordManager(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dsn := filepath.Join(t.TempDir(), "records.sqlite")
+	records, err := sqlite3.New(dsn, "store-a")
+	require.NoError(t, err)
+	defer records.Close()
+
+	keys := make([]string, 0, 600)
+	groupIDs := make([]string, 0, 600)
+	for i := 0; i < 600; i++ {
+		keys = append(keys, fmt.Sprintf("key-%d", i))
+		groupIDs = append(groupIDs, fmt.Sprintf("source-%d", i%2))
+	}
+	require.NoError(t, records.Update(ctx, keys, groupIDs))
+
+	exists, err := records.Exists(ctx, []string{"key-0", "key-599", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, exists)
+
+	start, err := records.Now(ctx)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	require.NoError(t, records.Update(ctx, []string{"key-0", "key-1"}, []string{"source-0", "source-1"}))
+
+	listed, err := records.ListKeys(ctx, indexing.ListFilter{Before: start, GroupIDs: []string{"source-1"}})
+	require.NoError(t, err)
+	assert.Len(t, listed, 299)
+	assert.NotContains(t, listed, "key-1")
+
+	manyGroupIDs := []string{"source-0", "source-1"}
+	for i := 2; i < 1200; i++ {
+		manyGroupIDs = append(manyGroupIDs, fmt.Sprintf("source-%d", i))
+	}
+	listed, err = records.ListKeys(ctx, indexing.ListFilter{GroupIDs: manyGroupIDs[1:]})
+	require.NoError(t, err)
+	assert.Len(t, listed, 300)
+	listed, err = records.ListKeys(ctx, indexing.ListFilter{GroupIDs: manyGroupIDs})
+	require.NoError(t, err)
+	assert.Len(t, listed, 600)
+
+	require.NoError(t, records.DeleteKeys(ctx, keys[2:]))
+	listed, err = records.ListKeys(ctx, indexing.ListFilter{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"key-0", "key-1"}, listed)
+
+	other, err := sqlite3.New(dsn, "store-b")
+	require.NoError(t, err)
+	defer other.Close()
+	exists, err = other.Exists(ctx, []string{"key-0"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false}, exists)
+
+	require.ErrorIs(t, records.Update(ctx, []string{"key-0"}, nil), indexing.ErrMismatchKeysAndGroupIDs)
+}
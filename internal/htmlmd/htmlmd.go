@@ -0,0 +1,357 @@
+// Package htmlmd converts HTML to Markdown, keeping headers, lists, tables,
+// code blocks and links.
+package htmlmd
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+var _spacesRegexp = regexp.MustCompile(`\s+`)
+
+// _skippedElements are not rendered at all.
+var _skippedElements = map[string]bool{ //nolint:gochecknoglobals
+	"head": true, "script": true, "style": true, "noscript": true,
+	"template": true, "svg": true, "iframe": true, "canvas": true,
+	"button": true, "select": true, "input": true, "textarea": true,
+}
+
+// _blockElements are rendered as paragraphs.
+var _blockElements = map[string]bool{ //nolint:gochecknoglobals
+	"p": true, "div": true, "section": true, "article": true, "main": true,
+	"header": true, "footer": true, "nav": true, "aside": true, "figure": true,
+	"figcaption": true, "address": true, "details": true, "summary": true,
+	"dl": true, "dt": true, "dd": true, "form": true, "fieldset": true, "body": true,
+}
+
+// Content returns the main content of the document: the elements matching the
+// selector if it is not empty and matches, and the body otherwise.
+func Content(doc *goquery.Document, selector string) *goquery.Selection {
+	if selector != "" {
+		if sel := doc.Find(selector); sel.Length() > 0 {
+			return sel
+		}
+	}
+	if body := doc.Find("body"); body.Length() > 0 {
+		return body
+	}
+	return doc.Selection
+}
+
+// Table returns the rows as a markdown table with the first row as header.
+// Rows are padded to the widest row, and the cells are trimmed and kept on a
+// single line.
+func Table(rows [][]string) string {
+	numColumns := 0
+	for _, row := range rows {
+		if len(row) > numColumns {
+			numColumns = len(row)
+		}
+	}
+	if numColumns == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < numColumns; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cell = strings.ReplaceAll(cell, "|", `\|`)
+			cell = strings.ReplaceAll(cell, "\n", " ")
+			sb.WriteString(" " + strings.TrimSpace(cell) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", numColumns) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Convert returns the selection as Markdown. Relative links and images are
+// resolved against the base URL if it is not nil. Links with a javascript
+// URL are replaced by their text.
+func Convert(sel *goquery.Selection, base *url.URL) string {
+	c := converter{base: base}
+
+	blocks := make([]string, 0, sel.Length())
+	for _, n := range sel.Nodes {
+		blocks = append(blocks, c.render(n))
+	}
+	return cleanup(strings.Join(blocks, "\n\n"))
+}
+
+type converter struct {
+	base *url.URL
+}
+
+func (c converter) render(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return _spacesRegexp.ReplaceAllString(n.Data, " ")
+	case html.DocumentNode:
+		return c.renderChildren(n)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	tag := n.Data
+	switch {
+	case _skippedElements[tag]:
+		return ""
+	case _blockElements[tag]:
+		return block(c.renderChildren(n))
+	}
+
+	switch tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(tag[1:])
+		text := inline(c.renderChildren(n))
+		if text == "" {
+			return ""
+		}
+		return block(strings.Repeat("#", level) + " " + text)
+	case "br":
+		return "\n"
+	case "hr":
+		return block("---")
+	case "strong", "b":
+		return wrap(c.renderChildren(n), "**")
+	case "em", "i":
+		return wrap(c.renderChildren(n), "*")
+	case "del", "s":
+		return wrap(c.renderChildren(n), "~~")
+	case "code", "kbd", "samp":
+		return wrap(textContent(n), "`")
+	case "pre":
+		return c.renderPre(n)
+	case "a":
+		return c.renderLink(n)
+	case "img":
+		return c.renderImage(n)
+	case "ul", "ol":
+		return c.renderList(n)
+	case "blockquote":
+		return renderQuote(c.renderChildren(n))
+	case "table":
+		return c.renderTable(n)
+	default:
+		return c.renderChildren(n)
+	}
+}
+
+func (c converter) renderChildren(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(c.render(child))
+	}
+	return sb.String()
+}
+
+func (c converter) renderPre(n *html.Node) string {
+	language := codeLanguage(n)
+	if code := n.FirstChild; code != nil && code.Type == html.ElementNode && code.Data == "code" && language == "" {
+		language = codeLanguage(code)
+	}
+	code := strings.Trim(textContent(n), "\n")
+	return "\n\n```" + language + "\n" + code + "\n```\n\n"
+}
+
+func (c converter) renderLink(n *html.Node) string {
+	text := inline(c.renderChildren(n))
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return text
+	}
+	if text == "" {
+		return ""
+	}
+	return "[" + text + "](" + c.resolve(href) + ")"
+}
+
+func (c converter) renderImage(n *html.Node) string {
+	src := strings.TrimSpace(attr(n, "src"))
+	if src == "" || strings.HasPrefix(src, "data:") {
+		return ""
+	}
+	return "![" + strings.TrimSpace(attr(n, "alt")) + "](" + c.resolve(src) + ")"
+}
+
+func (c converter) renderList(n *html.Node) string {
+	ordered := n.Data == "ol"
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil && ordered {
+		number = start
+	}
+
+	items := make([]string, 0)
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+
+		prefix := "- "
+		if ordered {
+			prefix = strconv.Itoa(number) + ". "
+			number++
+		}
+		indent := strings.Repeat(" ", len(prefix))
+
+		lines := make([]string, 0)
+		for _, line := range strings.Split(strings.TrimSpace(cleanup(c.renderChildren(li))), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if len(lines) == 0 {
+				lines = append(lines, prefix+line)
+				continue
+			}
+			lines = append(lines, indent+line)
+		}
+		if len(lines) == 0 {
+			lines = append(lines, strings.TrimSpace(prefix))
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return block(strings.Join(items, "\n"))
+}
+
+func (c converter) renderTable(n *html.Node) string {
+	rows := make([][]string, 0)
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.Data != "tr" {
+				walk(child)
+				continue
+			}
+
+			row := make([]string, 0)
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					row = append(row, inline(c.renderChildren(cell)))
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	walk(n)
+
+	return block(Table(rows))
+}
+
+func (c converter) resolve(ref string) string {
+	if c.base == nil {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func renderQuote(content string) string {
+	lines := strings.Split(strings.TrimSpace(cleanup(content)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return block(strings.Join(lines, "\n"))
+}
+
+func codeLanguage(n *html.Node) string {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(class, prefix) {
+				return strings.TrimPrefix(class, prefix)
+			}
+		}
+	}
+	return ""
+}
+
+func block(content string) string {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return ""
+	}
+	return "\n\n" + content + "\n\n"
+}
+
+// inline returns the content on a single line.
+func inline(content string) string {
+	return strings.TrimSpace(_spacesRegexp.ReplaceAllString(content, " "))
+}
+
+func wrap(content, marker string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+	leading := content[:strings.Index(content, trimmed)]
+	trailing := content[len(leading)+len(trimmed):]
+	return leading + marker + trimmed + marker + trailing
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+// cleanup trims the lines and removes repeated blank lines outside of fenced
+// code blocks.
+func cleanup(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			// Indentation of list items is at least two spaces, a single space
+			// is left over from collapsing whitespace.
+			line = strings.TrimRight(line, " \t")
+			if !strings.HasPrefix(line, "  ") {
+				line = strings.TrimLeft(line, " \t")
+			}
+			if line == "" && (len(result) == 0 || result[len(result)-1] == "") {
+				continue
+			}
+		}
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
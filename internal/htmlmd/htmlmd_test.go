@@ -0,0 +1,90 @@
+package htmlmd
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func convert(t *testing.T, src, selector, base string) string {
+	t.Helper()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(src))
+	require.NoError(t, err)
+
+	var baseURL *url.URL
+	if base != "" {
+		baseURL, err = url.Parse(base)
+		require.NoError(t, err)
+	}
+	return Convert(Content(doc, selector), baseURL)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	src := `<html><head><title>Ignored</title><style>p{}</style></head><body>
+<nav><a href="/">Home</a></nav>
+<main>
+  <h1>Guide</h1>
+  <p>Read the <a href="/docs/intro">intro</a> and <strong>then</strong>  <em>start</em>.<br>New line with <code>go test</code>.</p>
+  <h2>Steps</h2>
+  <ol>
+    <li>Install</li>
+    <li>Configure
+      <ul><li>Edit <b>config</b></li><li>Save</li></ul>
+    </li>
+  </ol>
+  <pre><code class="language-go">func main() {
+
+	fmt.Println("hi")
+}</code></pre>
+  <table>
+    <thead><tr><th>Name</th><th>Value</th></tr></thead>
+    <tbody><tr><td>a|b</td><td>1</td></tr></tbody>
+  </table>
+  <blockquote><p>Quoted</p><p>text</p></blockquote>
+  <img src="img/logo.png" alt="Logo">
+  <p><a href="javascript:alert(1)">unsafe</a></p>
+  <script>alert(1)</script>
+</main>
+</body></html>`
+
+	expected := "# Guide\n\n" +
+		"Read the [intro](https://example.com/docs/intro) and **then** *start*.\n" +
+		"New line with `go test`.\n\n" +
+		"## Steps\n\n" +
+		"1. Install\n" +
+		"2. Configure\n" +
+		"   - Edit **config**\n" +
+		"   - Save\n\n" +
+		"```go\nfunc main() {\n\n\tfmt.Println(\"hi\")\n}\n```\n\n" +
+		"| Name | Value |\n| --- | --- |\n| a\\|b | 1 |\n\n" +
+		"> Quoted\n>\n> text\n\n" +
+		"![Logo](https://example.com/guide/img/logo.png)\n\n" +
+		"unsafe"
+
+	assert.Equal(t, expected, convert(t, src, "main", "https://example.com/guide/"))
+}
+
+func TestContent(t *testing.T) {
+	t.Parallel()
+
+	src := `<body><nav>Menu</nav><article><p>Body</p></article></body>`
+
+	assert.Equal(t, "Body", convert(t, src, "article", ""))
+	assert.Equal(t, "Menu\n\nBody", convert(t, src, ".missing", ""))
+	assert.Equal(t, "[rel](rel/page)", convert(t, `<a href="rel/page">rel</a>`, "", ""))
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", Table(nil))
+	assert.Equal(t, "| a | b |\n| --- | --- |\n| x\\|y | two lines |\n| z |  |",
+		Table([][]string{{"a", "b"}, {"x|y", " two\nlines "}, {"z"}}))
+}
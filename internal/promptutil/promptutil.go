@@ -0,0 +1,31 @@
+// Package promptutil contains helpers shared by the prompts of the retrievers
+// and the parsing of their outputs.
+package promptutil
+
+import (
+	"regexp"
+	"strings"
+)
+
+// _listMarkerRegex matches numbering and bullets LLMs put in front of list items.
+var _listMarkerRegex = regexp.MustCompile(`^\s*(?:\d+[.)、:：]|[-*•])\s*`)
+
+// SameLanguage returns the instruction to write the output in the same
+// language as the input, like "Write the summary in the same language as the
+// document.", so the output matches the language of the indexed documents.
+func SameLanguage(output, input string) string {
+	return "Write " + output + " in the same language as " + input + "."
+}
+
+// ListItems returns the non-empty lines of the output of an LLM asked for one
+// item per line, without the numbering or bullets LLMs often add anyway.
+func ListItems(output string) []string {
+	items := make([]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		item := strings.TrimSpace(_listMarkerRegex.ReplaceAllString(line, ""))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
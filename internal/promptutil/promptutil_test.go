@@ -0,0 +1,22 @@
+package promptutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListItems(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"first", "second", "third", "fourth", "第五"},
+		ListItems("1. first\n\n2) second\n- third\n  * fourth\n5、第五\n"))
+	assert.Empty(t, ListItems("\n \n"))
+}
+
+func TestSameLanguage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Write the summary in the same language as the document.",
+		SameLanguage("the summary", "the document"))
+}
@@ -13,26 +13,35 @@ type Usage = chatglm_client.Usage
 type LLM struct {
 	CallbacksHandler callbacks.Handler
 	client           *chatglm_client.Client
+	tokenizer        llms.Tokenizer
+	model            string
 	usage            []chatglm_client.Usage
 }
 
 var (
-	_ llms.LLM           = (*LLM)(nil)
-	_ llms.LanguageModel = (*LLM)(nil)
+	_ llms.LLM               = (*LLM)(nil)
+	_ llms.LanguageModel     = (*LLM)(nil)
+	_ llms.TokenizerProvider = (*LLM)(nil)
 )
 
 func New(opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &LLM{
-		client: c,
+		client:    c,
+		tokenizer: tokenizer,
+		model:     model,
 	}, err
 }
 
 func NewWithCallback(handler callbacks.Handler, opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &LLM{
 		CallbacksHandler: handler,
 		client:           c,
+		tokenizer:        tokenizer,
+		model:            model,
 	}, err
 }
 
@@ -99,9 +108,15 @@ func (o *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptVa
 	return llms.GeneratePrompt(ctx, o, promptValues, options...)
 }
 
-// 与openai的实现方式不同, 无法计算
+// GetNumTokens counts the tokens of the text with the tokenizer, falling back
+// to llms.EstimateTokens.
 func (o *LLM) GetNumTokens(text string) int {
-	return 0
+	return llms.EstimateTokensWith(o.tokenizer, o.model, text)
+}
+
+// Tokenizer returns the tokenizer set with WithTokenizer.
+func (o *LLM) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *LLM) ResetUsage() {
@@ -114,25 +129,34 @@ func (o *LLM) GetUsage() []Usage {
 
 // CreateEmbedding creates embeddings for the given input texts.
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float64, error) {
-	embeddings := make([][]float64, 0, 1)
 	o.ResetUsage()
+	embeddings, usage, err := o.CreateEmbeddingWithUsage(ctx, inputTexts)
+	o.usage = append(o.usage, usage...)
+	return embeddings, err
+}
+
+// CreateEmbeddingWithUsage creates embeddings for the given input texts like
+// CreateEmbedding, but returns the usage instead of recording it, so it is
+// safe for concurrent use.
+func (o *LLM) CreateEmbeddingWithUsage(ctx context.Context, inputTexts []string) ([][]float64, []Usage, error) {
+	embeddings := make([][]float64, 0, 1)
+	usage := make([]Usage, 0, len(inputTexts))
 	for _, input := range inputTexts {
 		embedding, use, err := o.client.CreateEmbedding(ctx, &chatglm_client.EmbeddingRequest{
 			Prompt: input,
 		})
 		if err != nil {
-			return nil, err
+			return nil, usage, err
 		}
 		if len(embedding) == 0 {
-			return nil, ErrEmptyResponse
+			return nil, usage, ErrEmptyResponse
 		}
 		embeddings = append(embeddings, embedding)
 		// 用于记录本次token使用情况
-		o.usage = append(o.usage, use)
-
+		usage = append(usage, use)
 	}
 	if len(inputTexts) != len(embeddings) {
-		return embeddings, ErrUnexpectedResponseLength
+		return embeddings, usage, ErrUnexpectedResponseLength
 	}
-	return embeddings, nil
+	return embeddings, usage, nil
 }
@@ -14,6 +14,8 @@ type ChatMessage = chatglm_client.ChatMessage
 type Chat struct {
 	CallbacksHandler callbacks.Handler
 	client           *chatglm_client.Client
+	tokenizer        llms.Tokenizer
+	model            string
 	usage            []chatglm_client.Usage
 }
 
@@ -23,23 +25,30 @@ const (
 )
 
 var (
-	_ llms.ChatLLM       = (*Chat)(nil)
-	_ llms.LanguageModel = (*Chat)(nil)
+	_ llms.ChatLLM           = (*Chat)(nil)
+	_ llms.LanguageModel     = (*Chat)(nil)
+	_ llms.TokenizerProvider = (*Chat)(nil)
 )
 
 // NewChat returns a new OpenAI chat LLM.
 func NewChat(opts ...Option) (*Chat, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &Chat{
-		client: c,
+		client:    c,
+		tokenizer: tokenizer,
+		model:     model,
 	}, err
 }
 
 func NewChatWithCallback(handler callbacks.Handler, opts ...Option) (*Chat, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &Chat{
 		CallbacksHandler: handler,
 		client:           c,
+		tokenizer:        tokenizer,
+		model:            model,
 	}, err
 }
 
@@ -112,7 +121,12 @@ func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage,
 }
 
 func (o *Chat) GetNumTokens(text string) int {
-	return 0
+	return llms.EstimateTokensWith(o.tokenizer, o.model, text)
+}
+
+// Tokenizer returns the tokenizer set with WithTokenizer.
+func (o *Chat) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *Chat) ResetUsage() {
@@ -1,6 +1,9 @@
 package chatglm
 
-import "github.com/tmc/langchaingo/llms/chatglm/internal/chatglm_client"
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/chatglm/internal/chatglm_client"
+)
 
 const (
 	apiIdEnvName     = "CHATGLM_API_ID"
@@ -20,6 +23,7 @@ type options struct {
 	cache           chatglm_client.Cache
 	enableSearch    bool
 	searchQuery     string
+	tokenizer       llms.Tokenizer
 }
 
 type Option func(*options)
@@ -85,3 +89,12 @@ func WithSearchQuery(searchQuery string) Option {
 		o.searchQuery = searchQuery
 	}
 }
+
+// WithTokenizer sets the tokenizer used by GetNumTokens, like the SentencePiece
+// tokenizer of the model created with tokenizer.NewSentencePiece. If not set,
+// tokens are estimated offline with llms.EstimateTokens.
+func WithTokenizer(tokenizer llms.Tokenizer) Option {
+	return func(o *options) {
+		o.tokenizer = tokenizer
+	}
+}
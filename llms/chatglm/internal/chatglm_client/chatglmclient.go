@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -28,6 +29,10 @@ type Client struct {
 	cache           Cache
 	EnableSearch    bool
 	SearchQuery     string
+
+	// mu guards token and tokenExpireTime, which requests running
+	// concurrently refresh.
+	mu sync.Mutex
 }
 
 type Option func(client *Client) error
@@ -160,6 +165,9 @@ func (c *Client) buildURL(model string, method string) string {
 }
 
 func (c *Client) getAuthorization() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.token != "" && c.tokenExpireTime > time.Now().Unix() {
 		return c.token
 	}
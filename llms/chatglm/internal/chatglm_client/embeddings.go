@@ -35,7 +35,6 @@ type EmbeddingData struct {
 
 // nolint:lll
 func (c *Client) createEmbedding(ctx context.Context, payload *embeddingPayload) (*embeddingResponsePayload, error) {
-	fmt.Printf("%#v\n", payload)
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("marshal payload: %w", err)
@@ -74,6 +73,5 @@ func (c *Client) createEmbedding(ctx context.Context, payload *embeddingPayload)
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	fmt.Printf("%#v\n", response)
 	return &response, nil
 }
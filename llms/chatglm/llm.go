@@ -2,6 +2,7 @@ package chatglm
 
 import (
 	"errors"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/chatglm/internal/chatglm_client"
 	"net/http"
 	"os"
@@ -31,3 +32,12 @@ func newClient(opts ...Option) (*chatglm_client.Client, error) {
 	return chatglm_client.New(options.id, options.secret, options.model, options.baseURL, options.token, options.tokenExpireTime,
 		options.httpClient, options.embeddingModel, options.cache, options.enableSearch, options.searchQuery)
 }
+
+// newTokenizer returns the tokenizer and the model set by the options.
+func newTokenizer(opts ...Option) (llms.Tokenizer, string) {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options.tokenizer, options.model
+}
@@ -83,6 +83,30 @@ func (o *LLM) GeneratePrompt(
 	return llms.GeneratePrompt(ctx, o, promptValues, options...)
 }
 
+// CreateRerank scores the relevance of the documents to the query with a cohere
+// rerank model. The scores are returned in the order of the documents. If model
+// is empty rerank-multilingual-v2.0 is used.
+func (o *LLM) CreateRerank(ctx context.Context, query string, documents []string, model string) ([]float64, error) {
+	results, err := o.client.CreateRerank(ctx, &cohereclient.RerankRequest{
+		Query:     query,
+		Documents: documents,
+		Model:     model,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	for _, result := range results {
+		if result.Index < 0 || result.Index >= len(documents) {
+			return nil, ErrUnexpectedResponseLength
+		}
+		scores[result.Index] = result.RelevanceScore
+	}
+
+	return scores, nil
+}
+
 func New(opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
 	return &LLM{
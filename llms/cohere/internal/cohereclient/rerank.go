@@ -0,0 +1,99 @@
+package cohereclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const _defaultRerankModel = "rerank-multilingual-v2.0"
+
+type RerankRequest struct {
+	Query     string
+	Documents []string
+	Model     string
+}
+
+type RerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+type rerankRequestPayload struct {
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	Model           string   `json:"model"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+type rerankResponsePayload struct {
+	ID      string         `json:"id,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Results []RerankResult `json:"results,omitempty"`
+}
+
+func (c *Client) CreateRerank(ctx context.Context, r *RerankRequest) ([]RerankResult, error) {
+	if c.baseURL == "" {
+		c.baseURL = "https://api.cohere.ai"
+	}
+
+	payload := rerankRequestPayload{
+		Query:     r.Query,
+		Documents: r.Documents,
+		Model:     r.Model,
+	}
+	if payload.Model == "" {
+		payload.Model = _defaultRerankModel
+	}
+
+	payloadBytes, err := json.Marshal(&payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/v1/rerank", c.baseURL),
+		bytes.NewReader(payloadBytes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("authorization", "bearer "+c.token)
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg := fmt.Sprintf("API returned unexpected status code: %d", res.StatusCode)
+
+		// No need to check the error here: if it fails, we'll just return the
+		// status code.
+		var errResp rerankResponsePayload
+		if err := json.NewDecoder(res.Body).Decode(&errResp); err != nil {
+			return nil, errors.New(msg) // nolint:goerr113
+		}
+		if strings.HasPrefix(errResp.Message, "model not found") {
+			return nil, ErrModelNotFound
+		}
+
+		return nil, fmt.Errorf("%s: %s", msg, errResp.Message) // nolint:goerr113
+	}
+
+	var response rerankResponsePayload
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	return response.Results, nil
+}
@@ -48,8 +48,18 @@ func GetModelContextSize(model string) int {
 	return contextSize
 }
 
-// CountTokens gets the number of tokens the text contains.
+// CountTokens gets the number of tokens the text contains. If a tokenizer is
+// registered for the model with RegisterTokenizer it is used, otherwise the
+// tiktoken encoding of the model, falling back to gpt2.
 func CountTokens(model, text string) int {
+	if tokenizer, ok := registeredTokenizer(model); ok {
+		n, err := tokenizer.CountTokens(text)
+		if err == nil {
+			return n
+		}
+		log.Printf("[WARN] Failed to count tokens with the tokenizer of %s: %v", model, err)
+	}
+
 	e, err := tiktoken.EncodingForModel(model)
 	if err != nil {
 		e, err = tiktoken.GetEncoding("gpt2")
@@ -16,4 +16,7 @@
 // The `llms.go` file contains the types and interfaces for interacting with different LLMs.
 //
 // The `options.go` file provides various options and functions to configure the LLMs.
+//
+// The `tokenizer.go` file contains the Tokenizer interface used for counting tokens. Implementations
+// for tiktoken, BPE and SentencePiece vocabularies and remote count tokens APIs are in llms/tokenizer/.
 package llms
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"github.com/tmc/langchaingo/callbacks"
 	"os"
+	"strings"
 
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ernie/internal/ernieclient"
+	"github.com/tmc/langchaingo/llms/tokenizer"
 	"github.com/tmc/langchaingo/schema"
 )
 
@@ -23,12 +25,14 @@ type LLM struct {
 	CallbacksHandler callbacks.Handler
 	client           *ernieclient.Client
 	model            ModelName
+	tokenizer        llms.Tokenizer
 	usage            []ernieclient.Usage
 }
 
 var (
-	_ llms.LLM           = (*LLM)(nil)
-	_ llms.LanguageModel = (*LLM)(nil)
+	_ llms.LLM               = (*LLM)(nil)
+	_ llms.LanguageModel     = (*LLM)(nil)
+	_ llms.TokenizerProvider = (*LLM)(nil)
 )
 
 // New returns a new Anthropic LLM.
@@ -42,8 +46,9 @@ func New(opts ...Option) (*LLM, error) {
 	}
 
 	return &LLM{
-		client: c,
-		model:  options.modelName,
+		client:    c,
+		model:     options.modelName,
+		tokenizer: newTokenizer(c, options),
 	}, err
 }
 
@@ -79,11 +84,27 @@ func (l *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptVa
 	return llms.GeneratePrompt(ctx, l, promptValues, options...)
 }
 
+// newTokenizer returns the tokenizer set by the options, a tokenizer using the
+// ERNIE tokenizer API of the client if enabled, or nil to estimate tokens
+// offline with llms.EstimateTokens.
+func newTokenizer(c *ernieclient.Client, options *options) llms.Tokenizer {
+	if options.tokenizer != nil || !options.remoteTokenizer || c == nil {
+		return options.tokenizer
+	}
+	model := strings.ToLower(string(options.modelName))
+	return tokenizer.NewRemote(func(ctx context.Context, text string) (int, error) {
+		return c.CountTokens(ctx, model, text)
+	})
+}
+
 // GetNumTokens implements llms.LanguageModel.
-func (l *LLM) GetNumTokens(_ string) int {
-	// todo: not provided yet
-	// see: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/Nlks5zkzu
-	return -1
+func (l *LLM) GetNumTokens(text string) int {
+	return llms.EstimateTokensWith(l.tokenizer, string(l.model), text)
+}
+
+// Tokenizer returns the tokenizer of the model.
+func (l *LLM) Tokenizer() llms.Tokenizer {
+	return l.tokenizer
 }
 
 func (l *LLM) ResetUsage() {
@@ -154,23 +175,35 @@ func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 // doc: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/alj562vvu
 func (l *LLM) CreateEmbedding(ctx context.Context, texts []string) ([][]float64, error) {
 	l.ResetUsage()
+	emb, usage, err := l.CreateEmbeddingWithUsage(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	l.usage = append(l.usage, usage...)
+	return emb, nil
+}
+
+// CreateEmbeddingWithUsage creates embeddings like CreateEmbedding, but
+// returns the usage instead of recording it, so it is safe for concurrent use.
+func (l *LLM) CreateEmbeddingWithUsage(ctx context.Context, texts []string) ([][]float64, []Usage, error) {
 	resp, e := l.client.CreateEmbedding(ctx, texts)
 	if e != nil {
-		return nil, e
+		return nil, nil, e
 	}
 
 	if resp.ErrorCode > 0 {
-		return nil, fmt.Errorf("%w, error_code:%v, erro_msg:%v",
+		return nil, nil, fmt.Errorf("%w, error_code:%v, erro_msg:%v",
 			ErrCodeResponse, resp.ErrorCode, resp.ErrorMsg)
 	}
 
 	emb := make([][]float64, 0, len(texts))
+	usage := make([]Usage, 0, len(resp.Data))
 	for i := range resp.Data {
 		emb = append(emb, resp.Data[i].Embedding)
-		l.usage = append(l.usage, resp.Usage)
+		usage = append(usage, resp.Usage)
 	}
 
-	return emb, nil
+	return emb, usage, nil
 }
 
 func (l *LLM) getModelPath(opts llms.CallOptions) ernieclient.ModelPath {
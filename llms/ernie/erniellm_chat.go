@@ -14,11 +14,13 @@ type Chat struct {
 	client           *ernieclient.Client
 	usage            []ernieclient.Usage
 	model            ModelName
+	tokenizer        llms.Tokenizer
 }
 
 var (
-	_ llms.ChatLLM       = (*Chat)(nil)
-	_ llms.LanguageModel = (*Chat)(nil)
+	_ llms.ChatLLM           = (*Chat)(nil)
+	_ llms.LanguageModel     = (*Chat)(nil)
+	_ llms.TokenizerProvider = (*Chat)(nil)
 )
 
 // NewChat returns a new OpenAI chat LLM.
@@ -30,8 +32,9 @@ func NewChat(opts ...Option) (*Chat, error) {
 		opt(options)
 	}
 	return &Chat{
-		client: c,
-		model:  options.modelName,
+		client:    c,
+		model:     options.modelName,
+		tokenizer: newTokenizer(c, options),
 	}, err
 }
 
@@ -47,6 +50,7 @@ func NewChatWithCallback(handler callbacks.Handler, opts ...Option) (*Chat, erro
 		client:           c,
 		CallbacksHandler: handler,
 		model:            options.modelName,
+		tokenizer:        newTokenizer(c, options),
 	}, err
 }
 
@@ -108,7 +112,12 @@ func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage,
 }
 
 func (o *Chat) GetNumTokens(text string) int {
-	return 0
+	return llms.EstimateTokensWith(o.tokenizer, string(o.model), text)
+}
+
+// Tokenizer returns the tokenizer of the model.
+func (o *Chat) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *Chat) ResetUsage() {
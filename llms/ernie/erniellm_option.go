@@ -1,6 +1,9 @@
 package ernie
 
-import "github.com/tmc/langchaingo/llms/ernie/internal/ernieclient"
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/ernie/internal/ernieclient"
+)
 
 const (
 	ernieAPIKey    = "ERNIE_API_KEY"    //nolint:gosec
@@ -25,6 +28,9 @@ type options struct {
 	accessToken string
 	modelName   ModelName
 	cache       ernieclient.Cache
+	tokenizer   llms.Tokenizer
+	// remoteTokenizer counts tokens with the ERNIE tokenizer API.
+	remoteTokenizer bool
 }
 
 type Option func(*options)
@@ -64,3 +70,20 @@ func WithCache(cache ernieclient.Cache) Option {
 		opts.cache = cache
 	}
 }
+
+// WithTokenizer sets the tokenizer used by GetNumTokens. If not set, tokens are
+// estimated offline with llms.EstimateTokens.
+func WithTokenizer(tokenizer llms.Tokenizer) Option {
+	return func(opts *options) {
+		opts.tokenizer = tokenizer
+	}
+}
+
+// WithRemoteTokenizer counts the tokens of GetNumTokens with the ERNIE
+// tokenizer API, which costs a request per call, instead of estimating them
+// offline. A tokenizer set by WithTokenizer takes precedence.
+func WithRemoteTokenizer() Option {
+	return func(opts *options) {
+		opts.remoteTokenizer = true
+	}
+}
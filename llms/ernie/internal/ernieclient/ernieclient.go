@@ -19,6 +19,7 @@ var (
 	ErrCompletionCode  = errors.New("completion API returned unexpected status code")
 	ErrAccessTokenCode = errors.New("get access_token API returned unexpected status code")
 	ErrEmbeddingCode   = errors.New("embedding API returned unexpected status code")
+	ErrTokenizerCode   = errors.New("tokenizer API returned unexpected status code")
 )
 
 // Client is a client for the ERNIE API.
@@ -305,6 +306,61 @@ func (c *Client) CreateEmbedding(ctx context.Context, texts []string) (*Embeddin
 	return &response, json.NewDecoder(resp.Body).Decode(&response)
 }
 
+type tokenizerResponse struct {
+	Usage Usage `json:"usage"`
+	// for error
+	ErrorCode int    `json:"error_code,omitempty"`
+	ErrorMsg  string `json:"error_msg,omitempty"`
+}
+
+// defaultTokenizerModel is the model of the ERNIE tokenizer API.
+const defaultTokenizerModel = "ernie-bot"
+
+// CountTokens counts the tokens of the prompt for the model, like "ernie-bot",
+// with the ERNIE tokenizer API. The model defaults to "ernie-bot".
+// https://cloud.baidu.com/doc/WENXINWORKSHOP/s/Nlks5zkzu
+func (c *Client) CountTokens(ctx context.Context, model, prompt string) (int, error) {
+	if model == "" {
+		model = defaultTokenizerModel
+	}
+
+	url := "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/tokenizer/erniebot?access_token=" +
+		c.accessToken
+
+	payload := make(map[string]any)
+	payload["prompt"] = prompt
+	payload["model"] = model
+
+	body, e := json.Marshal(payload)
+	if e != nil {
+		return 0, e
+	}
+
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if e != nil {
+		return 0, e
+	}
+
+	resp, e := c.httpClient.Do(req)
+	if e != nil {
+		return 0, e
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%w: %d", ErrTokenizerCode, resp.StatusCode)
+	}
+
+	var response tokenizerResponse
+	if e := json.NewDecoder(resp.Body).Decode(&response); e != nil {
+		return 0, e
+	}
+	if response.ErrorCode != 0 {
+		return 0, fmt.Errorf("%w: %d %s", ErrTokenizerCode, response.ErrorCode, response.ErrorMsg)
+	}
+	return response.Usage.TotalTokens, nil
+}
+
 // accessToken 30 day expiration https://cloud.baidu.com/doc/WENXINWORKSHOP/s/Ilkkrb0i5
 func (c *Client) getAccessToken(ctx context.Context) (*authResponse, error) {
 	url := fmt.Sprintf(
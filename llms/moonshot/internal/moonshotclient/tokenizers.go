@@ -0,0 +1,63 @@
+package moonshotclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type estimateTokenCountRequest struct {
+	Model    string         `json:"model"`
+	Messages []*ChatMessage `json:"messages"`
+}
+
+type estimateTokenCountResponse struct {
+	Data struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"data"`
+}
+
+// EstimateTokenCount counts the tokens of the text as a user message with the
+// estimate-token-count API.
+func (c *Client) EstimateTokenCount(ctx context.Context, text string) (int, error) {
+	model := c.Model
+	if model == "" {
+		model = defaultChatModel
+	}
+	payloadBytes, err := json.Marshal(&estimateTokenCountRequest{
+		Model:    model,
+		Messages: []*ChatMessage{{Role: "user", Content: text}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	baseURL := c.baseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		baseURL+"/tokenizers/estimate-token-count", bytes.NewReader(payloadBytes))
+	if err != nil {
+		return 0, err
+	}
+	c.setHeaders(req)
+
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%w: %d", getErrMsg(r.StatusCode), r.StatusCode)
+	}
+
+	var response estimateTokenCountResponse
+	if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+		return 0, err
+	}
+	return response.Data.TotalTokens, nil
+}
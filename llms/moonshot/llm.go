@@ -2,7 +2,9 @@ package moonshot
 
 import (
 	"errors"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/moonshot/internal/moonshotclient"
+	"github.com/tmc/langchaingo/llms/tokenizer"
 	"net/http"
 	"os"
 )
@@ -36,3 +38,17 @@ func newClient(opts ...Option) (*moonshotclient.Client, error) {
 
 	return moonshotclient.New(options.token, options.model, options.baseURL, options.httpClient)
 }
+
+// newTokenizer returns the tokenizer set by the options, a tokenizer using the
+// estimate-token-count API of the client if enabled, or nil to estimate tokens
+// offline with llms.EstimateTokens.
+func newTokenizer(c *moonshotclient.Client, opts ...Option) llms.Tokenizer {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.tokenizer != nil || !options.remoteTokenizer || c == nil {
+		return options.tokenizer
+	}
+	return tokenizer.NewRemote(c.EstimateTokenCount)
+}
@@ -14,6 +14,7 @@ type ChatMessage = moonshotclient.ChatMessage
 type Chat struct {
 	CallbacksHandler callbacks.Handler
 	client           *moonshotclient.Client
+	tokenizer        llms.Tokenizer
 	usage            []moonshotclient.ChatUsage
 }
 
@@ -25,15 +26,17 @@ const (
 )
 
 var (
-	_ llms.ChatLLM       = (*Chat)(nil)
-	_ llms.LanguageModel = (*Chat)(nil)
+	_ llms.ChatLLM           = (*Chat)(nil)
+	_ llms.LanguageModel     = (*Chat)(nil)
+	_ llms.TokenizerProvider = (*Chat)(nil)
 )
 
 // NewChat returns a new OpenAI chat LLM.
 func NewChat(opts ...Option) (*Chat, error) {
 	c, err := newClient(opts...)
 	return &Chat{
-		client: c,
+		client:    c,
+		tokenizer: newTokenizer(c, opts...),
 	}, err
 }
 
@@ -108,7 +111,12 @@ func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage,
 }
 
 func (o *Chat) GetNumTokens(text string) int {
-	return 0 // llms.CountTokens(o.client.Model, text)
+	return llms.EstimateTokensWith(o.tokenizer, o.client.Model, text)
+}
+
+// Tokenizer returns the tokenizer of the model.
+func (o *Chat) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *Chat) ResetUsage() {
@@ -1,6 +1,9 @@
 package moonshot
 
-import "github.com/tmc/langchaingo/llms/moonshot/internal/moonshotclient"
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/moonshot/internal/moonshotclient"
+)
 
 const (
 	tokenEnvVarName   = "OPENAI_API_KEY"  //nolint:gosec
@@ -13,6 +16,9 @@ type options struct {
 	model      string
 	baseURL    string
 	httpClient moonshotclient.Doer
+	tokenizer  llms.Tokenizer
+	// remoteTokenizer counts tokens with the estimate-token-count API.
+	remoteTokenizer bool
 
 	// required when APIType is APITypeAzure or APITypeAzureAD
 	//apiVersion     string
@@ -53,3 +59,20 @@ func WithHTTPClient(client moonshotclient.Doer) Option {
 		opts.httpClient = client
 	}
 }
+
+// WithTokenizer sets the tokenizer used by GetNumTokens. If not set, tokens are
+// estimated offline with llms.EstimateTokens.
+func WithTokenizer(tokenizer llms.Tokenizer) Option {
+	return func(opts *options) {
+		opts.tokenizer = tokenizer
+	}
+}
+
+// WithRemoteTokenizer counts the tokens of GetNumTokens with the
+// estimate-token-count API, which costs a request per call, instead of
+// estimating them offline. A tokenizer set by WithTokenizer takes precedence.
+func WithRemoteTokenizer() Option {
+	return func(opts *options) {
+		opts.remoteTokenizer = true
+	}
+}
@@ -10,13 +10,15 @@ import (
 
 type LLM struct {
 	CallbacksHandler callbacks.Handler
+	tokenizer        llms.Tokenizer
 	client           *moonshotclient.Client
 	usage            []moonshotclient.ChatUsage
 }
 
 var (
-	_ llms.LLM           = (*LLM)(nil)
-	_ llms.LanguageModel = (*LLM)(nil)
+	_ llms.LLM               = (*LLM)(nil)
+	_ llms.LanguageModel     = (*LLM)(nil)
+	_ llms.TokenizerProvider = (*LLM)(nil)
 )
 
 type Usage = moonshotclient.ChatUsage
@@ -25,7 +27,8 @@ type Usage = moonshotclient.ChatUsage
 func New(opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
 	return &LLM{
-		client: c,
+		client:    c,
+		tokenizer: newTokenizer(c, opts...),
 	}, err
 }
 
@@ -69,14 +72,7 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 		generations = append(generations, &llms.Generation{
 			Text: result.Text,
 		})
-		PromptTokens := o.GetNumTokens(prompt)
-		CompletionTokens := o.GetNumTokens(result.Text)
-		TotalTokens := PromptTokens + CompletionTokens
-		o.usage = append(o.usage, Usage{
-			PromptTokens:     PromptTokens,
-			CompletionTokens: CompletionTokens,
-			TotalTokens:      TotalTokens,
-		})
+		o.usage = append(o.usage, result.Usage)
 	}
 
 	if o.CallbacksHandler != nil {
@@ -91,7 +87,12 @@ func (o *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptVa
 }
 
 func (o *LLM) GetNumTokens(text string) int {
-	return llms.CountTokens(o.client.Model, text)
+	return llms.EstimateTokensWith(o.tokenizer, o.client.Model, text)
+}
+
+// Tokenizer returns the tokenizer of the model.
+func (o *LLM) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *LLM) ResetUsage() {
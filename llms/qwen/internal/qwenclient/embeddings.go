@@ -44,9 +44,8 @@ func (c *Client) setEmbeddingHeader(req *http.Request) {
 
 func (c *Client) createEmbedding(ctx context.Context, payload *EmbeddingPayload) (*embeddingResponsePayload, error) {
 	if payload.Model == "" {
-		c.embeddingsModel = defaultEmbeddingModel
+		payload.Model = defaultEmbeddingModel
 	}
-	c.baseURL = defaultEmbeddingURL
 	if payload.Parameters.TextType == "" {
 		payload.Parameters.TextType = "document"
 	}
@@ -56,7 +55,7 @@ func (c *Client) createEmbedding(ctx context.Context, payload *EmbeddingPayload)
 		return nil, fmt.Errorf("marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, defaultEmbeddingURL, bytes.NewReader(payloadBytes))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
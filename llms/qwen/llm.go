@@ -2,6 +2,7 @@ package qwen
 
 import (
 	"errors"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/qwen/internal/qwenclient"
 	"net/http"
 	"os"
@@ -29,3 +30,12 @@ func newClient(opts ...Option) (*qwenclient.Client, error) {
 	return qwenclient.New(options.apiKey, options.baseURL, options.model,
 		options.httpClient, options.embeddingModel, options.EnableSearch)
 }
+
+// newTokenizer returns the tokenizer and the model set by the options.
+func newTokenizer(opts ...Option) (llms.Tokenizer, string) {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options.tokenizer, options.model
+}
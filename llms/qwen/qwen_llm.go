@@ -14,26 +14,35 @@ type Usage = qwenclient.Usage
 type LLM struct {
 	CallbacksHandler callbacks.Handler
 	client           *qwenclient.Client
+	tokenizer        llms.Tokenizer
+	model            string
 	usage            []CompletionUsage
 }
 
 var (
-	_ llms.LLM           = (*LLM)(nil)
-	_ llms.LanguageModel = (*LLM)(nil)
+	_ llms.LLM               = (*LLM)(nil)
+	_ llms.LanguageModel     = (*LLM)(nil)
+	_ llms.TokenizerProvider = (*LLM)(nil)
 )
 
 func New(opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &LLM{
-		client: c,
+		client:    c,
+		tokenizer: tokenizer,
+		model:     model,
 	}, err
 }
 
 func NewWithCallback(handler callbacks.Handler, opts ...Option) (*LLM, error) {
 	c, err := newClient(opts...)
+	tokenizer, model := newTokenizer(opts...)
 	return &LLM{
 		CallbacksHandler: handler,
 		client:           c,
+		tokenizer:        tokenizer,
+		model:            model,
 	}, err
 }
 
@@ -107,9 +116,15 @@ func (o *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptVa
 	return llms.GeneratePrompt(ctx, o, promptValues, options...)
 }
 
-// 与openai的实现方式不同, 无法计算
+// GetNumTokens counts the tokens of the text with the tokenizer, falling back
+// to llms.EstimateTokens.
 func (o *LLM) GetNumTokens(text string) int {
-	return 0
+	return llms.EstimateTokensWith(o.tokenizer, o.model, text)
+}
+
+// Tokenizer returns the tokenizer set with WithTokenizer.
+func (o *LLM) Tokenizer() llms.Tokenizer {
+	return o.tokenizer
 }
 
 func (o *LLM) ResetUsage() {
@@ -122,22 +137,32 @@ func (o *LLM) GetUsage() []CompletionUsage {
 
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float64, error) {
 	o.ResetUsage()
+	embeddings, usage, err := o.CreateEmbeddingWithUsage(ctx, inputTexts)
+	if err != nil {
+		return embeddings, err
+	}
+	o.usage = append(o.usage, usage)
+	return embeddings, nil
+}
+
+// CreateEmbeddingWithUsage creates embeddings for the given input texts like
+// CreateEmbedding, but returns the usage instead of recording it, so it is
+// safe for concurrent use.
+func (o *LLM) CreateEmbeddingWithUsage(ctx context.Context, inputTexts []string) ([][]float64, CompletionUsage, error) {
 	embeddings, use, err := o.client.CreateEmbedding(ctx, &qwenclient.EmbeddingPayload{
 		Input: qwenclient.EmbText{
 			Texts: inputTexts,
 		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, CompletionUsage{}, err
 	}
 	if len(embeddings) == 0 {
-		return nil, ErrEmptyResponse
+		return nil, CompletionUsage{}, ErrEmptyResponse
 	}
+	usage := CompletionUsage{TotalTokens: use}
 	if len(inputTexts) != len(embeddings) {
-		return embeddings, ErrUnexpectedResponseLength
+		return embeddings, usage, ErrUnexpectedResponseLength
 	}
-	o.usage = append(o.usage, CompletionUsage{
-		TotalTokens: use,
-	})
-	return embeddings, nil
+	return embeddings, usage, nil
 }
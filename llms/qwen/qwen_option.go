@@ -1,6 +1,9 @@
 package qwen
 
-import "github.com/tmc/langchaingo/llms/qwen/internal/qwenclient"
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/qwen/internal/qwenclient"
+)
 
 const (
 	apiKeyEnvName = "DASHSCOPE_API_KEY"
@@ -13,6 +16,7 @@ type options struct {
 	httpClient     qwenclient.Doer
 	embeddingModel string
 	EnableSearch   bool
+	tokenizer      llms.Tokenizer
 }
 
 type Option func(*options)
@@ -53,3 +57,12 @@ func WithEnableSearch(enableSearch bool) Option {
 		o.EnableSearch = enableSearch
 	}
 }
+
+// WithTokenizer sets the tokenizer used by GetNumTokens, like the tokenizer of
+// the qwen.tiktoken vocabulary created with tokenizer.NewQwen. If not set,
+// tokens are estimated offline with llms.EstimateTokens.
+func WithTokenizer(tokenizer llms.Tokenizer) Option {
+	return func(o *options) {
+		o.tokenizer = tokenizer
+	}
+}
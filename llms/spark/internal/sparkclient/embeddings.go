@@ -38,9 +38,6 @@ type EmbeddingResponsePayload struct {
 }
 
 func (c *Client) getParamEmbedding(p *EmbeddingPayloadUser) *EmbeddingPayload {
-	c.model = defaultEmbeddingModel
-	c.baseUrl = defaultBaseUrl3
-
 	resp := &EmbeddingPayload{}
 	resp.Header.AppId = c.appId
 	resp.Payload.Text = p.Prompt
@@ -53,8 +50,7 @@ func (c *Client) createEmbedding(ctx context.Context, payloadUser *EmbeddingPayl
 	if err != nil {
 		return nil, fmt.Errorf("marshal payload: %w", err)
 	}
-	apiUrl := assembleAuthUrl(c.baseUrl, c.apiKey, c.appSecret)
-	fmt.Println(apiUrl)
+	apiUrl := assembleAuthUrl(defaultBaseUrl3, c.apiKey, c.appSecret)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiUrl, bytes.NewReader(payloadBytes))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
@@ -85,6 +81,5 @@ func (c *Client) createEmbedding(ctx context.Context, payloadUser *EmbeddingPayl
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	fmt.Printf("%#v\n", response)
 	return &response, nil
 }
@@ -107,29 +107,38 @@ func (o *LLM) GetUsage() []Usage {
 }
 
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float64, error) {
-	embeddings := make([][]float64, 0, 1)
 	o.ResetUsage()
+	embeddings, usage, err := o.CreateEmbeddingWithUsage(ctx, inputTexts)
+	o.usage = append(o.usage, usage...)
+	return embeddings, err
+}
+
+// CreateEmbeddingWithUsage creates embeddings for the given input texts like
+// CreateEmbedding, but returns the usage instead of recording it, so it is
+// safe for concurrent use.
+func (o *LLM) CreateEmbeddingWithUsage(ctx context.Context, inputTexts []string) ([][]float64, []Usage, error) {
+	embeddings := make([][]float64, 0, 1)
+	usage := make([]Usage, 0, len(inputTexts))
 	for _, input := range inputTexts {
 		embedding, err := o.client.CreateEmbedding(ctx, &sparkclient.EmbeddingPayloadUser{
 			Prompt: input,
 		})
 		if err != nil {
-			return nil, err
+			return nil, usage, err
 		}
 		if len(embedding) == 0 {
-			return nil, ErrEmptyResponse
+			return nil, usage, ErrEmptyResponse
 		}
 		embeddings = append(embeddings, embedding)
 		// 用于记录本次token使用情况 , 讯飞embedding没有返回usage
-		o.usage = append(o.usage, Usage{
+		usage = append(usage, Usage{
 			PromptTokens:     0,
 			CompletionTokens: 0,
 			TotalTokens:      0,
 		})
-
 	}
 	if len(inputTexts) != len(embeddings) {
-		return embeddings, ErrUnexpectedResponseLength
+		return embeddings, usage, ErrUnexpectedResponseLength
 	}
-	return embeddings, nil
+	return embeddings, usage, nil
 }
@@ -0,0 +1,118 @@
+package llms
+
+import (
+	"log"
+	"sync"
+	"unicode"
+)
+
+// Tokenizer counts the tokens of texts the way a model does.
+type Tokenizer interface {
+	// CountTokens returns the number of tokens of the text.
+	CountTokens(text string) (int, error)
+}
+
+// TokenEncoder is a Tokenizer that also converts texts to token ids and back.
+type TokenEncoder interface {
+	Tokenizer
+	// Encode returns the token ids of the text.
+	Encode(text string) ([]int, error)
+	// Decode returns the text of the token ids.
+	Decode(tokens []int) (string, error)
+}
+
+// TokenizerProvider is implemented by language models that expose the
+// tokenizer used by GetNumTokens.
+type TokenizerProvider interface {
+	// Tokenizer returns the tokenizer of the model, or nil if it has none.
+	Tokenizer() Tokenizer
+}
+
+var (
+	tokenizersMu sync.RWMutex             //nolint:gochecknoglobals
+	tokenizers   = map[string]Tokenizer{} //nolint:gochecknoglobals
+)
+
+// RegisterTokenizer registers the tokenizer of a model, so CountTokens and
+// CalculateMaxTokens use it instead of tiktoken. Registering a nil tokenizer
+// removes the registration.
+func RegisterTokenizer(model string, tokenizer Tokenizer) {
+	tokenizersMu.Lock()
+	defer tokenizersMu.Unlock()
+
+	if tokenizer == nil {
+		delete(tokenizers, model)
+		return
+	}
+	tokenizers[model] = tokenizer
+}
+
+// registeredTokenizer returns the tokenizer registered for the model.
+func registeredTokenizer(model string) (Tokenizer, bool) {
+	tokenizersMu.RLock()
+	defer tokenizersMu.RUnlock()
+
+	tokenizer, ok := tokenizers[model]
+	return tokenizer, ok
+}
+
+// CountTokensWith gets the number of tokens the text contains with the
+// tokenizer. If the tokenizer is nil or fails, it falls back to CountTokens
+// with the model.
+func CountTokensWith(tokenizer Tokenizer, model, text string) int {
+	if tokenizer != nil {
+		n, err := tokenizer.CountTokens(text)
+		if err == nil {
+			return n
+		}
+		log.Printf("[WARN] Failed to count tokens with the tokenizer of %s: %v", model, err)
+	}
+	return CountTokens(model, text)
+}
+
+// EstimateTokensWith gets the number of tokens the text contains with the
+// tokenizer, or else the tokenizer registered for the model. If neither is set
+// or they fail, the tokens are estimated offline with EstimateTokens. Unlike
+// CountTokensWith it never falls back to tiktoken, which downloads its
+// encodings and miscounts Chinese text for models with Chinese vocabularies.
+func EstimateTokensWith(tokenizer Tokenizer, model, text string) int {
+	if tokenizer == nil {
+		tokenizer, _ = registeredTokenizer(model)
+	}
+	if tokenizer != nil {
+		n, err := tokenizer.CountTokens(text)
+		if err == nil {
+			return n
+		}
+		log.Printf("[WARN] Failed to count tokens with the tokenizer of %s: %v", model, err)
+	}
+	return EstimateTokens(text)
+}
+
+// EstimateTokens estimates the number of tokens of the text without a
+// vocabulary. Every Chinese, Japanese and Korean character and every
+// punctuation mark or symbol counts as a token, and other words as a token per
+// four characters.
+func EstimateTokens(text string) int {
+	tokens, word := 0, 0
+	flush := func() {
+		tokens += (word + _tokenApproximation - 1) / _tokenApproximation
+		word = 0
+	}
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens++
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,202 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var (
+	// ErrInvalidVocabulary is returned when a vocabulary file can't be parsed.
+	ErrInvalidVocabulary = errors.New("invalid vocabulary")
+	// ErrUnknownToken is returned when decoding a token id that is not in the vocabulary.
+	ErrUnknownToken = errors.New("unknown token")
+)
+
+// QwenPattern is the pattern Qwen models use to split texts into pieces
+// before byte pair encoding.
+const QwenPattern = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+` //nolint:lll
+
+const _qwenNumExtraTokens = 205
+
+// BPE is a byte pair encoding tokenizer with a tiktoken-style vocabulary.
+// Texts are split into pieces with a regular expression, and the bytes of
+// every piece are merged by the ranks of the vocabulary. Special tokens in
+// texts are encoded as ordinary text. A BPE is safe for concurrent use.
+type BPE struct {
+	ranks   map[string]int
+	decoder map[int]string
+	pattern *regexp2.Regexp
+}
+
+var _ llms.TokenEncoder = &BPE{}
+
+// NewBPE creates a tokenizer with the ranks of byte sequences, the special
+// tokens and the pattern for splitting texts into pieces. The pattern uses the
+// syntax of .NET regular expressions, which supports lookarounds.
+func NewBPE(ranks map[string]int, specialTokens map[string]int, pattern string) (*BPE, error) {
+	re, err := regexp2.Compile(pattern, regexp2.None)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := make(map[int]string, len(ranks)+len(specialTokens))
+	for token, rank := range ranks {
+		decoder[rank] = token
+	}
+	for token, id := range specialTokens {
+		decoder[id] = token
+	}
+
+	return &BPE{
+		ranks:   ranks,
+		decoder: decoder,
+		pattern: re,
+	}, nil
+}
+
+// LoadTiktokenRanks reads a tiktoken-style vocabulary, where every line holds
+// a base64 encoded byte sequence and its rank separated by a space.
+func LoadTiktokenRanks(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 { //nolint:gomnd
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidVocabulary, line)
+		}
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidVocabulary, line, err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidVocabulary, line, err)
+		}
+		ranks[string(token)] = rank
+	}
+
+	return ranks, scanner.Err()
+}
+
+// NewQwen creates the tokenizer of Qwen models from the qwen.tiktoken
+// vocabulary file distributed with the models.
+func NewQwen(r io.Reader) (*BPE, error) {
+	ranks, err := LoadTiktokenRanks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	special := []string{"<|endoftext|>", "<|im_start|>", "<|im_end|>"}
+	for i := 0; i < _qwenNumExtraTokens; i++ {
+		special = append(special, fmt.Sprintf("<|extra_%d|>", i))
+	}
+	specialTokens := make(map[string]int, len(special))
+	for i, token := range special {
+		specialTokens[token] = len(ranks) + i
+	}
+
+	return NewBPE(ranks, specialTokens, QwenPattern)
+}
+
+// NewQwenFromFile creates the tokenizer of Qwen models from the path of the
+// qwen.tiktoken vocabulary file.
+func NewQwenFromFile(path string) (*BPE, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewQwen(f)
+}
+
+// CountTokens returns the number of tokens of the text.
+func (b *BPE) CountTokens(text string) (int, error) {
+	tokens, err := b.Encode(text)
+	return len(tokens), err
+}
+
+// Encode returns the token ids of the text.
+func (b *BPE) Encode(text string) ([]int, error) {
+	tokens := make([]int, 0)
+	m, err := b.pattern.FindStringMatch(text)
+	for ; m != nil && err == nil; m, err = b.pattern.FindNextMatch(m) {
+		piece := m.String()
+		if rank, ok := b.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			continue
+		}
+
+		ids, encodeErr := b.encodePiece([]byte(piece))
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		tokens = append(tokens, ids...)
+	}
+
+	return tokens, err
+}
+
+// Decode returns the text of the token ids.
+func (b *BPE) Decode(tokens []int) (string, error) {
+	var sb strings.Builder
+	for _, token := range tokens {
+		s, ok := b.decoder[token]
+		if !ok {
+			return "", fmt.Errorf("%w: %d", ErrUnknownToken, token)
+		}
+		sb.WriteString(s)
+	}
+	return sb.String(), nil
+}
+
+// encodePiece merges the bytes of a piece, always merging the adjacent parts
+// whose concatenation has the lowest rank first.
+func (b *BPE) encodePiece(piece []byte) ([]int, error) {
+	parts := make([][]byte, 0, len(piece))
+	for i := range piece {
+		parts = append(parts, piece[i:i+1])
+	}
+
+	for len(parts) > 1 {
+		best, bestRank := -1, math.MaxInt
+		for i := 0; i < len(parts)-1; i++ {
+			rank, ok := b.ranks[string(parts[i])+string(parts[i+1])]
+			if ok && rank < bestRank {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		merged := append(append([]byte{}, parts[best]...), parts[best+1]...)
+		parts = append(parts[:best], append([][]byte{merged}, parts[best+2:]...)...)
+	}
+
+	ids := make([]int, 0, len(parts))
+	for _, part := range parts {
+		rank, ok := b.ranks[string(part)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not in the vocabulary", ErrInvalidVocabulary, part)
+		}
+		ids = append(ids, rank)
+	}
+	return ids, nil
+}
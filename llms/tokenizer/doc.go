@@ -0,0 +1,14 @@
+/*
+Package tokenizer contains implementations of llms.Tokenizer for counting the
+tokens of texts the way a model does.
+
+The package provides the tokenizers:
+
+- Tiktoken: the tiktoken encodings of OpenAI models.
+- BPE: byte pair encoding with tiktoken-style vocabulary files, like the
+qwen.tiktoken file of Qwen models. See NewQwen.
+- SentencePiece: BPE and unigram SentencePiece models, like the
+tokenizer.model file of ChatGLM models.
+- Remote: an adapter for count tokens APIs.
+*/
+package tokenizer
@@ -0,0 +1,61 @@
+package tokenizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+const _defaultRemoteTimeout = 30 * time.Second
+
+// CountFunc counts the tokens of a text with a remote service.
+type CountFunc func(ctx context.Context, text string) (int, error)
+
+// Remote is a tokenizer counting tokens with a remote service, like the
+// count tokens API of a model provider.
+type Remote struct {
+	count   CountFunc
+	timeout time.Duration
+}
+
+var _ llms.Tokenizer = &Remote{}
+
+// RemoteOption is a function that configures a Remote.
+type RemoteOption func(*Remote)
+
+// WithTimeout sets the timeout of every call to the remote service. Defaults
+// to 30 seconds.
+func WithTimeout(timeout time.Duration) RemoteOption {
+	return func(r *Remote) {
+		r.timeout = timeout
+	}
+}
+
+// NewRemote creates a tokenizer counting tokens with the function.
+func NewRemote(count CountFunc, opts ...RemoteOption) *Remote {
+	r := &Remote{
+		count:   count,
+		timeout: _defaultRemoteTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// CountTokens returns the number of tokens of the text.
+func (r *Remote) CountTokens(text string) (int, error) {
+	return r.CountTokensContext(context.Background(), text)
+}
+
+// CountTokensContext returns the number of tokens of the text, using the
+// context for the call to the remote service.
+func (r *Remote) CountTokensContext(ctx context.Context, text string) (int, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.count(ctx, text)
+}
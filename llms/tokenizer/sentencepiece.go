@@ -0,0 +1,382 @@
+package tokenizer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/llms"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const _spaceSymbol = "▁"
+
+// SentencePiece model types.
+const (
+	spModelUnigram = 1
+	spModelBPE     = 2
+)
+
+// SentencePiece piece types.
+const (
+	spPieceNormal      = 1
+	spPieceUnknown     = 2
+	spPieceControl     = 3
+	spPieceUserDefined = 4
+	spPieceUnused      = 5
+	spPieceByte        = 6
+)
+
+type sentencePiece struct {
+	piece string
+	score float64
+	typ   int
+}
+
+// SentencePiece is a tokenizer using a SentencePiece model, like the
+// tokenizer.model file of ChatGLM and LLaMA models. BPE and unigram models are
+// supported. Normalization is limited to the handling of whitespaces. A
+// SentencePiece is safe for concurrent use.
+type SentencePiece struct {
+	pieces         []sentencePiece
+	ids            map[string]int
+	modelType      int
+	byteFallback   bool
+	addDummyPrefix bool
+	removeSpaces   bool
+	unkID          int
+	minScore       float64
+	maxPieceLength int
+}
+
+var _ llms.TokenEncoder = &SentencePiece{}
+
+// NewSentencePiece creates a tokenizer from a serialized SentencePiece model.
+func NewSentencePiece(r io.Reader) (*SentencePiece, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &SentencePiece{
+		ids:            make(map[string]int),
+		modelType:      spModelUnigram,
+		addDummyPrefix: true,
+		removeSpaces:   true,
+		unkID:          -1,
+		minScore:       math.MaxFloat64,
+	}
+	if err := sp.parseModel(data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVocabulary, err)
+	}
+	if len(sp.pieces) == 0 {
+		return nil, fmt.Errorf("%w: no pieces", ErrInvalidVocabulary)
+	}
+	if sp.modelType != spModelUnigram && sp.modelType != spModelBPE {
+		return nil, fmt.Errorf("%w: unsupported model type %d", ErrInvalidVocabulary, sp.modelType)
+	}
+
+	for id, p := range sp.pieces {
+		switch p.typ {
+		case spPieceUnknown:
+			sp.unkID = id
+		case spPieceNormal, spPieceUserDefined:
+			sp.minScore = math.Min(sp.minScore, p.score)
+			sp.maxPieceLength = maxInt(sp.maxPieceLength, utf8.RuneCountInString(p.piece))
+		}
+		if _, ok := sp.ids[p.piece]; !ok {
+			sp.ids[p.piece] = id
+		}
+	}
+
+	return sp, nil
+}
+
+// NewSentencePieceFromFile creates a tokenizer from the path of a serialized
+// SentencePiece model.
+func NewSentencePieceFromFile(path string) (*SentencePiece, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewSentencePiece(f)
+}
+
+// CountTokens returns the number of tokens of the text.
+func (sp *SentencePiece) CountTokens(text string) (int, error) {
+	tokens, err := sp.Encode(text)
+	return len(tokens), err
+}
+
+// Encode returns the token ids of the text.
+func (sp *SentencePiece) Encode(text string) ([]int, error) {
+	text = sp.normalize(text)
+	if text == "" {
+		return []int{}, nil
+	}
+
+	var symbols []string
+	if sp.modelType == spModelBPE {
+		symbols = sp.encodeBPE(text)
+	} else {
+		symbols = sp.encodeUnigram(text)
+	}
+
+	tokens := make([]int, 0, len(symbols))
+	for _, symbol := range symbols {
+		if id, ok := sp.ids[symbol]; ok && sp.isEncodable(id) {
+			tokens = append(tokens, id)
+			continue
+		}
+		fallback, err := sp.fallback(symbol)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, fallback...)
+	}
+	return tokens, nil
+}
+
+// Decode returns the text of the token ids.
+func (sp *SentencePiece) Decode(tokens []int) (string, error) {
+	var (
+		sb    strings.Builder
+		bytes []byte
+	)
+	for _, token := range tokens {
+		if token < 0 || token >= len(sp.pieces) {
+			return "", fmt.Errorf("%w: %d", ErrUnknownToken, token)
+		}
+		p := sp.pieces[token]
+		if p.typ == spPieceByte {
+			if b, ok := parseBytePiece(p.piece); ok {
+				bytes = append(bytes, b)
+				continue
+			}
+		}
+		sb.Write(bytes)
+		bytes = bytes[:0]
+		if p.typ == spPieceControl || p.typ == spPieceUnused {
+			continue
+		}
+		sb.WriteString(p.piece)
+	}
+	sb.Write(bytes)
+
+	text := strings.ReplaceAll(sb.String(), _spaceSymbol, " ")
+	if sp.addDummyPrefix {
+		text = strings.TrimPrefix(text, " ")
+	}
+	return text, nil
+}
+
+func (sp *SentencePiece) normalize(text string) string {
+	if sp.removeSpaces {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	if text == "" {
+		return ""
+	}
+	if sp.addDummyPrefix {
+		text = " " + text
+	}
+	return strings.ReplaceAll(text, " ", _spaceSymbol)
+}
+
+func (sp *SentencePiece) isEncodable(id int) bool {
+	typ := sp.pieces[id].typ
+	return typ == spPieceNormal || typ == spPieceUserDefined
+}
+
+// encodeBPE splits the text into characters and repeatedly merges the
+// adjacent symbols whose concatenation is the piece with the highest score.
+func (sp *SentencePiece) encodeBPE(text string) []string {
+	symbols := make([]string, 0, len(text))
+	for _, r := range text {
+		symbols = append(symbols, string(r))
+	}
+
+	for len(symbols) > 1 {
+		best, bestScore := -1, math.Inf(-1)
+		for i := 0; i < len(symbols)-1; i++ {
+			id, ok := sp.ids[symbols[i]+symbols[i+1]]
+			if !ok || !sp.isEncodable(id) {
+				continue
+			}
+			if score := sp.pieces[id].score; score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		symbols[best] += symbols[best+1]
+		symbols = append(symbols[:best+1], symbols[best+2:]...)
+	}
+	return symbols
+}
+
+// encodeUnigram finds the segmentation of the text with the highest total
+// score. Characters not in the vocabulary are scored below every piece.
+func (sp *SentencePiece) encodeUnigram(text string) []string {
+	runes := []rune(text)
+	best := make([]float64, len(runes)+1)
+	prev := make([]int, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = math.Inf(-1)
+	}
+
+	unknownScore := sp.minScore - 10 //nolint:gomnd
+	for end := 1; end <= len(runes); end++ {
+		for start := maxInt(0, end-sp.maxPieceLength); start < end; start++ {
+			if math.IsInf(best[start], -1) {
+				continue
+			}
+			id, ok := sp.ids[string(runes[start:end])]
+			if !ok || !sp.isEncodable(id) {
+				continue
+			}
+			if score := best[start] + sp.pieces[id].score; score > best[end] {
+				best[end], prev[end] = score, start
+			}
+		}
+		if score := best[end-1] + unknownScore; math.IsInf(best[end], -1) || score > best[end] {
+			if _, ok := sp.ids[string(runes[end-1])]; !ok {
+				best[end], prev[end] = score, end-1
+			}
+		}
+	}
+
+	symbols := make([]string, 0)
+	for end := len(runes); end > 0; end = prev[end] {
+		symbols = append(symbols, string(runes[prev[end]:end]))
+	}
+	for i, j := 0, len(symbols)-1; i < j; i, j = i+1, j-1 {
+		symbols[i], symbols[j] = symbols[j], symbols[i]
+	}
+	return symbols
+}
+
+// fallback encodes a symbol not in the vocabulary as byte pieces when the
+// model supports them, and as the unknown piece otherwise.
+func (sp *SentencePiece) fallback(symbol string) ([]int, error) {
+	if sp.byteFallback {
+		tokens := make([]int, 0, len(symbol))
+		for i := 0; i < len(symbol); i++ {
+			id, ok := sp.ids[fmt.Sprintf("<0x%02X>", symbol[i])]
+			if !ok {
+				return nil, fmt.Errorf("%w: missing byte piece for %q", ErrInvalidVocabulary, symbol)
+			}
+			tokens = append(tokens, id)
+		}
+		return tokens, nil
+	}
+	if sp.unkID < 0 {
+		return nil, fmt.Errorf("%w: no unknown piece for %q", ErrInvalidVocabulary, symbol)
+	}
+	return []int{sp.unkID}, nil
+}
+
+func parseBytePiece(piece string) (byte, bool) {
+	if len(piece) != 6 || !strings.HasPrefix(piece, "<0x") || !strings.HasSuffix(piece, ">") { //nolint:gomnd
+		return 0, false
+	}
+	b, err := strconv.ParseUint(piece[3:5], 16, 8)
+	return byte(b), err == nil
+}
+
+// parseModel reads the fields of the ModelProto message used for encoding.
+func (sp *SentencePiece) parseModel(data []byte) error {
+	return parseMessage(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch num {
+		case 1: // pieces
+			return sp.parsePiece(value)
+		case 2: // trainer_spec
+			return parseMessage(value, func(num protowire.Number, _ protowire.Type, v []byte) error {
+				switch num {
+				case 3: // model_type
+					sp.modelType = int(decodeVarint(v))
+				case 35: // byte_fallback
+					sp.byteFallback = decodeVarint(v) != 0
+				}
+				return nil
+			})
+		case 3: // normalizer_spec
+			return parseMessage(value, func(num protowire.Number, _ protowire.Type, v []byte) error {
+				switch num {
+				case 3: // add_dummy_prefix
+					sp.addDummyPrefix = decodeVarint(v) != 0
+				case 4: // remove_extra_whitespaces
+					sp.removeSpaces = decodeVarint(v) != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+func (sp *SentencePiece) parsePiece(data []byte) error {
+	p := sentencePiece{typ: spPieceNormal}
+	err := parseMessage(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1: // piece
+			p.piece = string(v)
+		case 2: // score
+			if typ == protowire.Fixed32Type {
+				bits, _ := protowire.ConsumeFixed32(v)
+				p.score = float64(math.Float32frombits(bits))
+			}
+		case 3: // type
+			p.typ = int(decodeVarint(v))
+		}
+		return nil
+	})
+	sp.pieces = append(sp.pieces, p)
+	return err
+}
+
+// parseMessage calls fn with every field of a protobuf message. The value of
+// length-delimited fields is their content, and the raw encoding otherwise.
+func parseMessage(data []byte, fn func(protowire.Number, protowire.Type, []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		m := protowire.ConsumeFieldValue(num, typ, data)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		value := data[:m]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+		data = data[m:]
+	}
+	return nil
+}
+
+func decodeVarint(data []byte) uint64 {
+	v, _ := protowire.ConsumeVarint(data)
+	return v
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,48 @@
+package tokenizer
+
+import (
+	"github.com/pkoukk/tiktoken-go"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Tiktoken is a tokenizer using a tiktoken encoding. Special tokens in texts
+// are encoded as ordinary text.
+type Tiktoken struct {
+	tk *tiktoken.Tiktoken
+}
+
+var _ llms.TokenEncoder = &Tiktoken{}
+
+// NewTiktoken creates a tokenizer with a tiktoken encoding, like "cl100k_base".
+func NewTiktoken(encoding string) (*Tiktoken, error) {
+	tk, err := tiktoken.GetEncoding(encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &Tiktoken{tk: tk}, nil
+}
+
+// NewTiktokenForModel creates a tokenizer with the tiktoken encoding of an
+// OpenAI model, like "gpt-3.5-turbo".
+func NewTiktokenForModel(model string) (*Tiktoken, error) {
+	tk, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		return nil, err
+	}
+	return &Tiktoken{tk: tk}, nil
+}
+
+// CountTokens returns the number of tokens of the text.
+func (t *Tiktoken) CountTokens(text string) (int, error) {
+	return len(t.tk.Encode(text, nil, nil)), nil
+}
+
+// Encode returns the token ids of the text.
+func (t *Tiktoken) Encode(text string) ([]int, error) {
+	return t.tk.Encode(text, nil, nil), nil
+}
+
+// Decode returns the text of the token ids.
+func (t *Tiktoken) Decode(tokens []int) (string, error) {
+	return t.tk.Decode(tokens), nil
+}
@@ -0,0 +1,229 @@
+package tokenizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTiktoken(t *testing.T) {
+	t.Parallel()
+
+	tk, err := NewTiktoken("cl100k_base")
+	if err != nil {
+		// The encoding is downloaded on first use.
+		t.Skipf("cl100k_base encoding not available: %v", err)
+	}
+
+	tokens, err := tk.Encode("hello world")
+	require.NoError(t, err)
+	n, err := tk.CountTokens("hello world")
+	require.NoError(t, err)
+	assert.Equal(t, len(tokens), n)
+
+	text, err := tk.Decode(tokens)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", text)
+
+	_, err = NewTiktoken("unknown")
+	require.Error(t, err)
+}
+
+// tiktokenVocab returns a tiktoken-style vocabulary with all single bytes and
+// the merges, ranked in order.
+func tiktokenVocab(merges ...string) string {
+	var sb strings.Builder
+	rank := 0
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), rank)
+		rank++
+	}
+	for _, m := range merges {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(m)), rank)
+		rank++
+	}
+	return sb.String()
+}
+
+func TestQwen(t *testing.T) {
+	t.Parallel()
+
+	bpe, err := NewQwen(strings.NewReader(tiktokenVocab("he", "ll", "hell", "hello", " w", " wo", " wor", "ld")))
+	require.NoError(t, err)
+
+	tokens, err := bpe.Encode("hello world")
+	require.NoError(t, err)
+	assert.Equal(t, []int{259, 262, 263}, tokens)
+
+	tokens, err = bpe.Encode("helo, 你好")
+	require.NoError(t, err)
+	text, err := bpe.Decode(tokens)
+	require.NoError(t, err)
+	assert.Equal(t, "helo, 你好", text)
+
+	n, err := bpe.CountTokens("你好")
+	require.NoError(t, err)
+	assert.Equal(t, 6, n) // the bytes of the two characters.
+
+	text, err = bpe.Decode([]int{264, 265})
+	require.NoError(t, err)
+	assert.Equal(t, "<|endoftext|><|im_start|>", text)
+
+	_, err = bpe.Decode([]int{100000})
+	require.ErrorIs(t, err, ErrUnknownToken)
+}
+
+func TestLoadTiktokenRanksInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadTiktokenRanks(strings.NewReader("aGk= 0\nnot-base64 1\n"))
+	require.ErrorIs(t, err, ErrInvalidVocabulary)
+
+	_, err = LoadTiktokenRanks(strings.NewReader("aGk=\n"))
+	require.ErrorIs(t, err, ErrInvalidVocabulary)
+}
+
+type testPiece struct {
+	piece string
+	score float32
+	typ   int
+}
+
+// sentencePieceModel serializes a SentencePiece ModelProto.
+func sentencePieceModel(modelType int, byteFallback bool, pieces []testPiece) []byte {
+	var b []byte
+	for _, p := range pieces {
+		var pb []byte
+		pb = protowire.AppendTag(pb, 1, protowire.BytesType)
+		pb = protowire.AppendString(pb, p.piece)
+		pb = protowire.AppendTag(pb, 2, protowire.Fixed32Type)
+		pb = protowire.AppendFixed32(pb, math.Float32bits(p.score))
+		pb = protowire.AppendTag(pb, 3, protowire.VarintType)
+		pb = protowire.AppendVarint(pb, uint64(p.typ))
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, pb)
+	}
+
+	var trainer []byte
+	trainer = protowire.AppendTag(trainer, 3, protowire.VarintType)
+	trainer = protowire.AppendVarint(trainer, uint64(modelType))
+	trainer = protowire.AppendTag(trainer, 35, protowire.VarintType)
+	trainer = protowire.AppendVarint(trainer, protowire.EncodeBool(byteFallback))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, trainer)
+	return b
+}
+
+func basePieces(byteFallback bool) []testPiece {
+	pieces := []testPiece{
+		{"<unk>", 0, spPieceUnknown},
+		{"<s>", 0, spPieceControl},
+		{"</s>", 0, spPieceControl},
+	}
+	if byteFallback {
+		for i := 0; i < 256; i++ {
+			pieces = append(pieces, testPiece{fmt.Sprintf("<0x%02X>", i), 0, spPieceByte})
+		}
+	}
+	return pieces
+}
+
+func TestSentencePieceBPE(t *testing.T) {
+	t.Parallel()
+
+	pieces := append(basePieces(true),
+		testPiece{"▁", -1, spPieceNormal},
+		testPiece{"h", -2, spPieceNormal},
+		testPiece{"i", -2, spPieceNormal},
+		testPiece{"▁h", -3, spPieceNormal},
+		testPiece{"hi", -1, spPieceNormal},
+		testPiece{"▁hi", -4, spPieceNormal},
+	)
+	sp, err := NewSentencePiece(bytes.NewReader(sentencePieceModel(spModelBPE, true, pieces)))
+	require.NoError(t, err)
+
+	tokens, err := sp.Encode("hi  hi")
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	text, err := sp.Decode(tokens)
+	require.NoError(t, err)
+	assert.Equal(t, "hi hi", text)
+
+	// Characters not in the vocabulary fall back to bytes.
+	tokens, err = sp.Encode("hi 好")
+	require.NoError(t, err)
+	assert.Len(t, tokens, 5)
+	text, err = sp.Decode(append([]int{1}, tokens...))
+	require.NoError(t, err)
+	assert.Equal(t, "hi 好", text)
+}
+
+func TestSentencePieceUnigram(t *testing.T) {
+	t.Parallel()
+
+	pieces := append(basePieces(false),
+		testPiece{"▁", -2, spPieceNormal},
+		testPiece{"▁你好", -3, spPieceNormal},
+		testPiece{"你", -4, spPieceNormal},
+		testPiece{"好", -4, spPieceNormal},
+		testPiece{"世界", -3, spPieceNormal},
+		testPiece{"世", -5, spPieceNormal},
+		testPiece{"界", -5, spPieceNormal},
+	)
+	sp, err := NewSentencePiece(bytes.NewReader(sentencePieceModel(spModelUnigram, false, pieces)))
+	require.NoError(t, err)
+
+	tokens, err := sp.Encode("你好世界")
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 7}, tokens)
+
+	tokens, err = sp.Encode("你好，世界")
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 0, 7}, tokens)
+
+	n, err := sp.CountTokens("")
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestSentencePieceInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSentencePiece(bytes.NewReader([]byte{0xff}))
+	require.ErrorIs(t, err, ErrInvalidVocabulary)
+
+	_, err = NewSentencePiece(bytes.NewReader(sentencePieceModel(3, false, basePieces(false))))
+	require.ErrorIs(t, err, ErrInvalidVocabulary)
+}
+
+func TestRemote(t *testing.T) {
+	t.Parallel()
+
+	errCount := errors.New("count error")
+	r := NewRemote(func(ctx context.Context, text string) (int, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			return 0, errCount
+		}
+		return len([]rune(text)), nil
+	}, WithTimeout(time.Second))
+
+	n, err := r.CountTokens("你好")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	r = NewRemote(func(ctx context.Context, text string) (int, error) {
+		return 0, errCount
+	})
+	_, err = r.CountTokens("text")
+	require.ErrorIs(t, err, errCount)
+}
@@ -0,0 +1,58 @@
+package llms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type runeTokenizer struct{}
+
+func (runeTokenizer) CountTokens(text string) (int, error) {
+	return len([]rune(text)), nil
+}
+
+func TestRegisterTokenizer(t *testing.T) {
+	t.Parallel()
+
+	RegisterTokenizer("test-rune-model", runeTokenizer{})
+	assert.Equal(t, 4, CountTokens("test-rune-model", "你好世界"))
+
+	RegisterTokenizer("test-rune-model", nil)
+	_, ok := registeredTokenizer("test-rune-model")
+	assert.False(t, ok)
+}
+
+type failingTokenizer struct{}
+
+func (failingTokenizer) CountTokens(string) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func TestCountTokensWith(t *testing.T) {
+	t.Parallel()
+
+	RegisterTokenizer("test-fallback-model", runeTokenizer{})
+	defer RegisterTokenizer("test-fallback-model", nil)
+
+	assert.Equal(t, 2, CountTokensWith(runeTokenizer{}, "unused", "你好"))
+	assert.Equal(t, 5, CountTokensWith(failingTokenizer{}, "test-fallback-model", "hello"))
+	assert.Equal(t, 5, CountTokensWith(nil, "test-fallback-model", "hello"))
+}
+
+func TestEstimateTokensWith(t *testing.T) {
+	t.Parallel()
+
+	RegisterTokenizer("test-estimate-model", runeTokenizer{})
+	defer RegisterTokenizer("test-estimate-model", nil)
+
+	assert.Equal(t, 2, EstimateTokensWith(runeTokenizer{}, "unused", "你好"))
+	assert.Equal(t, 5, EstimateTokensWith(nil, "test-estimate-model", "hello"))
+	assert.Equal(t, 2, EstimateTokensWith(failingTokenizer{}, "unused", "hello"))
+
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 17, EstimateTokens("年假需要提前三天申请，由经理审批。"))
+	assert.Equal(t, 4, EstimateTokens("hello world"))
+	assert.Equal(t, 6, EstimateTokens("用 Go 写的 API。"))
+}
@@ -12,6 +12,9 @@ type ConversationTokenBuffer struct {
 	ConversationBuffer
 	LLM           llms.LanguageModel
 	MaxTokenLimit int
+	// Tokenizer counts the tokens of the buffer. If nil, the tokenizer of the
+	// LLM is used when it provides one, and GetNumTokens otherwise.
+	Tokenizer llms.Tokenizer
 }
 
 // Statically assert that ConversationTokenBuffer implement the memory interface.
@@ -105,5 +108,13 @@ func (tb *ConversationTokenBuffer) getNumTokensFromMessages(ctx context.Context)
 		return 0, err
 	}
 
+	tokenizer := tb.Tokenizer
+	if provider, ok := tb.LLM.(llms.TokenizerProvider); ok && tokenizer == nil {
+		tokenizer = provider.Tokenizer()
+	}
+	if tokenizer != nil {
+		return tokenizer.CountTokens(bufferString)
+	}
+
 	return tb.LLM.GetNumTokens(bufferString), nil
 }
@@ -94,3 +94,25 @@ func TestTokenBufferMemoryWithPreLoadedHistory(t *testing.T) {
 	expected := map[string]any{"history": "Human: bar\nAI: foo"}
 	assert.Equal(t, expected, result)
 }
+
+type runeTokenizer struct{}
+
+func (runeTokenizer) CountTokens(text string) (int, error) {
+	return len([]rune(text)), nil
+}
+
+func TestTokenBufferMemoryWithTokenizer(t *testing.T) {
+	t.Parallel()
+
+	m := NewConversationTokenBuffer(nil, 25)
+	m.Tokenizer = runeTokenizer{}
+
+	err := m.SaveContext(context.Background(), map[string]any{"foo": "你好"}, map[string]any{"bar": "你好呀"})
+	require.NoError(t, err)
+	err = m.SaveContext(context.Background(), map[string]any{"foo": "再见"}, map[string]any{"bar": "再见"})
+	require.NoError(t, err)
+
+	result, err := m.LoadMemoryVariables(context.Background(), map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"history": "AI: 你好呀\nHuman: 再见\nAI: 再见"}, result)
+}
@@ -0,0 +1,57 @@
+package cohere
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms/cohere"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Reranker is a reranker using the cohere rerank API.
+type Reranker struct {
+	client *cohere.LLM
+	model  string
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New creates a new reranker with options.
+func New(opts ...Option) (*Reranker, error) {
+	r := &Reranker{
+		model: _defaultModel,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.client == nil {
+		client, err := cohere.New()
+		if err != nil {
+			return nil, err
+		}
+		r.client = client
+	}
+
+	return r, nil
+}
+
+// Rerank scores the documents with the rerank model and orders them by
+// descending relevance score.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	if len(docs) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	scores, err := r.client.CreateRerank(ctx, query, texts, r.model)
+	if err != nil {
+		return nil, err
+	}
+
+	return rerankers.SortByScores(docs, scores)
+}
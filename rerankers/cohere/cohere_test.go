@@ -0,0 +1,52 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms/cohere"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestCohereReranker(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/rerank", r.URL.Path)
+		assert.Equal(t, "bearer token", r.Header.Get("authorization"))
+
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "rerank-multilingual-v2.0", payload["model"])
+
+		_, _ = w.Write([]byte(`{"id":"1","results":[
+			{"index":2,"relevance_score":0.98},
+			{"index":0,"relevance_score":0.2},
+			{"index":1,"relevance_score":0.01}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := cohere.New(cohere.WithToken("token"), cohere.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	reranker, err := New(WithClient(*client))
+	require.NoError(t, err)
+
+	docs, err := reranker.Rerank(context.Background(), "What is the capital of the United States?", []schema.Document{
+		{PageContent: "Carson City is the capital city of the American state of Nevada."},
+		{PageContent: "The Commonwealth of the Northern Mariana Islands is a group of islands."},
+		{PageContent: "Washington, D.C. is the capital of the United States."},
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "Washington, D.C. is the capital of the United States.", docs[0].PageContent)
+	assert.InDelta(t, 0.98, docs[0].Metadata[rerankers.RelevanceScoreKey], 1e-9)
+	assert.InDelta(t, 0.01, docs[2].Metadata[rerankers.RelevanceScoreKey], 1e-9)
+}
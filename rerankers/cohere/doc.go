@@ -0,0 +1,2 @@
+// Package cohere contains a reranker using the cohere rerank API.
+package cohere
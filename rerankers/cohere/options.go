@@ -0,0 +1,24 @@
+package cohere
+
+import "github.com/tmc/langchaingo/llms/cohere"
+
+const _defaultModel = "rerank-multilingual-v2.0"
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithClient is an option for providing the cohere LLM client. If not set a
+// client is created with the default options of the cohere package.
+func WithClient(client cohere.LLM) Option {
+	return func(r *Reranker) {
+		r.client = &client
+	}
+}
+
+// WithModel is an option for setting the rerank model. Defaults to
+// rerank-multilingual-v2.0, which supports Chinese.
+func WithModel(model string) Option {
+	return func(r *Reranker) {
+		r.model = model
+	}
+}
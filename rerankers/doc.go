@@ -0,0 +1,10 @@
+/*
+Package rerankers contains the Reranker interface, an interface for ordering
+retrieved documents by their relevance to a query in a second stage after
+retrieval, and an implementation using a chat LLM as a judge.
+
+Rerankers backed by hosted cross-encoder APIs live in the sub packages qwen,
+huggingface and cohere. Use retrievers.NewReranking to apply a reranker to the
+results of any schema.Retriever.
+*/
+package rerankers
@@ -0,0 +1,4 @@
+// Package huggingface contains a reranker using the rerank endpoint of a
+// HuggingFace text-embeddings-inference server, e.g. one serving
+// BAAI/bge-reranker-large on a HuggingFace inference endpoint.
+package huggingface
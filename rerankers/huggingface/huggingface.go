@@ -0,0 +1,140 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrMissingURL is returned when no server url is given.
+	ErrMissingURL = errors.New("missing url of the text-embeddings-inference server")
+	// ErrUnexpectedResponse is returned when a result refers to an unknown document.
+	ErrUnexpectedResponse = errors.New("unexpected response")
+)
+
+// Reranker is a reranker using the rerank endpoint of a text-embeddings-inference
+// server running a cross-encoder model like BAAI/bge-reranker-large.
+type Reranker struct {
+	url        string
+	token      string
+	batchSize  int
+	rawScores  bool
+	httpClient *http.Client
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New creates a new reranker with options. The url option must be set.
+func New(opts ...Option) (*Reranker, error) {
+	r := &Reranker{
+		token:      os.Getenv(_tokenEnvName),
+		batchSize:  _defaultBatchSize,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.url == "" {
+		return nil, ErrMissingURL
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = _defaultBatchSize
+	}
+
+	return r, nil
+}
+
+type rerankPayload struct {
+	Query     string   `json:"query"`
+	Texts     []string `json:"texts"`
+	RawScores bool     `json:"raw_scores"`
+	Truncate  bool     `json:"truncate"`
+}
+
+type rerankResult struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// Rerank scores the documents with the cross-encoder and orders them by
+// descending score.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	scores := make([]float64, len(docs))
+	for start := 0; start < len(docs); start += r.batchSize {
+		end := start + r.batchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+
+		texts := make([]string, 0, end-start)
+		for _, doc := range docs[start:end] {
+			texts = append(texts, doc.PageContent)
+		}
+
+		results, err := r.rerank(ctx, query, texts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			if result.Index < 0 || result.Index >= len(texts) {
+				return nil, fmt.Errorf("%w: document index %d", ErrUnexpectedResponse, result.Index)
+			}
+			scores[start+result.Index] = result.Score
+		}
+	}
+
+	return rerankers.SortByScores(docs, scores)
+}
+
+func (r *Reranker) rerank(ctx context.Context, query string, texts []string) ([]rerankResult, error) {
+	payloadBytes, err := json.Marshal(rerankPayload{
+		Query:     query,
+		Texts:     texts,
+		RawScores: r.rawScores,
+		Truncate:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	url := strings.TrimSuffix(r.url, "/") + "/rerank"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("API returned unexpected status code: %d: %s", // nolint:goerr113
+			res.StatusCode, string(body))
+	}
+
+	var results []rerankResult
+	if err := json.NewDecoder(res.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return results, nil
+}
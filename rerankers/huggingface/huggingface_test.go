@@ -0,0 +1,58 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestHuggingfaceReranker(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/rerank", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var payload rerankPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		// Score texts mentioning the query highest, like a cross-encoder would.
+		results := make([]rerankResult, 0, len(payload.Texts))
+		for i, text := range payload.Texts {
+			score := 0.1
+			if strings.Contains(text, payload.Query) {
+				score = 0.9
+			}
+			results = append(results, rerankResult{Index: i, Score: score})
+		}
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	defer server.Close()
+
+	reranker, err := New(WithURL(server.URL), WithToken("token"), WithBatchSize(2))
+	require.NoError(t, err)
+
+	docs, err := reranker.Rerank(context.Background(), "bge", []schema.Document{
+		{PageContent: "a"},
+		{PageContent: "b"},
+		{PageContent: "bge-reranker is a cross-encoder"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "bge-reranker is a cross-encoder", docs[0].PageContent)
+	assert.InDelta(t, 0.9, docs[0].Metadata[rerankers.RelevanceScoreKey], 1e-9)
+
+	_, err = New()
+	require.ErrorIs(t, err, ErrMissingURL)
+}
@@ -0,0 +1,52 @@
+package huggingface
+
+import "net/http"
+
+const (
+	_tokenEnvName     = "HUGGINGFACEHUB_API_TOKEN"
+	_defaultBatchSize = 32
+)
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithURL is an option for setting the url of the text-embeddings-inference
+// server, e.g. http://localhost:8080. Must be set.
+func WithURL(url string) Option {
+	return func(r *Reranker) {
+		r.url = url
+	}
+}
+
+// WithToken is an option for setting the token sent as bearer token. If the
+// option is not set the token is read from the HUGGINGFACEHUB_API_TOKEN
+// environment variable. A local server does not require a token.
+func WithToken(token string) Option {
+	return func(r *Reranker) {
+		r.token = token
+	}
+}
+
+// WithBatchSize is an option for setting the number of documents sent in one
+// request. It must not exceed the max client batch size of the server, which
+// defaults to 32.
+func WithBatchSize(batchSize int) Option {
+	return func(r *Reranker) {
+		r.batchSize = batchSize
+	}
+}
+
+// WithRawScores is an option for returning the raw logits of the model instead
+// of scores between 0 and 1.
+func WithRawScores(rawScores bool) Option {
+	return func(r *Reranker) {
+		r.rawScores = rawScores
+	}
+}
+
+// WithHTTPClient is an option for setting the http client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reranker) {
+		r.httpClient = client
+	}
+}
@@ -0,0 +1,148 @@
+package rerankers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultLLMBatchSize = 10
+	_llmMaxScore         = 10
+
+	_defaultLLMRerankTemplate = `You are judging how relevant passages are to a search query.
+Rate every passage with an integer from 0 (irrelevant) to 10 (answers the query completely).
+
+Query: {{.query}}
+
+Passages:
+{{.documents}}
+
+Respond with only a JSON array containing one score per passage, in the order of the passages, e.g. [7, 0, 10].`
+)
+
+// ErrInvalidLLMOutput is returned when the output of the LLM can't be parsed
+// into one score per document.
+var ErrInvalidLLMOutput = errors.New("invalid llm output")
+
+// LLM is a reranker using a chat LLM as a judge. Unlike chains.MapRerankDocuments,
+// which makes one call per document, the documents are scored in batches with
+// one call per batch. Scores are normalized to the range 0 to 1.
+type LLM struct {
+	llm       llms.ChatLLM
+	prompt    prompts.PromptTemplate
+	batchSize int
+}
+
+var _ Reranker = LLM{}
+
+// LLMOption is a function type that can be used to modify the LLM reranker.
+type LLMOption func(*LLM)
+
+// WithPrompt is an option for setting the prompt. The prompt receives the
+// variables "query" and "documents", the numbered documents of the batch, and
+// must make the LLM respond with a JSON array of scores from 0 to 10.
+func WithPrompt(prompt prompts.PromptTemplate) LLMOption {
+	return func(l *LLM) {
+		l.prompt = prompt
+	}
+}
+
+// WithBatchSize is an option for setting the number of documents scored in one
+// call. Defaults to 10.
+func WithBatchSize(batchSize int) LLMOption {
+	return func(l *LLM) {
+		l.batchSize = batchSize
+	}
+}
+
+// NewLLM creates a new reranker using the chat LLM as a judge.
+func NewLLM(llm llms.ChatLLM, opts ...LLMOption) LLM {
+	l := LLM{
+		llm:       llm,
+		prompt:    prompts.NewPromptTemplate(_defaultLLMRerankTemplate, []string{"query", "documents"}),
+		batchSize: _defaultLLMBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&l)
+	}
+	if l.batchSize <= 0 {
+		l.batchSize = _defaultLLMBatchSize
+	}
+	return l
+}
+
+// Rerank scores the documents with the LLM and orders them by descending score.
+func (l LLM) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	scores := make([]float64, 0, len(docs))
+	for start := 0; start < len(docs); start += l.batchSize {
+		end := start + l.batchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+
+		batchScores, err := l.scoreBatch(ctx, query, docs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, batchScores...)
+	}
+
+	return SortByScores(docs, scores)
+}
+
+func (l LLM) scoreBatch(ctx context.Context, query string, docs []schema.Document) ([]float64, error) {
+	var documents strings.Builder
+	for i, doc := range docs {
+		fmt.Fprintf(&documents, "[%d] %s\n\n", i+1, strings.TrimSpace(doc.PageContent))
+	}
+
+	prompt, err := l.prompt.Format(map[string]any{
+		"query":     query,
+		"documents": strings.TrimSpace(documents.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := l.llm.Call(ctx, []schema.ChatMessage{schema.HumanChatMessage{Content: prompt}})
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := parseScores(result.Content)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(docs) {
+		return nil, fmt.Errorf("%w: got %d scores for %d documents", ErrInvalidLLMOutput, len(scores), len(docs))
+	}
+
+	for i := range scores {
+		scores[i] /= _llmMaxScore
+	}
+
+	return scores, nil
+}
+
+// parseScores extracts the JSON array of scores from the output of the LLM.
+func parseScores(output string) ([]float64, error) {
+	start := strings.Index(output, "[")
+	end := strings.LastIndex(output, "]")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("%w: no JSON array in %q", ErrInvalidLLMOutput, output)
+	}
+
+	var scores []float64
+	if err := json.Unmarshal([]byte(output[start:end+1]), &scores); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLLMOutput, err.Error())
+	}
+
+	return scores, nil
+}
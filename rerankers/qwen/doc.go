@@ -0,0 +1,3 @@
+// Package qwen contains a reranker using the DashScope text rerank API with the
+// gte-rerank model.
+package qwen
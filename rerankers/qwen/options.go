@@ -0,0 +1,41 @@
+package qwen
+
+import "net/http"
+
+const (
+	_apiKeyEnvName  = "DASHSCOPE_API_KEY"
+	_defaultModel   = "gte-rerank"
+	_defaultBaseURL = "https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank"
+)
+
+// Option is a function type that can be used to modify the reranker.
+type Option func(r *Reranker)
+
+// WithAPIKey is an option for setting the api key. If the option is not set
+// the api key is read from the DASHSCOPE_API_KEY environment variable.
+func WithAPIKey(apiKey string) Option {
+	return func(r *Reranker) {
+		r.apiKey = apiKey
+	}
+}
+
+// WithModel is an option for setting the rerank model. Defaults to gte-rerank.
+func WithModel(model string) Option {
+	return func(r *Reranker) {
+		r.model = model
+	}
+}
+
+// WithBaseURL is an option for setting the url of the rerank API.
+func WithBaseURL(baseURL string) Option {
+	return func(r *Reranker) {
+		r.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient is an option for setting the http client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Reranker) {
+		r.httpClient = client
+	}
+}
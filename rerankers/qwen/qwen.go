@@ -0,0 +1,133 @@
+package qwen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var (
+	// ErrMissingToken is returned when no api key is given.
+	ErrMissingToken = errors.New("missing the DASHSCOPE_API_KEY key, set it in the DASHSCOPE_API_KEY environment variable")
+	// ErrUnexpectedResponse is returned when a result refers to an unknown document.
+	ErrUnexpectedResponse = errors.New("unexpected response")
+)
+
+// Reranker is a reranker using the DashScope text rerank API.
+type Reranker struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ rerankers.Reranker = &Reranker{}
+
+// New creates a new reranker with options.
+func New(opts ...Option) (*Reranker, error) {
+	r := &Reranker{
+		apiKey:     os.Getenv(_apiKeyEnvName),
+		model:      _defaultModel,
+		baseURL:    _defaultBaseURL,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.apiKey == "" {
+		return nil, ErrMissingToken
+	}
+
+	return r, nil
+}
+
+type rerankPayload struct {
+	Model string `json:"model"`
+	Input struct {
+		Query     string   `json:"query"`
+		Documents []string `json:"documents"`
+	} `json:"input"`
+	Parameters struct {
+		ReturnDocuments bool `json:"return_documents"`
+	} `json:"parameters"`
+}
+
+type rerankResponse struct {
+	Output struct {
+		Results []struct {
+			Index          int     `json:"index"`
+			RelevanceScore float64 `json:"relevance_score"`
+		} `json:"results"`
+	} `json:"output"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// Rerank scores the documents with the rerank model and orders them by
+// descending relevance score.
+func (r *Reranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	if len(docs) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	payload := rerankPayload{Model: r.model}
+	payload.Input.Query = query
+	for _, doc := range docs {
+		payload.Input.Documents = append(payload.Input.Documents, doc.PageContent)
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.apiKey)
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg := fmt.Sprintf("API returned unexpected status code: %d", res.StatusCode)
+
+		// No need to check the error here: if it fails, we'll just return the
+		// status code.
+		var errResp rerankResponse
+		if err := json.NewDecoder(res.Body).Decode(&errResp); err != nil {
+			return nil, errors.New(msg) // nolint:goerr113
+		}
+
+		return nil, fmt.Errorf("%s: %s: %s", msg, errResp.Code, errResp.Message) // nolint:goerr113
+	}
+
+	var response rerankResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	scores := make([]float64, len(docs))
+	for _, result := range response.Output.Results {
+		if result.Index < 0 || result.Index >= len(docs) {
+			return nil, fmt.Errorf("%w: document index %d", ErrUnexpectedResponse, result.Index)
+		}
+		scores[result.Index] = result.RelevanceScore
+	}
+
+	return rerankers.SortByScores(docs, scores)
+}
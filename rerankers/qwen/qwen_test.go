@@ -0,0 +1,78 @@
+package qwen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestQwenReranker(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var payload rerankPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "gte-rerank", payload.Model)
+		assert.Equal(t, "什么是文本排序模型", payload.Input.Query)
+		assert.Len(t, payload.Input.Documents, 2)
+
+		_, _ = w.Write([]byte(`{"output":{"results":[
+			{"index":1,"relevance_score":0.93},
+			{"index":0,"relevance_score":0.05}
+		]},"usage":{"total_tokens":79},"request_id":"1"}`))
+	}))
+	defer server.Close()
+
+	reranker, err := New(WithAPIKey("key"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	docs, err := reranker.Rerank(context.Background(), "什么是文本排序模型", []schema.Document{
+		{PageContent: "量子计算是计算科学的一个前沿领域"},
+		{PageContent: "文本排序模型广泛用于搜索引擎和推荐系统中"},
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "文本排序模型广泛用于搜索引擎和推荐系统中", docs[0].PageContent)
+	assert.InDelta(t, 0.93, docs[0].Metadata[rerankers.RelevanceScoreKey], 1e-9)
+}
+
+func TestQwenRerankerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"InvalidApiKey","message":"Invalid API-key provided."}`))
+	}))
+	defer server.Close()
+
+	reranker, err := New(WithAPIKey("key"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = reranker.Rerank(context.Background(), "query", []schema.Document{{PageContent: "a"}})
+	require.ErrorContains(t, err, "InvalidApiKey")
+}
+
+func TestQwenRerankerErrorWithoutJSON(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>Bad Gateway</html>"))
+	}))
+	defer server.Close()
+
+	reranker, err := New(WithAPIKey("key"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = reranker.Rerank(context.Background(), "query", []schema.Document{{PageContent: "a"}})
+	require.EqualError(t, err, "API returned unexpected status code: 502")
+}
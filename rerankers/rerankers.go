@@ -0,0 +1,58 @@
+package rerankers
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// RelevanceScoreKey is the metadata key the relevance score of a reranked
+// document is stored under.
+const RelevanceScoreKey = "relevance_score"
+
+// ErrMismatchScoresAndDocuments is returned when the number of relevance scores
+// does not match the number of documents.
+var ErrMismatchScoresAndDocuments = errors.New("number of scores and documents does not match")
+
+// Reranker is the interface for ordering documents by their relevance to a query.
+type Reranker interface {
+	// Rerank returns the documents ordered by descending relevance to the query.
+	// The relevance score of each document is stored in a copy of its metadata
+	// under RelevanceScoreKey.
+	Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// SortByScores returns copies of the documents with the scores added to their
+// metadata, ordered by descending score. Documents with the same score keep
+// their original order.
+func SortByScores(docs []schema.Document, scores []float64) ([]schema.Document, error) {
+	if len(docs) != len(scores) {
+		return nil, ErrMismatchScoresAndDocuments
+	}
+
+	indexes := make([]int, len(docs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+
+	result := make([]schema.Document, 0, len(docs))
+	for _, i := range indexes {
+		metadata := make(map[string]any, len(docs[i].Metadata)+1)
+		for key, value := range docs[i].Metadata {
+			metadata[key] = value
+		}
+		metadata[RelevanceScoreKey] = scores[i]
+
+		result = append(result, schema.Document{
+			PageContent: docs[i].PageContent,
+			Metadata:    metadata,
+		})
+	}
+
+	return result, nil
+}
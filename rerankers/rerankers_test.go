@@ -0,0 +1,86 @@
+package rerankers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// fakeChatLLM answers every call with the next response.
+type fakeChatLLM struct {
+	responses []string
+	prompts   []string
+}
+
+func (f *fakeChatLLM) Call(_ context.Context, messages []schema.ChatMessage, _ ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	f.prompts = append(f.prompts, messages[0].GetContent())
+	response := f.responses[0]
+	f.responses = f.responses[1:]
+	return &schema.AIChatMessage{Content: response}, nil
+}
+
+func (f *fakeChatLLM) Generate(_ context.Context, _ [][]schema.ChatMessage, _ ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	return nil, nil
+}
+
+func TestSortByScores(t *testing.T) {
+	t.Parallel()
+
+	docs := []schema.Document{
+		{PageContent: "a", Metadata: map[string]any{"source": "x"}},
+		{PageContent: "b"},
+		{PageContent: "c"},
+	}
+	sorted, err := SortByScores(docs, []float64{0.1, 0.9, 0.1})
+	require.NoError(t, err)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, "b", sorted[0].PageContent)
+	assert.Equal(t, "a", sorted[1].PageContent)
+	assert.Equal(t, map[string]any{"source": "x", RelevanceScoreKey: 0.1}, sorted[1].Metadata)
+	assert.Equal(t, map[string]any{"source": "x"}, docs[0].Metadata)
+
+	_, err = SortByScores(docs, []float64{1})
+	require.ErrorIs(t, err, ErrMismatchScoresAndDocuments)
+}
+
+func TestLLMReranker(t *testing.T) {
+	t.Parallel()
+
+	llm := &fakeChatLLM{responses: []string{"Scores: [2, 9]", "[5]"}}
+	reranker := NewLLM(llm, WithBatchSize(2))
+
+	docs, err := reranker.Rerank(context.Background(), "capital of Japan", []schema.Document{
+		{PageContent: "Paris is in France."},
+		{PageContent: "Tokyo is the capital of Japan."},
+		{PageContent: "Japan is an island nation."},
+	})
+	require.NoError(t, err)
+	require.Len(t, llm.prompts, 2)
+	assert.True(t, strings.Contains(llm.prompts[0], "[2] Tokyo is the capital of Japan."))
+	assert.True(t, strings.Contains(llm.prompts[1], "[1] Japan is an island nation."))
+
+	require.Len(t, docs, 3)
+	assert.Equal(t, "Tokyo is the capital of Japan.", docs[0].PageContent)
+	assert.InDelta(t, 0.9, docs[0].Metadata[RelevanceScoreKey], 1e-9)
+	assert.Equal(t, "Japan is an island nation.", docs[1].PageContent)
+	assert.Equal(t, "Paris is in France.", docs[2].PageContent)
+}
+
+func TestLLMRerankerInvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	docs := []schema.Document{{PageContent: "a"}, {PageContent: "b"}}
+
+	_, err := NewLLM(&fakeChatLLM{responses: []string{"I can't rate these."}}).
+		Rerank(context.Background(), "query", docs)
+	require.ErrorIs(t, err, ErrInvalidLLMOutput)
+
+	_, err = NewLLM(&fakeChatLLM{responses: []string{"[1]"}}).
+		Rerank(context.Background(), "query", docs)
+	require.ErrorIs(t, err, ErrInvalidLLMOutput)
+}
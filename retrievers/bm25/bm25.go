@@ -0,0 +1,218 @@
+package bm25
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/wordsegmenter"
+)
+
+// ScoredDocument is a document with its BM25 score for a query.
+type ScoredDocument struct {
+	Document schema.Document
+	Score    float64
+}
+
+// Index is an in-memory BM25 index over documents. An Index is safe for
+// concurrent use.
+type Index struct {
+	mu sync.RWMutex
+
+	segmenter wordsegmenter.Segmenter
+	k1        float64
+	b         float64
+
+	docs      []schema.Document
+	termFreqs []map[string]int
+	docLens   []int
+	docFreqs  map[string]int
+	totalLen  int
+}
+
+// NewIndex creates a new empty index with options.
+func NewIndex(opts ...Option) *Index {
+	idx := &Index{
+		segmenter: wordsegmenter.NewSimple(),
+		k1:        _defaultK1,
+		b:         _defaultB,
+		docFreqs:  make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(idx)
+	}
+
+	return idx
+}
+
+// AddDocuments segments the documents and adds them to the index.
+func (idx *Index) AddDocuments(docs []schema.Document) {
+	termFreqs := make([]map[string]int, 0, len(docs))
+	docLens := make([]int, 0, len(docs))
+	for _, doc := range docs {
+		words := idx.segmenter.Segment(doc.PageContent)
+		tf := make(map[string]int)
+		for _, w := range words {
+			tf[w]++
+		}
+		termFreqs = append(termFreqs, tf)
+		docLens = append(docLens, len(words))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for i, doc := range docs {
+		idx.add(doc, termFreqs[i], docLens[i])
+	}
+}
+
+// Len returns the number of documents in the index.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.docs)
+}
+
+// Search returns up to numDocuments documents matching the query, ordered by
+// descending BM25 score. Documents not sharing any word with the query are not
+// returned.
+func (idx *Index) Search(query string, numDocuments int) []ScoredDocument {
+	terms := unique(idx.segmenter.Segment(query))
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := len(idx.docs)
+	if n == 0 || len(terms) == 0 {
+		return []ScoredDocument{}
+	}
+	avgLen := float64(idx.totalLen) / float64(n)
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	results := make([]ScoredDocument, 0)
+	for i, tf := range idx.termFreqs {
+		var score float64
+		for _, term := range terms {
+			freq := float64(tf[term])
+			if freq == 0 {
+				continue
+			}
+			norm := idx.k1 * (1 - idx.b + idx.b*float64(idx.docLens[i])/avgLen)
+			score += idx.idf(term) * freq * (idx.k1 + 1) / (freq + norm)
+		}
+		if score > 0 {
+			results = append(results, ScoredDocument{Document: idx.docs[i], Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if numDocuments > 0 && len(results) > numDocuments {
+		results = results[:numDocuments]
+	}
+
+	return results
+}
+
+// idf returns the inverse document frequency of a term. It never returns a
+// negative value, so very common words can't lower the score of a document.
+func (idx *Index) idf(term string) float64 {
+	n := float64(len(idx.docs))
+	df := float64(idx.docFreqs[term])
+	return math.Log((n-df+0.5)/(df+0.5) + 1)
+}
+
+func (idx *Index) add(doc schema.Document, tf map[string]int, docLen int) {
+	idx.docs = append(idx.docs, doc)
+	idx.termFreqs = append(idx.termFreqs, tf)
+	idx.docLens = append(idx.docLens, docLen)
+	idx.totalLen += docLen
+	for term := range tf {
+		idx.docFreqs[term]++
+	}
+}
+
+type serializedDocument struct {
+	PageContent string         `json:"page_content"`
+	Metadata    map[string]any `json:"metadata"`
+	TermFreqs   map[string]int `json:"term_freqs"`
+	Length      int            `json:"length"`
+}
+
+type serializedIndex struct {
+	K1        float64              `json:"k1"`
+	B         float64              `json:"b"`
+	Documents []serializedDocument `json:"documents"`
+}
+
+// Save writes the index as JSON to the writer. The segmented words are stored,
+// so loading the index does not segment the documents again. Metadata values go
+// through JSON, so numbers are loaded as float64.
+func (idx *Index) Save(w io.Writer) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	s := serializedIndex{
+		K1:        idx.k1,
+		B:         idx.b,
+		Documents: make([]serializedDocument, 0, len(idx.docs)),
+	}
+	for i, doc := range idx.docs {
+		s.Documents = append(s.Documents, serializedDocument{
+			PageContent: doc.PageContent,
+			Metadata:    doc.Metadata,
+			TermFreqs:   idx.termFreqs[i],
+			Length:      idx.docLens[i],
+		})
+	}
+
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Load reads an index written by Save. The segmenter is not stored in the
+// index, so the same segmenter used to build the index should be given as an
+// option to get consistent query words. Options for k1 and b override the
+// stored values.
+func Load(r io.Reader, opts ...Option) (*Index, error) {
+	var s serializedIndex
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	idx := NewIndex(append([]Option{WithK1(s.K1), WithB(s.B)}, opts...)...)
+	for _, doc := range s.Documents {
+		tf := doc.TermFreqs
+		if tf == nil {
+			tf = make(map[string]int)
+		}
+		idx.add(schema.Document{
+			PageContent: doc.PageContent,
+			Metadata:    doc.Metadata,
+		}, tf, doc.Length)
+	}
+
+	return idx, nil
+}
+
+func unique(words []string) []string {
+	seen := make(map[string]struct{}, len(words))
+	result := make([]string, 0, len(words))
+	for _, w := range words {
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		result = append(result, w)
+	}
+	return result
+}
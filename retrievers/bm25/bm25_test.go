@@ -0,0 +1,86 @@
+package bm25
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/wordsegmenter"
+)
+
+func TestIndexSearch(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex()
+	idx.AddDocuments([]schema.Document{
+		{PageContent: "The quick brown fox jumps over the lazy dog"},
+		{PageContent: "Order number X200-PRO shipped yesterday"},
+		{PageContent: "The dog sleeps all day"},
+	})
+	require.Equal(t, 3, idx.Len())
+
+	results := idx.Search("x200 order", 5)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Order number X200-PRO shipped yesterday", results[0].Document.PageContent)
+
+	results = idx.Search("lazy dog", 5)
+	require.Len(t, results, 2)
+	assert.Equal(t, "The quick brown fox jumps over the lazy dog", results[0].Document.PageContent)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	assert.Len(t, idx.Search("dog", 1), 1)
+	assert.Empty(t, idx.Search("cat", 5))
+}
+
+func TestChineseRetriever(t *testing.T) {
+	t.Parallel()
+
+	seg, err := wordsegmenter.NewChinese(wordsegmenter.WithUserWords("年假"))
+	require.NoError(t, err)
+
+	idx := NewIndex(WithSegmenter(wordsegmenter.NewStopWords(seg, wordsegmenter.ChineseStopWords)))
+	idx.AddDocuments([]schema.Document{
+		{PageContent: "员工请假需要提前三天提交审批流程。", Metadata: map[string]any{"id": "leave"}},
+		{PageContent: "公司的报销制度规定发票必须真实。", Metadata: map[string]any{"id": "expense"}},
+		{PageContent: "年假天数根据员工工龄确定。", Metadata: map[string]any{"id": "annual"}},
+	})
+
+	docs, err := NewRetriever(idx, 2).GetRelevantDocuments(context.Background(), "报销发票有什么规定？")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "expense", docs[0].Metadata["id"])
+
+	docs, err = NewRetriever(idx, 1).GetRelevantDocuments(context.Background(), "员工的年假")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "annual", docs[0].Metadata["id"])
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex(WithK1(1.2), WithB(0.5))
+	idx.AddDocuments([]schema.Document{
+		{PageContent: "tokyo is in japan", Metadata: map[string]any{"page": 1}},
+		{PageContent: "paris is in france", Metadata: map[string]any{"page": 2}},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, idx.Save(&buf))
+
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded.Len())
+	assert.InDelta(t, 1.2, loaded.k1, 1e-9)
+	assert.InDelta(t, 0.5, loaded.b, 1e-9)
+
+	want := idx.Search("japan", 1)
+	got := loaded.Search("japan", 1)
+	require.Len(t, got, 1)
+	assert.Equal(t, want[0].Document.PageContent, got[0].Document.PageContent)
+	assert.InDelta(t, want[0].Score, got[0].Score, 1e-9)
+	assert.Equal(t, map[string]any{"page": float64(1)}, got[0].Document.Metadata)
+}
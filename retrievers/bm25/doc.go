@@ -0,0 +1,4 @@
+// Package bm25 contains an in-memory BM25 keyword index over documents and a
+// retriever using it. Texts are split into words by a pluggable
+// wordsegmenter.Segmenter, so the index works for Chinese text as well.
+package bm25
@@ -0,0 +1,36 @@
+package bm25
+
+import "github.com/tmc/langchaingo/wordsegmenter"
+
+const (
+	_defaultK1 = 1.5
+	_defaultB  = 0.75
+)
+
+// Option is a function type that can be used to modify the index.
+type Option func(*Index)
+
+// WithSegmenter is an option for setting the segmenter used to split documents
+// and queries into words. Defaults to wordsegmenter.Simple. Use a
+// wordsegmenter.Chinese for Chinese text.
+func WithSegmenter(segmenter wordsegmenter.Segmenter) Option {
+	return func(idx *Index) {
+		idx.segmenter = segmenter
+	}
+}
+
+// WithK1 is an option for setting the k1 parameter, which controls the term
+// frequency saturation. Defaults to 1.5.
+func WithK1(k1 float64) Option {
+	return func(idx *Index) {
+		idx.k1 = k1
+	}
+}
+
+// WithB is an option for setting the b parameter, which controls how much the
+// document length normalizes the score. Defaults to 0.75.
+func WithB(b float64) Option {
+	return func(idx *Index) {
+		idx.b = b
+	}
+}
@@ -0,0 +1,46 @@
+package bm25
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Retriever is a retriever for a BM25 index.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+	index            *Index
+	numDocs          int
+}
+
+var _ schema.Retriever = Retriever{}
+
+// NewRetriever takes an index and returns a retriever returning up to
+// numDocuments documents from the index.
+func NewRetriever(index *Index, numDocuments int) Retriever {
+	return Retriever{
+		index:   index,
+		numDocs: numDocuments,
+	}
+}
+
+// GetRelevantDocuments returns the documents with the highest BM25 score for
+// the query.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	results := r.index.Search(query, r.numDocs)
+	docs := make([]schema.Document, 0, len(results))
+	for _, result := range results {
+		docs = append(docs, result.Document)
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,59 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/compressors"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ContextualCompression is a retriever that compresses the documents of
+// another retriever with respect to the query, so that less irrelevant text
+// is passed to the LLM.
+type ContextualCompression struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	compressor       compressors.Compressor
+}
+
+var _ schema.Retriever = ContextualCompression{}
+
+// NewContextualCompression creates a new retriever compressing the documents of
+// the retriever with the compressor. Use compressors.NewPipeline to apply
+// several compressors.
+func NewContextualCompression(
+	retriever schema.Retriever,
+	compressor compressors.Compressor,
+) ContextualCompression {
+	return ContextualCompression{
+		retriever:  retriever,
+		compressor: compressor,
+	}
+}
+
+// GetRelevantDocuments retrieves documents with the underlying retriever and
+// returns them compressed.
+func (c ContextualCompression) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if c.CallbacksHandler != nil {
+		c.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := c.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) > 0 {
+		docs, err = c.compressor.CompressDocuments(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.CallbacksHandler != nil {
+		c.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
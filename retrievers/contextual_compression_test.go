@@ -0,0 +1,40 @@
+package retrievers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// firstSentenceCompressor keeps the first sentence of every document.
+type firstSentenceCompressor struct{}
+
+func (firstSentenceCompressor) CompressDocuments(
+	_ context.Context,
+	_ string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	compressed := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		sentence, _, _ := strings.Cut(doc.PageContent, "。")
+		compressed = append(compressed, schema.Document{PageContent: sentence, Metadata: doc.Metadata})
+	}
+	return compressed, nil
+}
+
+func TestContextualCompression(t *testing.T) {
+	t.Parallel()
+
+	base := staticRetriever{docs: []schema.Document{
+		{PageContent: "年假五天。病假十天。"},
+		{PageContent: "加班调休。"},
+	}}
+
+	docs, err := NewContextualCompression(base, firstSentenceCompressor{}).GetRelevantDocuments(context.Background(), "假期")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假五天", "加班调休"}, contents(docs))
+}
@@ -0,0 +1,21 @@
+/*
+Package retrievers contains implementations of the schema.Retriever interface
+that wrap and combine other retrievers.
+
+The main components of this package are:
+
+- Ensemble: a retriever fusing the results of several retrievers with
+weighted reciprocal rank fusion.
+- Reranking: a retriever reordering the results of another retriever with a
+rerankers.Reranker and keeping the top n documents.
+- MultiQuery: a retriever using an LLM to rewrite the query into several
+queries and returning the union of their results.
+- HyDE: a retriever using an LLM to write a hypothetical answer and
+retrieving documents similar to that answer.
+- ContextualCompression: a retriever shortening and filtering the results of
+another retriever with a compressors.Compressor.
+
+Retrievers over a specific index live in their own packages, like
+vectorstores.Retriever and bm25.Retriever.
+*/
+package retrievers
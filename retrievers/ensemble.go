@@ -0,0 +1,222 @@
+package retrievers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultRRFConstant = 60
+
+	// RRFScoreKey is the metadata key the fused score of a document is stored under.
+	RRFScoreKey = "rrf_score"
+	// SourceScoresKey is the metadata key the per retriever scores of a document
+	// are stored under. The value is a []float64 with one score per retriever, in
+	// the order the retrievers were given. Retrievers that did not return the
+	// document have a score of zero.
+	SourceScoresKey = "rrf_source_scores"
+)
+
+// ErrMismatchWeightsAndRetrievers is returned when the number of weights given
+// to the ensemble does not match the number of retrievers.
+var ErrMismatchWeightsAndRetrievers = errors.New("number of weights and retrievers does not match")
+
+// Ensemble is a retriever that combines the results of several retrievers with
+// weighted reciprocal rank fusion. A document at rank r (starting at 1) of
+// retriever i gets the score weight[i] / (c + r). Scores of the same document
+// from different retrievers are summed and the documents are returned in order
+// of descending fused score.
+//
+// Documents are considered the same if they have the same value for the id key
+// in their metadata or, if no id key is set, the same page content.
+type Ensemble struct {
+	CallbacksHandler callbacks.Handler
+	retrievers       []schema.Retriever
+	weights          []float64
+	c                float64
+	idKey            string
+	numDocs          int
+}
+
+var _ schema.Retriever = Ensemble{}
+
+// EnsembleOption is a function type that can be used to modify the ensemble.
+type EnsembleOption func(*Ensemble)
+
+// WithWeights is an option for setting the weight of each retriever. By default
+// all retrievers have a weight of 1.
+func WithWeights(weights ...float64) EnsembleOption {
+	return func(e *Ensemble) {
+		e.weights = weights
+	}
+}
+
+// WithRRFConstant is an option for setting the constant c of the reciprocal rank
+// fusion. Higher values reduce the advantage of top ranked documents. Defaults to 60.
+func WithRRFConstant(c float64) EnsembleOption {
+	return func(e *Ensemble) {
+		e.c = c
+	}
+}
+
+// WithIDKey is an option for setting the metadata key used to find duplicate
+// documents. Documents without the key are compared by a hash of their content.
+func WithIDKey(key string) EnsembleOption {
+	return func(e *Ensemble) {
+		e.idKey = key
+	}
+}
+
+// WithMaxDocuments is an option for limiting the number of fused documents
+// returned. By default all documents are returned.
+func WithMaxDocuments(numDocuments int) EnsembleOption {
+	return func(e *Ensemble) {
+		e.numDocs = numDocuments
+	}
+}
+
+// NewEnsemble creates a new ensemble of retrievers.
+func NewEnsemble(retrievers []schema.Retriever, opts ...EnsembleOption) (Ensemble, error) {
+	e := Ensemble{
+		retrievers: retrievers,
+		c:          _defaultRRFConstant,
+	}
+	for _, opt := range opts {
+		opt(&e)
+	}
+
+	if e.weights == nil {
+		e.weights = make([]float64, len(retrievers))
+		for i := range e.weights {
+			e.weights[i] = 1
+		}
+	}
+
+	if len(e.weights) != len(e.retrievers) {
+		return Ensemble{}, ErrMismatchWeightsAndRetrievers
+	}
+
+	return e, nil
+}
+
+// GetRelevantDocuments queries all retrievers concurrently and returns the fused
+// documents. The metadata of the returned documents is a copy with the fused
+// score and the per retriever scores added.
+func (e Ensemble) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	results, err := e.retrieveAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := e.fuse(results)
+
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
+
+func (e Ensemble) retrieveAll(ctx context.Context, query string) ([][]schema.Document, error) {
+	results := make([][]schema.Document, len(e.retrievers))
+	errs := make([]error, len(e.retrievers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(e.retrievers))
+	for i, r := range e.retrievers {
+		go func(i int, r schema.Retriever) {
+			defer wg.Done()
+			results[i], errs[i] = r.GetRelevantDocuments(ctx, query)
+		}(i, r)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("retriever %d: %w", i, err)
+		}
+	}
+
+	return results, nil
+}
+
+type fusedDocument struct {
+	doc    schema.Document
+	score  float64
+	scores []float64
+}
+
+func (e Ensemble) fuse(results [][]schema.Document) []schema.Document {
+	fused := make(map[string]*fusedDocument)
+	order := make([]string, 0)
+
+	for i, docs := range results {
+		for rank, doc := range docs {
+			key := e.documentKey(doc)
+			f, ok := fused[key]
+			if !ok {
+				f = &fusedDocument{doc: doc, scores: make([]float64, len(e.retrievers))}
+				fused[key] = f
+				order = append(order, key)
+			}
+			score := e.weights[i] / (e.c + float64(rank+1))
+			// A retriever returning the same document twice only counts once.
+			if f.scores[i] == 0 {
+				f.scores[i] = score
+				f.score += score
+			}
+		}
+	}
+
+	sorted := make([]*fusedDocument, 0, len(order))
+	for _, key := range order {
+		sorted = append(sorted, fused[key])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].score > sorted[j].score
+	})
+
+	if e.numDocs > 0 && len(sorted) > e.numDocs {
+		sorted = sorted[:e.numDocs]
+	}
+
+	docs := make([]schema.Document, 0, len(sorted))
+	for _, f := range sorted {
+		metadata := make(map[string]any, len(f.doc.Metadata)+2)
+		for key, value := range f.doc.Metadata {
+			metadata[key] = value
+		}
+		metadata[RRFScoreKey] = f.score
+		metadata[SourceScoresKey] = f.scores
+
+		docs = append(docs, schema.Document{
+			PageContent: f.doc.PageContent,
+			Metadata:    metadata,
+		})
+	}
+
+	return docs
+}
+
+func (e Ensemble) documentKey(doc schema.Document) string {
+	if e.idKey != "" {
+		if id, ok := doc.Metadata[e.idKey]; ok {
+			return fmt.Sprintf("id:%v", id)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(doc.PageContent))
+	return "content:" + hex.EncodeToString(sum[:])
+}
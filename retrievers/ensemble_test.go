@@ -0,0 +1,89 @@
+package retrievers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// staticRetriever returns the same documents for every query.
+type staticRetriever struct {
+	docs []schema.Document
+	err  error
+}
+
+func (r staticRetriever) GetRelevantDocuments(_ context.Context, _ string) ([]schema.Document, error) {
+	return r.docs, r.err
+}
+
+func contents(docs []schema.Document) []string {
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.PageContent)
+	}
+	return result
+}
+
+func TestEnsemble(t *testing.T) {
+	t.Parallel()
+
+	dense := staticRetriever{docs: []schema.Document{
+		{PageContent: "a"}, {PageContent: "b"}, {PageContent: "c"},
+	}}
+	sparse := staticRetriever{docs: []schema.Document{
+		{PageContent: "c"}, {PageContent: "d"}, {PageContent: "a"},
+	}}
+
+	e, err := NewEnsemble([]schema.Retriever{dense, sparse})
+	require.NoError(t, err)
+
+	docs, err := e.GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b", "d"}, contents(docs))
+	assert.InDelta(t, 1.0/61+1.0/63, docs[0].Metadata[RRFScoreKey], 1e-9)
+	assert.Equal(t, []float64{1.0 / 61, 1.0 / 63}, docs[0].Metadata[SourceScoresKey])
+	assert.Equal(t, []float64{0, 1.0 / 62}, docs[3].Metadata[SourceScoresKey])
+	assert.Nil(t, dense.docs[0].Metadata)
+
+	e, err = NewEnsemble([]schema.Retriever{dense, sparse}, WithWeights(0.2, 0.8), WithMaxDocuments(2))
+	require.NoError(t, err)
+	docs, err = e.GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a"}, contents(docs))
+}
+
+func TestEnsembleIDKey(t *testing.T) {
+	t.Parallel()
+
+	first := staticRetriever{docs: []schema.Document{
+		{PageContent: "chunk one", Metadata: map[string]any{"id": 1}},
+	}}
+	second := staticRetriever{docs: []schema.Document{
+		{PageContent: "chunk one, reformatted", Metadata: map[string]any{"id": 1}},
+		{PageContent: "chunk two"},
+	}}
+
+	e, err := NewEnsemble([]schema.Retriever{first, second}, WithIDKey("id"))
+	require.NoError(t, err)
+
+	docs, err := e.GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chunk one", "chunk two"}, contents(docs))
+}
+
+func TestEnsembleErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEnsemble([]schema.Retriever{staticRetriever{}}, WithWeights(1, 2))
+	require.ErrorIs(t, err, ErrMismatchWeightsAndRetrievers)
+
+	errFailed := errors.New("failed")
+	e, err := NewEnsemble([]schema.Retriever{staticRetriever{}, staticRetriever{err: errFailed}})
+	require.NoError(t, err)
+	_, err = e.GetRelevantDocuments(context.Background(), "query")
+	require.ErrorIs(t, err, errFailed)
+}
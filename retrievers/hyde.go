@@ -0,0 +1,112 @@
+package retrievers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/internal/promptutil"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var _defaultHyDETemplate = `Please write a short passage that answers the question. ` + //nolint:gochecknoglobals
+	promptutil.SameLanguage("the passage", "the question") + `
+
+Question: {{.question}}
+
+Passage:`
+
+// HyDE is a retriever using hypothetical document embeddings. An LLM writes a
+// hypothetical answer to the query, and that answer instead of the query is
+// given to another retriever, usually a vectorstores.Retriever, so it is
+// embedded and matched against the documents. Answers tend to be closer to the
+// relevant documents in the embedding space than short questions.
+type HyDE struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	chain            *chains.LLMChain
+	includeQuery     bool
+}
+
+var _ schema.Retriever = HyDE{}
+
+// HyDEOption is a function type that can be used to modify the HyDE retriever.
+type HyDEOption func(*HyDE)
+
+// WithHyDEPrompt is an option for setting the prompt used to generate the
+// hypothetical document. The prompt receives the variable "question".
+func WithHyDEPrompt(prompt prompts.PromptTemplate) HyDEOption {
+	return func(h *HyDE) {
+		h.chain.Prompt = prompt
+	}
+}
+
+// WithIncludeQuery is an option for prepending the query to the hypothetical
+// document given to the retriever. Defaults to false.
+func WithIncludeQuery(includeQuery bool) HyDEOption {
+	return func(h *HyDE) {
+		h.includeQuery = includeQuery
+	}
+}
+
+// NewHyDE creates a new HyDE retriever generating hypothetical documents with
+// the llm and retrieving documents with the retriever.
+func NewHyDE(llm llms.LanguageModel, retriever schema.Retriever, opts ...HyDEOption) HyDE {
+	h := HyDE{
+		retriever: retriever,
+		chain: chains.NewLLMChain(
+			llm,
+			prompts.NewPromptTemplate(_defaultHyDETemplate, []string{"question"}),
+		),
+	}
+	for _, opt := range opts {
+		opt(&h)
+	}
+	return h
+}
+
+// GetRelevantDocuments generates a hypothetical document for the query and
+// retrieves documents similar to it. The callbacks handler is notified of the
+// original query and of the generated document.
+func (h HyDE) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if h.CallbacksHandler != nil {
+		h.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	hypothetical, err := h.GenerateHypotheticalDocument(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.includeQuery {
+		hypothetical = query + "\n" + hypothetical
+	}
+
+	if h.CallbacksHandler != nil {
+		h.CallbacksHandler.HandleRetrieverStart(ctx, hypothetical)
+	}
+
+	docs, err := h.retriever.GetRelevantDocuments(ctx, hypothetical)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.CallbacksHandler != nil {
+		h.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
+
+// GenerateHypotheticalDocument returns the hypothetical answer the LLM writes
+// for the query.
+func (h HyDE) GenerateHypotheticalDocument(ctx context.Context, query string) (string, error) {
+	output, err := chains.Predict(ctx, h.chain, map[string]any{"question": query})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
@@ -0,0 +1,152 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/internal/promptutil"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _defaultNumQueries = 3
+
+var _defaultMultiQueryTemplate = `You are an AI language model assistant. Your task is to generate {{.num_queries}} different versions of the given user question to retrieve relevant documents from a vector database. By generating multiple perspectives on the user question, your goal is to help the user overcome some of the limitations of distance-based similarity search. ` + //nolint:gochecknoglobals,lll
+	promptutil.SameLanguage("the questions", "the original question") + `
+Provide these alternative questions separated by newlines, without numbering.
+
+Original question: {{.question}}`
+
+// MultiQuery is a retriever that uses an LLM to generate several paraphrases of
+// the query, retrieves documents for each of them with another retriever and
+// returns the union of the documents. This helps with short or ambiguous queries.
+type MultiQuery struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	chain            *chains.LLMChain
+	numQueries       int
+	includeOriginal  bool
+}
+
+var _ schema.Retriever = MultiQuery{}
+
+// MultiQueryOption is a function type that can be used to modify the multi query retriever.
+type MultiQueryOption func(*MultiQuery)
+
+// WithNumQueries is an option for setting the number of queries to generate.
+// Defaults to 3.
+func WithNumQueries(numQueries int) MultiQueryOption {
+	return func(m *MultiQuery) {
+		m.numQueries = numQueries
+	}
+}
+
+// WithMultiQueryPrompt is an option for setting the prompt used to generate the
+// queries. The prompt receives the variables "question" and "num_queries" and
+// must make the LLM respond with one query per line.
+func WithMultiQueryPrompt(prompt prompts.PromptTemplate) MultiQueryOption {
+	return func(m *MultiQuery) {
+		m.chain.Prompt = prompt
+	}
+}
+
+// WithIncludeOriginal is an option for setting if the original query is
+// also used to retrieve documents. Defaults to true.
+func WithIncludeOriginal(includeOriginal bool) MultiQueryOption {
+	return func(m *MultiQuery) {
+		m.includeOriginal = includeOriginal
+	}
+}
+
+// NewMultiQuery creates a new multi query retriever generating queries with the
+// llm and retrieving documents with the retriever.
+func NewMultiQuery(llm llms.LanguageModel, retriever schema.Retriever, opts ...MultiQueryOption) MultiQuery {
+	m := MultiQuery{
+		retriever: retriever,
+		chain: chains.NewLLMChain(
+			llm,
+			prompts.NewPromptTemplate(_defaultMultiQueryTemplate, []string{"question", "num_queries"}),
+		),
+		numQueries:      _defaultNumQueries,
+		includeOriginal: true,
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// GetRelevantDocuments generates queries, retrieves documents for all of them
+// and returns the unique documents in order of first appearance. The callbacks
+// handler is notified of the original query and of each generated query.
+func (m MultiQuery) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if m.CallbacksHandler != nil {
+		m.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	queries, err := m.GenerateQueries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.CallbacksHandler != nil {
+		for _, q := range queries {
+			m.CallbacksHandler.HandleRetrieverStart(ctx, q)
+		}
+	}
+
+	if m.includeOriginal {
+		queries = append([]string{query}, queries...)
+	}
+
+	seen := make(map[string]struct{})
+	docs := make([]schema.Document, 0)
+	for _, q := range queries {
+		qDocs, err := m.retriever.GetRelevantDocuments(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range qDocs {
+			if _, ok := seen[doc.PageContent]; ok {
+				continue
+			}
+			seen[doc.PageContent] = struct{}{}
+			docs = append(docs, doc)
+		}
+	}
+
+	if m.CallbacksHandler != nil {
+		m.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
+
+// GenerateQueries returns the queries generated by the LLM for the query.
+func (m MultiQuery) GenerateQueries(ctx context.Context, query string) ([]string, error) {
+	output, err := chains.Predict(ctx, m.chain, map[string]any{
+		"question":    query,
+		"num_queries": m.numQueries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]string, 0, m.numQueries)
+	seen := map[string]struct{}{query: {}}
+	for _, q := range promptutil.ListItems(output) {
+		if _, ok := seen[q]; ok {
+			continue
+		}
+		seen[q] = struct{}{}
+		queries = append(queries, q)
+	}
+
+	if m.numQueries > 0 && len(queries) > m.numQueries {
+		queries = queries[:m.numQueries]
+	}
+
+	return queries, nil
+}
@@ -0,0 +1,80 @@
+package multivector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/internal/promptutil"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+var (
+	_summaryTemplate = `Summarize the following document. ` + //nolint:gochecknoglobals
+		promptutil.SameLanguage("the summary", "the document") + `
+
+{{.context}}
+
+Summary:`
+
+	_questionTemplate = `Write {{.num_questions}} questions that could be answered by the following document. ` + //nolint:gochecknoglobals,lll
+		promptutil.SameLanguage("the questions", "the document") + ` Write one question per line, without numbering.
+
+{{.context}}
+
+Questions:`
+)
+
+// ChildrenFunc derives the documents added to the vector store for a parent
+// document. The returned documents do not need to carry any metadata.
+type ChildrenFunc func(ctx context.Context, parent schema.Document) ([]schema.Document, error)
+
+// SplitChildren returns a ChildrenFunc splitting the parent into chunks with the
+// text splitter.
+func SplitChildren(splitter textsplitter.TextSplitter) ChildrenFunc {
+	return func(_ context.Context, parent schema.Document) ([]schema.Document, error) {
+		return textsplitter.SplitDocuments(splitter, []schema.Document{parent})
+	}
+}
+
+// SummaryChildren returns a ChildrenFunc using the llm to write a summary of
+// the parent.
+func SummaryChildren(llm llms.LanguageModel) ChildrenFunc {
+	chain := chains.NewLLMChain(llm, prompts.NewPromptTemplate(_summaryTemplate, []string{"context"}))
+
+	return func(ctx context.Context, parent schema.Document) ([]schema.Document, error) {
+		output, err := chains.Predict(ctx, chain, map[string]any{"context": parent.PageContent})
+		if err != nil {
+			return nil, err
+		}
+		return []schema.Document{{PageContent: strings.TrimSpace(output)}}, nil
+	}
+}
+
+// QuestionChildren returns a ChildrenFunc using the llm to write numQuestions
+// hypothetical questions answered by the parent.
+func QuestionChildren(llm llms.LanguageModel, numQuestions int) ChildrenFunc {
+	chain := chains.NewLLMChain(
+		llm,
+		prompts.NewPromptTemplate(_questionTemplate, []string{"context", "num_questions"}),
+	)
+
+	return func(ctx context.Context, parent schema.Document) ([]schema.Document, error) {
+		output, err := chains.Predict(ctx, chain, map[string]any{
+			"context":       parent.PageContent,
+			"num_questions": numQuestions,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		children := make([]schema.Document, 0, numQuestions)
+		for _, question := range promptutil.ListItems(output) {
+			children = append(children, schema.Document{PageContent: question})
+		}
+		return children, nil
+	}
+}
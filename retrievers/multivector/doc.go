@@ -0,0 +1,17 @@
+/*
+Package multivector contains a retriever that indexes several vectors per
+document and returns the whole document when any of them matches.
+
+Documents, optionally split into parent chunks, are stored in a
+docstore.DocStore. For each of them child documents are derived and added to
+a vectorstores.VectorStore with the parent id in their metadata. Queries are
+matched against the children and the deduplicated parents are returned.
+
+Children can be derived with:
+
+- SplitChildren: small chunks of the parent split with a text splitter. This
+is the parent document retriever created with NewParentDocument.
+- SummaryChildren: a summary of the parent written by an LLM.
+- QuestionChildren: hypothetical questions the parent answers written by an LLM.
+*/
+package multivector
@@ -0,0 +1,163 @@
+package multivector
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/docstore"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// ErrMissingParentID is returned when a child found in the vector store has no
+// parent id in its metadata.
+var ErrMissingParentID = errors.New("child document has no parent id")
+
+// Retriever is a retriever searching child documents in a vector store and
+// returning their parent documents from a docstore.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+	store            vectorstores.VectorStore
+	docStore         docstore.DocStore
+	children         ChildrenFunc
+	parentSplitter   textsplitter.TextSplitter
+	idKey            string
+	numChildren      int
+	maxDocuments     int
+	searchOptions    []vectorstores.Option
+}
+
+var _ schema.Retriever = Retriever{}
+
+// New creates a new multivector retriever storing children derived with the
+// children function in the vector store and parents in the docstore.
+func New(
+	store vectorstores.VectorStore,
+	docStore docstore.DocStore,
+	children ChildrenFunc,
+	opts ...Option,
+) Retriever {
+	r := Retriever{
+		store:       store,
+		docStore:    docStore,
+		children:    children,
+		idKey:       _defaultIDKey,
+		numChildren: _defaultNumChildren,
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// NewParentDocument creates a new parent document retriever, indexing small
+// chunks split with the child splitter and returning the documents, or the
+// chunks split with WithParentSplitter, they belong to.
+func NewParentDocument(
+	store vectorstores.VectorStore,
+	docStore docstore.DocStore,
+	childSplitter textsplitter.TextSplitter,
+	opts ...Option,
+) Retriever {
+	return New(store, docStore, SplitChildren(childSplitter), opts...)
+}
+
+// AddDocuments splits the documents into parents, stores them in the docstore
+// and adds their children to the vector store. It returns the ids of the
+// parents.
+func (r Retriever) AddDocuments(ctx context.Context, docs []schema.Document) ([]string, error) {
+	parents := docs
+	if r.parentSplitter != nil {
+		var err error
+		parents, err = textsplitter.SplitDocuments(r.parentSplitter, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(parents))
+	children := make([]schema.Document, 0, len(parents))
+	for _, parent := range parents {
+		id := uuid.NewString()
+		ids = append(ids, id)
+
+		parentChildren, err := r.children(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range parentChildren {
+			metadata := make(map[string]any, len(parent.Metadata)+1)
+			for key, value := range parent.Metadata {
+				metadata[key] = value
+			}
+			for key, value := range child.Metadata {
+				metadata[key] = value
+			}
+			metadata[r.idKey] = id
+			children = append(children, schema.Document{PageContent: child.PageContent, Metadata: metadata})
+		}
+	}
+
+	if err := r.docStore.MSet(ctx, ids, parents); err != nil {
+		return nil, err
+	}
+	if len(children) > 0 {
+		if err := r.store.AddDocuments(ctx, children); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// GetRelevantDocuments searches children similar to the query and returns their
+// parents in order of the best matching child.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	children, err := r.store.SimilaritySearch(ctx, query, r.numChildren, r.searchOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(children))
+	seen := make(map[string]struct{}, len(children))
+	for _, child := range children {
+		id, ok := child.Metadata[r.idKey].(string)
+		if !ok {
+			return nil, ErrMissingParentID
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	parents, err := r.docStore.MGet(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(parents))
+	for _, id := range ids {
+		if r.maxDocuments > 0 && len(docs) >= r.maxDocuments {
+			break
+		}
+		// Parents deleted from the docstore are skipped.
+		if parent, ok := parents[id]; ok {
+			docs = append(docs, parent)
+		}
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
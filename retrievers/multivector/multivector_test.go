@@ -0,0 +1,128 @@
+package multivector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/docstore"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// substringStore is a vector store returning the documents containing the query.
+type substringStore struct {
+	docs []schema.Document
+}
+
+func (s *substringStore) AddDocuments(_ context.Context, docs []schema.Document, _ ...vectorstores.Option) error {
+	s.docs = append(s.docs, docs...)
+	return nil
+}
+
+func (s *substringStore) SimilaritySearch(
+	_ context.Context,
+	query string,
+	numDocuments int,
+	_ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	docs := make([]schema.Document, 0)
+	for _, doc := range s.docs {
+		if strings.Contains(doc.PageContent, query) && len(docs) < numDocuments {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// separatorSplitter splits texts at a separator.
+type separatorSplitter string
+
+func (s separatorSplitter) SplitText(text string) ([]string, error) {
+	return strings.Split(text, string(s)), nil
+}
+
+type testLanguageModel struct {
+	expResult string
+}
+
+func (l testLanguageModel) GeneratePrompt(_ context.Context, _ []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.LLMResult{
+		Generations: [][]*llms.Generation{{&llms.Generation{
+			Text: l.expResult,
+		}}},
+	}, nil
+}
+
+func (l testLanguageModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+func TestParentDocument(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store := &substringStore{}
+	docStore := docstore.NewInMemory()
+	r := NewParentDocument(store, docStore, separatorSplitter("\n"), WithParentSplitter(separatorSplitter("\n\n")))
+
+	ids, err := r.AddDocuments(ctx, []schema.Document{{
+		PageContent: "年假五天\n病假十天\n\n加班调休\n加班工资",
+		Metadata:    map[string]any{"source": "handbook.txt"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.Len(t, store.docs, 4)
+	assert.Equal(t, ids[0], store.docs[1].Metadata["doc_id"])
+	assert.Equal(t, "handbook.txt", store.docs[3].Metadata["source"])
+
+	docs, err := r.GetRelevantDocuments(ctx, "假")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "年假五天\n病假十天", docs[0].PageContent)
+	assert.Equal(t, "handbook.txt", docs[0].Metadata["source"])
+
+	docs, err = r.GetRelevantDocuments(ctx, "加班")
+	require.NoError(t, err)
+	assert.Equal(t, "加班调休\n加班工资", docs[0].PageContent)
+
+	require.NoError(t, docStore.MDelete(ctx, ids[:1]))
+	docs, err = r.GetRelevantDocuments(ctx, "天")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
+
+func TestMultiVectorChildren(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	parent := schema.Document{PageContent: "员工每年享有五天带薪年假，工作满十年后增加到十天。"}
+
+	store := &substringStore{}
+	r := New(store, docstore.NewInMemory(), QuestionChildren(testLanguageModel{expResult: "1. 年假有几天？\n\n2. 工龄对年假的影响？"}, 2)) //nolint:lll
+	_, err := r.AddDocuments(ctx, []schema.Document{parent})
+	require.NoError(t, err)
+	assert.Len(t, store.docs, 2)
+	assert.Equal(t, "年假有几天？", store.docs[0].PageContent)
+
+	docs, err := r.GetRelevantDocuments(ctx, "工龄")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, parent.PageContent, docs[0].PageContent)
+
+	store = &substringStore{}
+	r = New(store, docstore.NewInMemory(), SummaryChildren(testLanguageModel{expResult: " 带薪年假规定 "}))
+	_, err = r.AddDocuments(ctx, []schema.Document{parent})
+	require.NoError(t, err)
+	docs, err = r.GetRelevantDocuments(ctx, "带薪年假规定")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, parent.PageContent, docs[0].PageContent)
+
+	_, err = New(&substringStore{docs: []schema.Document{{PageContent: "x"}}}, docstore.NewInMemory(), nil).
+		GetRelevantDocuments(ctx, "x")
+	require.ErrorIs(t, err, ErrMissingParentID)
+}
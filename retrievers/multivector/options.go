@@ -0,0 +1,55 @@
+package multivector
+
+import (
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	_defaultIDKey       = "doc_id"
+	_defaultNumChildren = 10
+)
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(*Retriever)
+
+// WithParentSplitter is an option for splitting added documents into parent
+// chunks before deriving the children. By default whole documents are parents.
+func WithParentSplitter(splitter textsplitter.TextSplitter) Option {
+	return func(r *Retriever) {
+		r.parentSplitter = splitter
+	}
+}
+
+// WithIDKey is an option for setting the metadata key of the children holding
+// the parent id. Defaults to "doc_id".
+func WithIDKey(idKey string) Option {
+	return func(r *Retriever) {
+		r.idKey = idKey
+	}
+}
+
+// WithNumChildren is an option for setting the number of children searched in
+// the vector store. Several children can belong to the same parent, so this
+// should be larger than the number of parents wanted. Defaults to 10.
+func WithNumChildren(numChildren int) Option {
+	return func(r *Retriever) {
+		r.numChildren = numChildren
+	}
+}
+
+// WithMaxDocuments is an option for setting the maximum number of parents
+// returned. By default all matched parents are returned.
+func WithMaxDocuments(maxDocuments int) Option {
+	return func(r *Retriever) {
+		r.maxDocuments = maxDocuments
+	}
+}
+
+// WithSearchOptions is an option for setting the options of the vector store
+// similarity search, like a score threshold or filters.
+func WithSearchOptions(opts ...vectorstores.Option) Option {
+	return func(r *Retriever) {
+		r.searchOptions = opts
+	}
+}
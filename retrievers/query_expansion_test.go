@@ -0,0 +1,108 @@
+package retrievers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+type testLanguageModel struct {
+	// expected result of the language model
+	expResult string
+	// record the prompt that was passed to the language model
+	recordedPrompt []schema.PromptValue
+}
+
+func (l *testLanguageModel) GeneratePrompt(_ context.Context, promptValue []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	l.recordedPrompt = promptValue
+	return llms.LLMResult{
+		Generations: [][]*llms.Generation{{&llms.Generation{
+			Text: l.expResult,
+		}}},
+	}, nil
+}
+
+func (l *testLanguageModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+// queryRetriever returns the documents mapped to a query and records the queries.
+type queryRetriever struct {
+	docs    map[string][]schema.Document
+	queries []string
+}
+
+func (r *queryRetriever) GetRelevantDocuments(_ context.Context, query string) ([]schema.Document, error) {
+	r.queries = append(r.queries, query)
+	return r.docs[query], nil
+}
+
+// retrieverStartHandler records the queries of HandleRetrieverStart.
+type retrieverStartHandler struct {
+	callbacks.LogHandler
+	queries []string
+}
+
+func (h *retrieverStartHandler) HandleRetrieverStart(_ context.Context, query string) {
+	h.queries = append(h.queries, query)
+}
+
+func (h *retrieverStartHandler) HandleRetrieverEnd(_ context.Context, _ []schema.Document) {}
+
+func TestMultiQuery(t *testing.T) {
+	t.Parallel()
+
+	llm := &testLanguageModel{expResult: "1. 年假有几天？\n2. 带薪年休假规定\n\n- 年假\n4. 多余的问题"}
+	base := &queryRetriever{docs: map[string][]schema.Document{
+		"年假":      {{PageContent: "a"}},
+		"年假有几天？":  {{PageContent: "b"}, {PageContent: "a"}},
+		"带薪年休假规定": {{PageContent: "c"}},
+	}}
+	handler := &retrieverStartHandler{}
+
+	r := NewMultiQuery(llm, base)
+	r.CallbacksHandler = handler
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "年假")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, contents(docs))
+	assert.Equal(t, []string{"年假", "年假有几天？", "带薪年休假规定", "多余的问题"}, base.queries)
+	assert.Equal(t, []string{"年假", "年假有几天？", "带薪年休假规定", "多余的问题"}, handler.queries)
+	assert.Contains(t, llm.recordedPrompt[0].String(), "generate 3 different versions")
+
+	base.queries = nil
+	r = NewMultiQuery(llm, base, WithNumQueries(2), WithIncludeOriginal(false))
+	docs, err = r.GetRelevantDocuments(context.Background(), "年假")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, contents(docs))
+	assert.Equal(t, []string{"年假有几天？", "带薪年休假规定"}, base.queries)
+}
+
+func TestHyDE(t *testing.T) {
+	t.Parallel()
+
+	llm := &testLanguageModel{expResult: " 员工每年享有5至15天带薪年假。 "}
+	base := &queryRetriever{docs: map[string][]schema.Document{
+		"员工每年享有5至15天带薪年假。":     {{PageContent: "年假制度"}},
+		"年假\n员工每年享有5至15天带薪年假。": {{PageContent: "年假制度全文"}},
+	}}
+	handler := &retrieverStartHandler{}
+
+	r := NewHyDE(llm, base)
+	r.CallbacksHandler = handler
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "年假")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假制度"}, contents(docs))
+	assert.Equal(t, []string{"年假", "员工每年享有5至15天带薪年假。"}, handler.queries)
+	assert.Contains(t, llm.recordedPrompt[0].String(), "Question: 年假")
+
+	docs, err = NewHyDE(llm, base, WithIncludeQuery(true)).GetRelevantDocuments(context.Background(), "年假")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"年假制度全文"}, contents(docs))
+}
@@ -0,0 +1,62 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Reranking is a retriever that reorders the documents of another retriever
+// with a reranker and keeps only the most relevant ones. Retrieve more documents
+// than needed from the underlying retriever and let the reranker pick the top n.
+type Reranking struct {
+	CallbacksHandler callbacks.Handler
+	retriever        schema.Retriever
+	reranker         rerankers.Reranker
+	topN             int
+}
+
+var _ schema.Retriever = Reranking{}
+
+// NewReranking creates a new retriever reranking the documents of the retriever
+// and returning at most topN documents. If topN is not positive all reranked
+// documents are returned.
+func NewReranking(retriever schema.Retriever, reranker rerankers.Reranker, topN int) Reranking {
+	return Reranking{
+		retriever: retriever,
+		reranker:  reranker,
+		topN:      topN,
+	}
+}
+
+// GetRelevantDocuments retrieves documents with the underlying retriever and
+// returns the top n documents after reranking.
+func (r Reranking) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := r.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) > 0 {
+		docs, err = r.reranker.Rerank(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.topN > 0 && len(docs) > r.topN {
+		docs = docs[:r.topN]
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,46 @@
+package retrievers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/rerankers"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// lengthReranker ranks shorter documents higher.
+type lengthReranker struct{}
+
+func (lengthReranker) Rerank(_ context.Context, _ string, docs []schema.Document) ([]schema.Document, error) {
+	scores := make([]float64, 0, len(docs))
+	for _, doc := range docs {
+		scores = append(scores, 1/float64(len(doc.PageContent)))
+	}
+	return rerankers.SortByScores(docs, scores)
+}
+
+func TestReranking(t *testing.T) {
+	t.Parallel()
+
+	base := staticRetriever{docs: []schema.Document{
+		{PageContent: strings.Repeat("a", 3)},
+		{PageContent: strings.Repeat("b", 1)},
+		{PageContent: strings.Repeat("c", 2)},
+	}}
+
+	docs, err := NewReranking(base, lengthReranker{}, 2).GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "cc"}, contents(docs))
+	assert.InDelta(t, 1.0, docs[0].Metadata[rerankers.RelevanceScoreKey], 1e-9)
+
+	docs, err = NewReranking(base, lengthReranker{}, 0).GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	docs, err = NewReranking(staticRetriever{}, lengthReranker{}, 2).GetRelevantDocuments(context.Background(), "query")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
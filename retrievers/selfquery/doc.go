@@ -0,0 +1,16 @@
+/*
+Package selfquery contains a retriever that uses an LLM to turn a natural
+language question into a semantic query and a metadata filter, and searches a
+vectorstores.VectorStore with both.
+
+The metadata fields the LLM may filter on are declared as a list of
+AttributeInfo. The filter written by the LLM is parsed into Comparison and
+Operation values, validated against the attributes and converted into the
+filter format of the vector store by a Translator. If the LLM output can not
+be parsed or validated the retriever falls back to an unfiltered search with
+the original question.
+
+Range comparisons are only allowed on integer and float attributes. Store
+dates as numbers, e.g. the year or 20230115, to filter on them.
+*/
+package selfquery
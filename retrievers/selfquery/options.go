@@ -0,0 +1,38 @@
+package selfquery
+
+import (
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const _defaultNumDocuments = 4
+
+// Option is a function type that can be used to modify the retriever.
+type Option func(*Retriever)
+
+// WithNumDocuments is an option for setting the number of documents returned.
+// Defaults to 4.
+func WithNumDocuments(numDocuments int) Option {
+	return func(r *Retriever) {
+		r.numDocuments = numDocuments
+	}
+}
+
+// WithPrompt is an option for setting the prompt used to structure the
+// question. The prompt receives the variables "content", "attributes" and
+// "question" and must make the LLM answer with the JSON format parsed by
+// ParseOutput.
+func WithPrompt(prompt prompts.PromptTemplate) Option {
+	return func(r *Retriever) {
+		r.chain.Prompt = prompt
+	}
+}
+
+// WithSearchOptions is an option for setting additional options of the vector
+// store similarity search, like a name space or score threshold. Filters set
+// here are replaced by the filter of the question.
+func WithSearchOptions(opts ...vectorstores.Option) Option {
+	return func(r *Retriever) {
+		r.searchOptions = opts
+	}
+}
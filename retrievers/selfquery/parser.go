@@ -0,0 +1,87 @@
+package selfquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidOutput is returned when the output of the LLM does not contain a
+// structured query.
+var ErrInvalidOutput = errors.New("invalid structured query output")
+
+// rawQuery is the JSON written by the LLM.
+type rawQuery struct {
+	Query  string     `json:"query"`
+	Filter *rawFilter `json:"filter"`
+}
+
+// rawFilter is either a comparison or an operation.
+type rawFilter struct {
+	Comparator string       `json:"comparator"`
+	Attribute  string       `json:"attribute"`
+	Value      any          `json:"value"`
+	Operator   string       `json:"operator"`
+	Arguments  []*rawFilter `json:"arguments"`
+}
+
+// ParseOutput parses the JSON output of the LLM into a structured query and
+// validates the filter against the attributes. Text around the JSON object,
+// like markdown code fences, is ignored.
+func ParseOutput(output string, attributes []AttributeInfo) (StructuredQuery, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start == -1 || end < start {
+		return StructuredQuery{}, fmt.Errorf("%w: no JSON object in %q", ErrInvalidOutput, output)
+	}
+
+	var raw rawQuery
+	if err := json.Unmarshal([]byte(output[start:end+1]), &raw); err != nil {
+		return StructuredQuery{}, fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+
+	filter, err := raw.Filter.toFilter()
+	if err != nil {
+		return StructuredQuery{}, err
+	}
+	filter, err = Validate(filter, attributes)
+	if err != nil {
+		return StructuredQuery{}, err
+	}
+
+	return StructuredQuery{Query: strings.TrimSpace(raw.Query), Filter: filter}, nil
+}
+
+func (f *rawFilter) toFilter() (Filter, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	if f.Operator == "" {
+		if f.Comparator == "" {
+			// An empty object means no filter.
+			if f.Attribute == "" && f.Value == nil {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("%w: filter without comparator or operator", ErrInvalidOutput)
+		}
+		return Comparison{
+			Comparator: Comparator(strings.ToLower(f.Comparator)),
+			Attribute:  f.Attribute,
+			Value:      f.Value,
+		}, nil
+	}
+
+	arguments := make([]Filter, 0, len(f.Arguments))
+	for _, argument := range f.Arguments {
+		filter, err := argument.toFilter()
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			arguments = append(arguments, filter)
+		}
+	}
+	return Operation{Operator: Operator(strings.ToLower(f.Operator)), Arguments: arguments}, nil
+}
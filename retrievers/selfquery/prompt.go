@@ -0,0 +1,40 @@
+package selfquery
+
+import (
+	"encoding/json"
+)
+
+const _defaultTemplate = `Your goal is to structure the user's question to match the request schema provided below.
+
+The request must be a JSON object with the following keys:
+- "query": text string to compare to the document contents. Remove the parts of the question that are expressed by the filter. Keep the language of the question.
+- "filter": logical condition statement for filtering documents, or null if the question does not restrict the metadata.
+
+A filter is either a comparison:
+{"comparator": "eq" | "ne" | "gt" | "gte" | "lt" | "lte" | "in" | "nin", "attribute": "name of the attribute", "value": value to compare to}
+or a logical operation of filters:
+{"operator": "and" | "or" | "not", "arguments": [filter, ...]}
+
+Make sure that you only use the comparators and logical operators listed above and no others.
+Make sure that filters only refer to attributes that exist in the data source.
+Make sure that the values match the types of the attributes. The value of "in" and "nin" is a list.
+Range comparators ("gt", "gte", "lt", "lte") can only be used with integer and float attributes.
+
+Example:
+Data source: documents of company policies
+Attributes: [{"name": "year", "type": "integer", "description": "the year the policy was issued"}, {"name": "department", "type": "string", "description": "the department issuing the policy"}]
+Question: 2023年以后人事部的请假制度
+Answer: {"query": "请假制度", "filter": {"operator": "and", "arguments": [{"comparator": "gte", "attribute": "year", "value": 2023}, {"comparator": "eq", "attribute": "department", "value": "人事部"}]}}
+
+Data source: {{.content}}
+Attributes: {{.attributes}}
+Question: {{.question}}
+Answer:`
+
+func formatAttributes(attributes []AttributeInfo) string {
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
@@ -0,0 +1,123 @@
+package selfquery
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// Retriever is a retriever structuring questions into a semantic query and a
+// metadata filter with an LLM before searching a vector store.
+type Retriever struct {
+	CallbacksHandler callbacks.Handler
+	store            vectorstores.VectorStore
+	translator       Translator
+	chain            *chains.LLMChain
+	content          string
+	attributes       []AttributeInfo
+	numDocuments     int
+	searchOptions    []vectorstores.Option
+}
+
+var _ schema.Retriever = Retriever{}
+
+// New creates a new self query retriever. The content describes the documents
+// of the store and the attributes their metadata the LLM may filter on. The
+// translator must match the vector store.
+func New(
+	llm llms.LanguageModel,
+	store vectorstores.VectorStore,
+	translator Translator,
+	content string,
+	attributes []AttributeInfo,
+	opts ...Option,
+) Retriever {
+	r := Retriever{
+		store:      store,
+		translator: translator,
+		chain: chains.NewLLMChain(
+			llm,
+			prompts.NewPromptTemplate(_defaultTemplate, []string{"content", "attributes", "question"}),
+		),
+		content:      content,
+		attributes:   attributes,
+		numDocuments: _defaultNumDocuments,
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// GetRelevantDocuments structures the question and searches the vector store
+// with the semantic query and the translated filter. If the LLM output is
+// invalid the original question is searched without a filter. Errors of the
+// LLM and the vector store are returned.
+func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	output, err := r.predict(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery, options := query, r.searchOptions
+	if structured, err := ParseOutput(output, r.attributes); err == nil {
+		searchQuery, options = r.searchArguments(query, structured)
+	}
+
+	docs, err := r.store.SimilaritySearch(ctx, searchQuery, r.numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	}
+
+	return docs, nil
+}
+
+// StructureQuery asks the LLM to structure the question and returns the
+// parsed and validated result.
+func (r Retriever) StructureQuery(ctx context.Context, query string) (StructuredQuery, error) {
+	output, err := r.predict(ctx, query)
+	if err != nil {
+		return StructuredQuery{}, err
+	}
+	return ParseOutput(output, r.attributes)
+}
+
+func (r Retriever) predict(ctx context.Context, query string) (string, error) {
+	return chains.Predict(ctx, r.chain, map[string]any{
+		"content":    r.content,
+		"attributes": formatAttributes(r.attributes),
+		"question":   query,
+	})
+}
+
+func (r Retriever) searchArguments(query string, structured StructuredQuery) (string, []vectorstores.Option) {
+	if structured.Query != "" {
+		query = structured.Query
+	}
+	if structured.Filter == nil {
+		return query, r.searchOptions
+	}
+
+	filter, err := r.translator.Translate(structured.Filter)
+	if err != nil {
+		return query, r.searchOptions
+	}
+
+	options := make([]vectorstores.Option, 0, len(r.searchOptions)+1)
+	options = append(options, r.searchOptions...)
+	options = append(options, vectorstores.WithFilters(filter))
+	return query, options
+}
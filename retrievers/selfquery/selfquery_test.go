@@ -0,0 +1,164 @@
+package selfquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+var testAttributes = []AttributeInfo{
+	{Name: "year", Type: TypeInteger, Description: "发布年份"},
+	{Name: "category", Type: TypeString, Description: "文件类别"},
+	{Name: "score", Type: TypeFloat, Description: "评分"},
+	{Name: "active", Type: TypeBoolean, Description: "是否有效"},
+}
+
+type testLanguageModel struct {
+	expResult string
+}
+
+func (l testLanguageModel) GeneratePrompt(_ context.Context, _ []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.LLMResult{
+		Generations: [][]*llms.Generation{{&llms.Generation{
+			Text: l.expResult,
+		}}},
+	}, nil
+}
+
+func (l testLanguageModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+// recordingStore records the arguments of the similarity search.
+type recordingStore struct {
+	query   string
+	filters any
+}
+
+func (s *recordingStore) AddDocuments(context.Context, []schema.Document, ...vectorstores.Option) error {
+	return nil
+}
+
+func (s *recordingStore) SimilaritySearch(
+	_ context.Context,
+	query string,
+	_ int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	s.query, s.filters = query, opts.Filters
+	return []schema.Document{{PageContent: query}}, nil
+}
+
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	output := "```json\n" + `{"query": "人事制度", "filter": {"operator": "AND", "arguments": [
+		{"comparator": "gt", "attribute": "year", "value": "2023"},
+		{"operator": "or", "arguments": [{"comparator": "in", "attribute": "category", "value": ["人事", 1]}]}
+	]}}` + "\n```"
+
+	structured, err := ParseOutput(output, testAttributes)
+	require.NoError(t, err)
+	assert.Equal(t, StructuredQuery{
+		Query: "人事制度",
+		Filter: Operation{Operator: And, Arguments: []Filter{
+			Comparison{Comparator: Gt, Attribute: "year", Value: int64(2023)},
+			Comparison{Comparator: In, Attribute: "category", Value: []any{"人事", "1"}},
+		}},
+	}, structured)
+
+	structured, err = ParseOutput(`{"query": "制度", "filter": null}`, testAttributes)
+	require.NoError(t, err)
+	assert.Nil(t, structured.Filter)
+
+	cases := map[string]error{
+		`没有 JSON`: ErrInvalidOutput,
+		`{"query": "制度", "filter": {"comparator": "eq", "attribute": "author", "value": "张三"}}`:     ErrUnknownAttribute,
+		`{"query": "制度", "filter": {"comparator": "gt", "attribute": "category", "value": "人事"}}`:   ErrInvalidComparator,
+		`{"query": "制度", "filter": {"comparator": "like", "attribute": "category", "value": "人事"}}`: ErrInvalidComparator,
+		`{"query": "制度", "filter": {"comparator": "eq", "attribute": "year", "value": 2023.5}}`:     ErrInvalidValue,
+		`{"query": "制度", "filter": {"comparator": "in", "attribute": "year", "value": 2023}}`:       ErrInvalidValue,
+		`{"query": "制度", "filter": {"operator": "xor", "arguments": []}}`:                           ErrInvalidOperator,
+		`{"query": "制度", "filter": {"operator": "not", "arguments": []}}`:                           ErrInvalidOperator,
+	}
+	for output, expected := range cases {
+		_, err := ParseOutput(output, testAttributes)
+		assert.ErrorIs(t, err, expected, output)
+	}
+}
+
+func TestTranslators(t *testing.T) {
+	t.Parallel()
+
+	filter := Operation{Operator: And, Arguments: []Filter{
+		Comparison{Comparator: Gte, Attribute: "year", Value: int64(2023)},
+		Operation{Operator: Not, Arguments: []Filter{
+			Comparison{Comparator: In, Attribute: "category", Value: []any{"财务", "行政"}},
+		}},
+	}}
+
+	pinecone, err := PineconeTranslator{}.Translate(filter)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"$and": []any{
+		map[string]any{"year": map[string]any{"$gte": int64(2023)}},
+		map[string]any{"category": map[string]any{"$nin": []any{"财务", "行政"}}},
+	}}, pinecone)
+
+	qdrant, err := QdrantTranslator{}.Translate(filter)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"must": []any{
+		map[string]any{"key": "year", "range": map[string]any{"gte": int64(2023)}},
+		map[string]any{"must_not": []any{
+			map[string]any{"key": "category", "match": map[string]any{"any": []any{"财务", "行政"}}},
+		}},
+	}}, qdrant)
+
+	milvus, err := MilvusTranslator{}.Translate(filter)
+	require.NoError(t, err)
+	assert.Equal(t, `(year >= 2023) and (not (category in ["财务", "行政"]))`, milvus)
+
+	weaviate, err := WeaviateTranslator{}.Translate(filter)
+	require.NoError(t, err)
+	where, ok := weaviate.(*filters.WhereBuilder)
+	require.True(t, ok)
+	assert.Equal(t, "And", string(where.Build().Operator))
+	require.Len(t, where.Build().Operands, 2)
+	assert.Equal(t, "Not", string(where.Build().Operands[1].Operator))
+}
+
+func TestRetriever(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store := &recordingStore{}
+	llm := testLanguageModel{expResult: `{"query": "人事制度", "filter": {"comparator": "gte", "attribute": "year", "value": 2023}}`}
+	r := New(llm, store, MilvusTranslator{}, "公司制度文件", testAttributes,
+		WithSearchOptions(vectorstores.WithNameSpace("hr")))
+
+	docs, err := r.GetRelevantDocuments(ctx, "2023年以后的人事制度文件")
+	require.NoError(t, err)
+	assert.Equal(t, "人事制度", docs[0].PageContent)
+	assert.Equal(t, "year >= 2023", store.filters)
+
+	structured, err := r.StructureQuery(ctx, "2023年以后的人事制度文件")
+	require.NoError(t, err)
+	assert.Equal(t, Comparison{Comparator: Gte, Attribute: "year", Value: int64(2023)}, structured.Filter)
+
+	llm = testLanguageModel{expResult: `{"query": "人事制度", "filter": {"comparator": "eq", "attribute": "author", "value": "张三"}}`}
+	store = &recordingStore{}
+	_, err = New(llm, store, MilvusTranslator{}, "公司制度文件", testAttributes).
+		GetRelevantDocuments(ctx, "张三写的人事制度")
+	require.NoError(t, err)
+	assert.Equal(t, "张三写的人事制度", store.query)
+	assert.Nil(t, store.filters)
+}
@@ -0,0 +1,267 @@
+package selfquery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownAttribute is returned when a filter uses an attribute that is
+	// not declared.
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	// ErrInvalidComparator is returned when a filter uses an unknown comparator
+	// or a comparator not allowed for the type of the attribute.
+	ErrInvalidComparator = errors.New("invalid comparator")
+	// ErrInvalidOperator is returned when a filter uses an unknown operator or
+	// the wrong number of arguments for an operator.
+	ErrInvalidOperator = errors.New("invalid operator")
+	// ErrInvalidValue is returned when a value of a filter does not match the
+	// type of the attribute.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// FieldType is the type of a metadata attribute.
+type FieldType string
+
+// The types of metadata attributes.
+const (
+	TypeString  FieldType = "string"
+	TypeInteger FieldType = "integer"
+	TypeFloat   FieldType = "float"
+	TypeBoolean FieldType = "boolean"
+)
+
+// AttributeInfo describes a metadata attribute the LLM can filter on.
+type AttributeInfo struct {
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description"`
+}
+
+// Comparator is the comparison of a Comparison.
+type Comparator string
+
+// The comparators of comparisons.
+const (
+	Eq  Comparator = "eq"
+	Ne  Comparator = "ne"
+	Gt  Comparator = "gt"
+	Gte Comparator = "gte"
+	Lt  Comparator = "lt"
+	Lte Comparator = "lte"
+	In  Comparator = "in"
+	Nin Comparator = "nin"
+)
+
+// Operator is the logical operator of an Operation.
+type Operator string
+
+// The operators of operations.
+const (
+	And Operator = "and"
+	Or  Operator = "or"
+	Not Operator = "not"
+)
+
+// Filter is a metadata filter, either a Comparison or an Operation.
+type Filter interface {
+	isFilter()
+}
+
+// Comparison compares a metadata attribute with a value. The value of the In
+// and Nin comparators is a []any.
+type Comparison struct {
+	Comparator Comparator
+	Attribute  string
+	Value      any
+}
+
+// Operation combines filters with a logical operator. Not has exactly one argument.
+type Operation struct {
+	Operator  Operator
+	Arguments []Filter
+}
+
+func (Comparison) isFilter() {}
+func (Operation) isFilter()  {}
+
+// StructuredQuery is a question parsed into a semantic query and a metadata
+// filter. Filter is nil if the question does not restrict the metadata.
+type StructuredQuery struct {
+	Query  string
+	Filter Filter
+}
+
+// Validate checks the filter against the attributes and returns it with the
+// values converted to the attribute types: int64 for integers, float64 for
+// floats, bool for booleans and string for strings.
+func Validate(filter Filter, attributes []AttributeInfo) (Filter, error) {
+	types := make(map[string]FieldType, len(attributes))
+	for _, attribute := range attributes {
+		types[attribute.Name] = attribute.Type
+	}
+	return validate(filter, types)
+}
+
+func validate(filter Filter, types map[string]FieldType) (Filter, error) {
+	switch f := filter.(type) {
+	case nil:
+		return nil, nil
+	case Comparison:
+		return validateComparison(f, types)
+	case Operation:
+		return validateOperation(f, types)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrInvalidOperator, filter)
+	}
+}
+
+func validateOperation(o Operation, types map[string]FieldType) (Filter, error) {
+	switch o.Operator {
+	case And, Or:
+		if len(o.Arguments) == 0 {
+			return nil, fmt.Errorf("%w: %s without arguments", ErrInvalidOperator, o.Operator)
+		}
+	case Not:
+		if len(o.Arguments) != 1 {
+			return nil, fmt.Errorf("%w: not with %d arguments", ErrInvalidOperator, len(o.Arguments))
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidOperator, o.Operator)
+	}
+
+	arguments := make([]Filter, 0, len(o.Arguments))
+	for _, argument := range o.Arguments {
+		validated, err := validate(argument, types)
+		if err != nil {
+			return nil, err
+		}
+		if validated == nil {
+			return nil, fmt.Errorf("%w: empty argument of %s", ErrInvalidOperator, o.Operator)
+		}
+		arguments = append(arguments, validated)
+	}
+
+	if o.Operator != Not && len(arguments) == 1 {
+		return arguments[0], nil
+	}
+	return Operation{Operator: o.Operator, Arguments: arguments}, nil
+}
+
+func validateComparison(c Comparison, types map[string]FieldType) (Filter, error) {
+	fieldType, ok := types[c.Attribute]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAttribute, c.Attribute)
+	}
+
+	switch c.Comparator {
+	case Eq, Ne:
+	case Gt, Gte, Lt, Lte:
+		if fieldType != TypeInteger && fieldType != TypeFloat {
+			return nil, fmt.Errorf("%w: %s on %s attribute %q", ErrInvalidComparator, c.Comparator, fieldType, c.Attribute)
+		}
+	case In, Nin:
+		values, ok := c.Value.([]any)
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("%w: %s needs a non empty list", ErrInvalidValue, c.Comparator)
+		}
+		converted := make([]any, 0, len(values))
+		for _, value := range values {
+			v, err := convertValue(value, fieldType)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", c.Attribute, err)
+			}
+			converted = append(converted, v)
+		}
+		return Comparison{Comparator: c.Comparator, Attribute: c.Attribute, Value: converted}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidComparator, c.Comparator)
+	}
+
+	value, err := convertValue(c.Value, fieldType)
+	if err != nil {
+		return nil, fmt.Errorf("attribute %q: %w", c.Attribute, err)
+	}
+	return Comparison{Comparator: c.Comparator, Attribute: c.Attribute, Value: value}, nil
+}
+
+// convertValue converts a value parsed from JSON to the type of an attribute.
+// Numbers and booleans written as strings are accepted.
+func convertValue(value any, fieldType FieldType) (any, error) { //nolint:cyclop
+	switch fieldType {
+	case TypeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case TypeInteger:
+		switch v := value.(type) {
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), nil
+			}
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case string:
+			if i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return i, nil
+			}
+		}
+	case TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f, nil
+			}
+		}
+	case TypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %v is not a %s", ErrInvalidValue, value, fieldType)
+}
+
+// negate returns the filter matching exactly the documents the filter does not
+// match, without using the Not operator.
+func negate(filter Filter) Filter {
+	switch f := filter.(type) {
+	case Comparison:
+		negated := map[Comparator]Comparator{Eq: Ne, Ne: Eq, Gt: Lte, Gte: Lt, Lt: Gte, Lte: Gt, In: Nin, Nin: In}
+		return Comparison{Comparator: negated[f.Comparator], Attribute: f.Attribute, Value: f.Value}
+	case Operation:
+		switch f.Operator {
+		case Not:
+			return f.Arguments[0]
+		case And, Or:
+			operator := And
+			if f.Operator == And {
+				operator = Or
+			}
+			arguments := make([]Filter, 0, len(f.Arguments))
+			for _, argument := range f.Arguments {
+				arguments = append(arguments, negate(argument))
+			}
+			return Operation{Operator: operator, Arguments: arguments}
+		}
+	}
+	return filter
+}
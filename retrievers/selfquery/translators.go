@@ -0,0 +1,219 @@
+package selfquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+// Translator converts a validated filter into the filter format of a vector
+// store, which is passed to it with vectorstores.WithFilters.
+type Translator interface {
+	Translate(filter Filter) (any, error)
+}
+
+// PineconeTranslator translates filters into pinecone metadata filters.
+// See https://docs.pinecone.io/docs/metadata-filtering
+type PineconeTranslator struct{}
+
+// QdrantTranslator translates filters into qdrant payload filters.
+// See https://qdrant.tech/documentation/concepts/filtering/
+type QdrantTranslator struct{}
+
+// MilvusTranslator translates filters into milvus boolean expressions.
+// See https://milvus.io/docs/boolean.md
+type MilvusTranslator struct{}
+
+// WeaviateTranslator translates filters into weaviate where filters.
+// Attributes are used as property paths.
+type WeaviateTranslator struct{}
+
+var (
+	_ Translator = PineconeTranslator{}
+	_ Translator = QdrantTranslator{}
+	_ Translator = MilvusTranslator{}
+	_ Translator = WeaviateTranslator{}
+)
+
+// Translate returns the filter as a pinecone filter map. Pinecone has no not
+// operator, so negations are pushed down to the comparisons.
+func (t PineconeTranslator) Translate(filter Filter) (any, error) {
+	switch f := filter.(type) {
+	case Comparison:
+		return map[string]any{f.Attribute: map[string]any{"$" + string(f.Comparator): f.Value}}, nil
+	case Operation:
+		if f.Operator == Not {
+			return t.Translate(negate(f.Arguments[0]))
+		}
+		arguments, err := translateArguments(t, f.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"$" + string(f.Operator): arguments}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrInvalidOperator, filter)
+}
+
+// Translate returns the filter as a qdrant filter map.
+func (t QdrantTranslator) Translate(filter Filter) (any, error) {
+	switch f := filter.(type) {
+	case Comparison:
+		return t.translateComparison(f)
+	case Operation:
+		arguments, err := translateArguments(t, f.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		clause := map[Operator]string{And: "must", Or: "should", Not: "must_not"}[f.Operator]
+		return map[string]any{clause: arguments}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrInvalidOperator, filter)
+}
+
+func (t QdrantTranslator) translateComparison(c Comparison) (any, error) {
+	switch c.Comparator {
+	case Eq:
+		return map[string]any{"key": c.Attribute, "match": map[string]any{"value": c.Value}}, nil
+	case Ne:
+		return map[string]any{"must_not": []any{
+			map[string]any{"key": c.Attribute, "match": map[string]any{"value": c.Value}},
+		}}, nil
+	case In:
+		return map[string]any{"key": c.Attribute, "match": map[string]any{"any": c.Value}}, nil
+	case Nin:
+		return map[string]any{"key": c.Attribute, "match": map[string]any{"except": c.Value}}, nil
+	case Gt, Gte, Lt, Lte:
+		return map[string]any{"key": c.Attribute, "range": map[string]any{string(c.Comparator): c.Value}}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrInvalidComparator, c.Comparator)
+}
+
+// Translate returns the filter as a milvus expression string.
+func (t MilvusTranslator) Translate(filter Filter) (any, error) {
+	return t.translate(filter)
+}
+
+func (t MilvusTranslator) translate(filter Filter) (string, error) {
+	switch f := filter.(type) {
+	case Comparison:
+		operator := map[Comparator]string{
+			Eq: "==", Ne: "!=", Gt: ">", Gte: ">=", Lt: "<", Lte: "<=", In: "in", Nin: "not in",
+		}[f.Comparator]
+		if operator == "" {
+			return "", fmt.Errorf("%w: %q", ErrInvalidComparator, f.Comparator)
+		}
+		return fmt.Sprintf("%s %s %s", f.Attribute, operator, milvusValue(f.Value)), nil
+	case Operation:
+		arguments := make([]string, 0, len(f.Arguments))
+		for _, argument := range f.Arguments {
+			expression, err := t.translate(argument)
+			if err != nil {
+				return "", err
+			}
+			arguments = append(arguments, "("+expression+")")
+		}
+		if f.Operator == Not {
+			return "not " + arguments[0], nil
+		}
+		return strings.Join(arguments, " "+string(f.Operator)+" "), nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrInvalidOperator, filter)
+}
+
+func milvusValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, milvusValue(item))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Translate returns the filter as a *filters.WhereBuilder. Weaviate has no in
+// operator, so in and nin are expanded into equality comparisons.
+func (t WeaviateTranslator) Translate(filter Filter) (any, error) {
+	return t.translate(filter)
+}
+
+func (t WeaviateTranslator) translate(filter Filter) (*filters.WhereBuilder, error) {
+	switch f := filter.(type) {
+	case Comparison:
+		return t.translateComparison(f)
+	case Operation:
+		operands := make([]*filters.WhereBuilder, 0, len(f.Arguments))
+		for _, argument := range f.Arguments {
+			operand, err := t.translate(argument)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, operand)
+		}
+		operator := map[Operator]filters.WhereOperator{And: filters.And, Or: filters.Or, Not: filters.Not}[f.Operator]
+		return filters.Where().WithOperator(operator).WithOperands(operands), nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrInvalidOperator, filter)
+}
+
+func (t WeaviateTranslator) translateComparison(c Comparison) (*filters.WhereBuilder, error) {
+	if c.Comparator == In || c.Comparator == Nin {
+		comparator, operator := Eq, filters.Or
+		if c.Comparator == Nin {
+			comparator, operator = Ne, filters.And
+		}
+		values, _ := c.Value.([]any)
+		operands := make([]*filters.WhereBuilder, 0, len(values))
+		for _, value := range values {
+			operand, err := t.translateComparison(Comparison{Comparator: comparator, Attribute: c.Attribute, Value: value})
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, operand)
+		}
+		return filters.Where().WithOperator(operator).WithOperands(operands), nil
+	}
+
+	operator, ok := map[Comparator]filters.WhereOperator{
+		Eq:  filters.Equal,
+		Ne:  filters.NotEqual,
+		Gt:  filters.GreaterThan,
+		Gte: filters.GreaterThanEqual,
+		Lt:  filters.LessThan,
+		Lte: filters.LessThanEqual,
+	}[c.Comparator]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidComparator, c.Comparator)
+	}
+
+	where := filters.Where().WithPath([]string{c.Attribute}).WithOperator(operator)
+	switch v := c.Value.(type) {
+	case string:
+		return where.WithValueText(v), nil
+	case int64:
+		return where.WithValueInt(v), nil
+	case float64:
+		return where.WithValueNumber(v), nil
+	case bool:
+		return where.WithValueBoolean(v), nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrInvalidValue, c.Value)
+}
+
+func translateArguments(t Translator, filters []Filter) ([]any, error) {
+	arguments := make([]any, 0, len(filters))
+	for _, filter := range filters {
+		argument, err := t.Translate(filter)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments, nil
+}
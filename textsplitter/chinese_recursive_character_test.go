@@ -0,0 +1,59 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChineseRecursiveCharacterSplitter(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewChineseRecursiveCharacter()
+	splitter.ChunkSize = 20
+	splitter.ChunkOverlap = 0
+
+	text := "员工每年享有五天带薪年假。他说：“年假可以累计吗？”不可以！" +
+		"年假需要提前三天申请，经部门经理审批后生效。\n\n" +
+		"Remote work needs the VPN client. Install it first, then log in!"
+	chunks, err := splitter.SplitText(text)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"员工每年享有五天带薪年假。",
+		"他说：“年假可以累计吗？”不可以！",
+		"年假需要提前三天申请，",
+		"经部门经理审批后生效。",
+		"Remote work needs",
+		"the VPN client.",
+		"Install it first,",
+		"then log in!",
+	}, chunks)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 20, chunk)
+	}
+	assert.Equal(t, strings.ReplaceAll(strings.ReplaceAll(text, "\n", ""), " ", ""),
+		strings.ReplaceAll(strings.Join(chunks, ""), " ", ""))
+}
+
+func TestChineseRecursiveCharacterSplitterOverlap(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewChineseRecursiveCharacter()
+	splitter.ChunkSize = 12
+	splitter.ChunkOverlap = 6
+
+	chunks, err := splitter.SplitText("第一句话。第二句话。第三句话。第四句话。")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"第一句话。第二句话。", "第二句话。第三句话。", "第三句话。第四句话。"}, chunks)
+
+	splitter.LenFunc = func(text string) int { return len(strings.Fields(text)) }
+	splitter.ChunkSize = 3
+	splitter.ChunkOverlap = 0
+	chunks, err = splitter.SplitText("one two three four five")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one two three", "four five"}, chunks)
+}
@@ -0,0 +1,389 @@
+package textsplitter
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrUnsupportedLanguage is returned when a Code splitter has a language
+// without separators.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// Language is a programming language supported by the Code splitter.
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguageJava       Language = "java"
+	LanguagePython     Language = "python"
+	LanguageTypeScript Language = "typescript"
+	LanguageJavaScript Language = "javascript"
+)
+
+// _languageSeparators are the separators of the languages, from class and
+// function boundaries over blocks to lines.
+var _languageSeparators = map[Language][]string{ //nolint:gochecknoglobals
+	LanguageGo: {
+		"\nfunc ", "\nvar ", "\nconst ", "\ntype ",
+		"\n\tif ", "\n\tfor ", "\n\tswitch ", "\n\tcase ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageJava: {
+		"\nclass ", "\npublic ", "\nprotected ", "\nprivate ", "\nstatic ",
+		"\n    public ", "\n    protected ", "\n    private ", "\n    static ",
+		"\n\tpublic ", "\n\tprotected ", "\n\tprivate ", "\n\tstatic ",
+		"\n        if ", "\n        for ", "\n        while ", "\n        switch ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguagePython: {
+		"\nclass ", "\ndef ", "\nasync def ",
+		"\n    def ", "\n    async def ", "\n\tdef ", "\n\tasync def ",
+		"\n        if ", "\n        for ", "\n        while ", "\n        with ", "\n        try:",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageTypeScript: {
+		"\nexport ", "\nenum ", "\ninterface ", "\nnamespace ", "\ntype ", "\nclass ", "\nfunction ",
+		"\nconst ", "\nlet ", "\nvar ",
+		"\n  if ", "\n  for ", "\n  while ", "\n  switch ",
+		"\n\n", "\n", " ", "",
+	},
+	LanguageJavaScript: {
+		"\nexport ", "\nclass ", "\nfunction ", "\nconst ", "\nlet ", "\nvar ",
+		"\n  if ", "\n  for ", "\n  while ", "\n  switch ",
+		"\n\n", "\n", " ", "",
+	},
+}
+
+// _languageSymbols match the names of the classes, functions and types
+// declared in a chunk.
+var _languageSymbols = map[Language][]*regexp.Regexp{ //nolint:gochecknoglobals
+	LanguageGo: {
+		regexp.MustCompile(`(?m)^func\s+(?:\(\s*\w*\s*\*?(\w+)[^)]*\)\s*)?(\w+)`),
+		regexp.MustCompile(`(?m)^type\s+(\w+)`),
+	},
+	LanguageJava: {
+		regexp.MustCompile(`(?m)^[ \t]*(?:(?:public|protected|private|static|final|abstract|sealed)\s+)*(?:class|interface|enum|record)\s+(\w+)`), //nolint:lll
+		regexp.MustCompile(`(?m)^[ \t]*(?:(?:public|protected|private|static|final|abstract|synchronized)\s+)+[\w<>\[\], ?]+\s+(\w+)\s*\(`),       //nolint:lll
+	},
+	LanguagePython: {
+		regexp.MustCompile(`(?m)^[ \t]*(?:async[ \t]+)?(?:def|class)[ \t]+(\w+)`),
+	},
+	LanguageTypeScript: {
+		regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\*?|class|interface|enum|type|namespace)\s+(\w+)`), //nolint:lll
+		regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:const|let|var)\s+(\w+)`),
+	},
+	LanguageJavaScript: {
+		regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:default\s+)?(?:async\s+)?(?:function\*?|class)\s+(\w+)`),
+		regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:const|let|var)\s+(\w+)`),
+	},
+}
+
+// _languageExtensions are the file extensions of the languages.
+var _languageExtensions = map[string]Language{ //nolint:gochecknoglobals
+	".go":   LanguageGo,
+	".java": LanguageJava,
+	".py":   LanguagePython,
+	".ts":   LanguageTypeScript,
+	".tsx":  LanguageTypeScript,
+	".mts":  LanguageTypeScript,
+	".js":   LanguageJavaScript,
+	".jsx":  LanguageJavaScript,
+	".mjs":  LanguageJavaScript,
+	".cjs":  LanguageJavaScript,
+}
+
+// LanguageSeparators returns the separators the Code splitter uses for the
+// language, or nil if the language is not supported.
+func LanguageSeparators(language Language) []string {
+	return append([]string(nil), _languageSeparators[language]...)
+}
+
+// LanguageFromPath returns the language of a source file by its extension.
+func LanguageFromPath(path string) (Language, bool) {
+	language, ok := _languageExtensions[strings.ToLower(filepath.Ext(path))]
+	return language, ok
+}
+
+// Code is a text splitter for source code. It splits on the boundaries of
+// classes and functions first, then on blocks and lines. Go code is parsed
+// with go/parser, so chunks hold whole declarations unless a declaration is
+// longer than the chunk size. The metadata of every chunk holds the language,
+// the names of the declared symbols and the range of lines.
+type Code struct {
+	Language     Language
+	ChunkSize    int
+	ChunkOverlap int
+	// LenFunc measures the length of texts for ChunkSize and ChunkOverlap.
+	// Defaults to the number of bytes.
+	LenFunc func(string) int
+}
+
+var _ MetadataTextSplitter = Code{}
+
+// NewCode creates a new code splitter for the language. The chunk size is set
+// to 4000 and chunk overlap is set to 200. Whole Go declarations are never
+// overlapped.
+func NewCode(language Language) Code {
+	return Code{
+		Language:     language,
+		ChunkSize:    _defaultChunkSize,
+		ChunkOverlap: _defaultChunkOverlap,
+	}
+}
+
+// SplitText splits a text into multiple text.
+func (s Code) SplitText(text string) ([]string, error) {
+	chunks, _, err := s.SplitTextWithMetadata(text)
+	return chunks, err
+}
+
+// SplitTextWithMetadata splits a text and returns the language, the symbols
+// and the start and end line of every chunk as metadata with the keys
+// "language", "symbol", "start_line" and "end_line".
+func (s Code) SplitTextWithMetadata(text string) ([]string, []map[string]any, error) {
+	if _, ok := _languageSeparators[s.Language]; !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedLanguage, s.Language)
+	}
+
+	var units []codeUnit
+	if s.Language == LanguageGo {
+		units = goUnits(text)
+	}
+	if units == nil {
+		units = []codeUnit{{text: text}}
+	}
+
+	chunks := make([]string, 0)
+	metadatas := make([]map[string]any, 0)
+	line := 1
+	for _, group := range s.mergeUnits(units) {
+		parts := []string{group.text}
+		if s.length(strings.TrimSpace(group.text)) > s.ChunkSize {
+			parts = s.splitText(group.text)
+		}
+
+		offset := 0
+		for _, part := range parts {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			part = strings.TrimSpace(part)
+			idx := strings.Index(group.text[offset:], part)
+			if idx < 0 {
+				idx = 0
+			}
+			start := offset + idx
+			startLine := line + strings.Count(group.text[:start], "\n")
+			endLine := startLine + strings.Count(part, "\n")
+			offset = start + 1
+
+			symbol := group.symbol
+			if symbol == "" {
+				symbol = s.findSymbol(part)
+			}
+
+			chunks = append(chunks, part)
+			metadatas = append(metadatas, map[string]any{
+				"language":   string(s.Language),
+				"symbol":     symbol,
+				"start_line": startLine,
+				"end_line":   endLine,
+			})
+		}
+		line += strings.Count(group.text, "\n")
+	}
+
+	return chunks, metadatas, nil
+}
+
+// codeUnit is a part of a source file, like a declaration with its comments.
+type codeUnit struct {
+	text   string
+	symbol string
+}
+
+// mergeUnits merges adjacent units as long as they fit in the chunk size.
+func (s Code) mergeUnits(units []codeUnit) []codeUnit {
+	merged := make([]codeUnit, 0, len(units))
+	var (
+		current codeUnit
+		symbols []string
+	)
+	flush := func() {
+		if current.text != "" {
+			current.symbol = strings.Join(symbols, ", ")
+			merged = append(merged, current)
+		}
+		current, symbols = codeUnit{}, nil
+	}
+
+	for _, unit := range units {
+		if current.text != "" && s.length(strings.TrimSpace(current.text+unit.text)) > s.ChunkSize {
+			flush()
+		}
+		current.text += unit.text
+		if unit.symbol != "" {
+			symbols = append(symbols, unit.symbol)
+		}
+	}
+	flush()
+
+	return merged
+}
+
+// splitText splits the text recursively by the separators of the language,
+// keeping every separator at the start of the split following it.
+func (s Code) splitText(text string) []string {
+	return RecursiveCharacter{
+		Separators:   _languageSeparators[s.Language],
+		ChunkSize:    s.ChunkSize,
+		ChunkOverlap: s.ChunkOverlap,
+		LenFunc:      s.LenFunc,
+		SplitFunc:    splitBeforeSeparator,
+	}.splitText(text, _languageSeparators[s.Language])
+}
+
+// findSymbol returns the name of the first class, function or type declared
+// in the chunk.
+func (s Code) findSymbol(chunk string) string {
+	best, bestIdx := "", -1
+	for _, re := range _languageSymbols[s.Language] {
+		match := re.FindStringSubmatchIndex(chunk)
+		if match == nil || (bestIdx >= 0 && match[0] >= bestIdx) {
+			continue
+		}
+
+		names := make([]string, 0, 2) //nolint:gomnd
+		for i := 2; i+1 < len(match); i += 2 {
+			if match[i] >= 0 {
+				names = append(names, chunk[match[i]:match[i+1]])
+			}
+		}
+		best, bestIdx = strings.Join(names, "."), match[0]
+	}
+	return best
+}
+
+func (s Code) length(text string) int {
+	if s.LenFunc == nil {
+		return stringLen(text)
+	}
+	return s.LenFunc(text)
+}
+
+// splitBeforeSeparator splits the text before every separator, so joining the
+// splits returns the text.
+func splitBeforeSeparator(text, separator string) []string {
+	if separator == "" {
+		return strings.Split(text, "")
+	}
+
+	splits := make([]string, 0)
+	for text != "" {
+		idx := strings.Index(text[1:], separator)
+		if idx < 0 {
+			break
+		}
+		splits = append(splits, text[:idx+1])
+		text = text[idx+1:]
+	}
+	if text != "" {
+		splits = append(splits, text)
+	}
+	return splits
+}
+
+// goUnits splits Go source into the package clause with the imports and the
+// top level declarations. Comments belong to the declaration following them.
+// It returns nil if the source can't be parsed.
+func goUnits(src string) []codeUnit {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+
+	units := []codeUnit{{symbol: "package " + file.Name.Name}}
+	start := 0
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+
+		pos := decl.Pos()
+		if doc := declDoc(decl); doc != nil {
+			pos = doc.Pos()
+		}
+		offset := lineStart(src, fset.Position(pos).Offset)
+		units[len(units)-1].text = src[start:offset]
+		units = append(units, codeUnit{symbol: goSymbol(decl)})
+		start = offset
+	}
+	units[len(units)-1].text = src[start:]
+
+	return units
+}
+
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	default:
+		return nil
+	}
+}
+
+// goSymbol returns the names declared by a declaration. Methods are named by
+// their receiver type and name, like "Client.Do".
+func goSymbol(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			return receiverName(d.Recv.List[0].Type) + "." + d.Name.Name
+		}
+		return d.Name.Name
+	case *ast.GenDecl:
+		names := make([]string, 0, len(d.Specs))
+		for _, spec := range d.Specs {
+			switch sp := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, sp.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range sp.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+		return strings.Join(names, ", ")
+	default:
+		return ""
+	}
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	default:
+		return ""
+	}
+}
+
+// lineStart returns the offset of the start of the line holding the offset.
+func lineStart(src string, offset int) int {
+	return strings.LastIndex(src[:offset], "\n") + 1
+}
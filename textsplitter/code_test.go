@@ -0,0 +1,187 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _goSource = `package shapes
+
+import "math"
+
+// Shape is a geometric shape.
+type Shape interface {
+	Area() float64
+}
+
+// Circle is a circle.
+type Circle struct {
+	Radius float64
+}
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+const (
+	Small = 1
+	Large = 10
+)
+
+func NewCircle(radius float64) *Circle {
+	if radius < 0 {
+		radius = -radius
+	}
+	return &Circle{Radius: radius}
+}
+`
+
+func TestCodeSplitterGo(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewCode(LanguageGo)
+	splitter.ChunkSize = 120
+
+	chunks, metadatas, err := splitter.SplitTextWithMetadata(_goSource)
+	require.NoError(t, err)
+	require.Len(t, chunks, 5)
+
+	assert.Equal(t, "package shapes\n\nimport \"math\"\n\n// Shape is a geometric shape.\ntype Shape interface {\n\tArea() float64\n}", chunks[0]) //nolint:lll
+	assert.Equal(t, map[string]any{"language": "go", "symbol": "package shapes, Shape", "start_line": 1, "end_line": 8}, metadatas[0])             //nolint:lll
+
+	assert.Equal(t, "Circle", metadatas[1]["symbol"])
+	assert.True(t, strings.HasPrefix(chunks[1], "// Circle is a circle."))
+
+	assert.Equal(t, "Circle.Area", metadatas[2]["symbol"])
+	assert.Equal(t, 15, metadatas[2]["start_line"])
+	assert.Equal(t, 18, metadatas[2]["end_line"])
+
+	assert.Equal(t, "Small, Large", metadatas[3]["symbol"])
+
+	assert.Equal(t, "NewCircle", metadatas[4]["symbol"])
+	assert.Equal(t, 25, metadatas[4]["start_line"])
+	assert.Equal(t, 30, metadatas[4]["end_line"])
+	assert.True(t, strings.HasSuffix(chunks[4], "return &Circle{Radius: radius}\n}"))
+
+	lines := strings.Split(_goSource, "\n")
+	for i, chunk := range chunks {
+		start, end := metadatas[i]["start_line"].(int), metadatas[i]["end_line"].(int)
+		assert.Equal(t, chunk, strings.TrimSpace(strings.Join(lines[start-1:end], "\n")))
+	}
+}
+
+func TestCodeSplitterGoLongDeclaration(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewCode(LanguageGo)
+	splitter.ChunkSize = 60
+	splitter.ChunkOverlap = 0
+
+	chunks, metadatas, err := splitter.SplitTextWithMetadata(_goSource)
+	require.NoError(t, err)
+
+	lines := strings.Split(_goSource, "\n")
+	newCircle := 0
+	for i, chunk := range chunks {
+		start, end := metadatas[i]["start_line"].(int), metadatas[i]["end_line"].(int)
+		assert.Contains(t, strings.Join(lines[start-1:end], "\n"), chunk)
+		if metadatas[i]["symbol"] == "NewCircle" {
+			newCircle++
+		}
+	}
+	assert.Equal(t, 3, newCircle)
+	assert.Contains(t, chunks, "if radius < 0 {\n\t\tradius = -radius\n\t}")
+	assert.Contains(t, chunks, "return &Circle{Radius: radius}\n}")
+}
+
+func TestCodeSplitterGoInvalid(t *testing.T) {
+	t.Parallel()
+
+	// Code that doesn't parse falls back to the separators.
+	splitter := NewCode(LanguageGo)
+	splitter.ChunkSize = 30
+	splitter.ChunkOverlap = 0
+
+	chunks, metadatas, err := splitter.SplitTextWithMetadata("func a() {\n\treturn\n}\nfunc (s *S) b() {\n\treturn\n}")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"func a() {\n\treturn\n}", "func (s *S) b() {\n\treturn\n}"}, chunks)
+	assert.Equal(t, "a", metadatas[0]["symbol"])
+	assert.Equal(t, "S.b", metadatas[1]["symbol"])
+	assert.Equal(t, 4, metadatas[1]["start_line"])
+}
+
+func TestCodeSplitterPython(t *testing.T) {
+	t.Parallel()
+
+	source := `import os
+
+
+class Greeter:
+    def __init__(self, name):
+        self.name = name
+
+    def greet(self):
+        return "Hello " + self.name
+
+
+async def main():
+    print(Greeter(os.getenv("USER")).greet())
+`
+	splitter := NewCode(LanguagePython)
+	splitter.ChunkSize = 100
+	splitter.ChunkOverlap = 0
+
+	chunks, metadatas, err := splitter.SplitTextWithMetadata(source)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"import os",
+		"class Greeter:\n    def __init__(self, name):\n        self.name = name",
+		"def greet(self):\n        return \"Hello \" + self.name",
+		"async def main():\n    print(Greeter(os.getenv(\"USER\")).greet())",
+	}, chunks)
+	assert.Equal(t, []any{"", "Greeter", "greet", "main"}, []any{
+		metadatas[0]["symbol"], metadatas[1]["symbol"], metadatas[2]["symbol"], metadatas[3]["symbol"],
+	})
+	assert.Equal(t, 8, metadatas[2]["start_line"])
+	assert.Equal(t, 12, metadatas[3]["start_line"])
+}
+
+func TestCodeSplitterSymbols(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		language Language
+		source   string
+		symbol   string
+	}{
+		{LanguageJava, "package a;\n\npublic final class OrderService {\n}", "OrderService"},
+		{LanguageJava, "    private static List<String> loadNames(int n) {", "loadNames"},
+		{LanguageTypeScript, "export default async function fetchUser(id: string) {}", "fetchUser"},
+		{LanguageTypeScript, "export interface User {\n  id: string\n}", "User"},
+		{LanguageJavaScript, "const handler = async (req) => {}", "handler"},
+	}
+	for _, c := range cases {
+		_, metadatas, err := NewCode(c.language).SplitTextWithMetadata(c.source)
+		require.NoError(t, err)
+		assert.Equal(t, c.symbol, metadatas[0]["symbol"], c.source)
+		assert.Equal(t, string(c.language), metadatas[0]["language"])
+	}
+}
+
+func TestCodeSplitterUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCode("cobol").SplitText("DISPLAY 'HELLO'.")
+	require.ErrorIs(t, err, ErrUnsupportedLanguage)
+
+	language, ok := LanguageFromPath("src/App.TSX")
+	assert.True(t, ok)
+	assert.Equal(t, LanguageTypeScript, language)
+	_, ok = LanguageFromPath("README.md")
+	assert.False(t, ok)
+	assert.Nil(t, LanguageSeparators("cobol"))
+}
@@ -9,6 +9,17 @@ The main components of this package are:
 - TextSplitter interface: a common interface for splitting texts into smaller chunks.
 - RecursiveCharacter: a text splitter that recursively splits texts by different characters (separators)
 combined with chunk size and overlap settings.
+- NewChineseRecursiveCharacter: a RecursiveCharacter preset for Chinese and mixed texts that splits on CJK
+punctuation, keeps punctuation attached to its sentence and measures lengths in runes.
+- MarkdownHeader: a text splitter that splits markdown on headers, keeps code blocks and tables
+intact and returns the header path of every chunk as metadata.
+- HTMLHeader: a text splitter that converts html to markdown and splits it like MarkdownHeader, returning
+the h1 to h3 path of every chunk as metadata.
+- Semantic: a text splitter that embeds sentences and places chunk boundaries where the distance between
+adjacent sentences is above a percentile, standard deviation or interquartile threshold.
+- Code: a text splitter for Go, Java, Python, TypeScript and JavaScript source that splits on declarations
+before blocks and lines, parses Go with go/parser and returns the language, symbols and lines of every chunk.
+- MetadataTextSplitter interface: a text splitter that also returns metadata for every chunk.
 - Helper functions: utility functions for creating documents out of split texts and rejoining them if necessary.
 
 Using the TextSplitter interface, developers can implement custom
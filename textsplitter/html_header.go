@@ -0,0 +1,55 @@
+package textsplitter
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tmc/langchaingo/internal/htmlmd"
+)
+
+// HTMLHeader is a text splitter for html. It converts the html to markdown and
+// splits it like MarkdownHeader, returning the h1 to h3 headers of the section
+// of every chunk as metadata with the keys "h1", "h2" and "h3".
+type HTMLHeader struct {
+	ChunkSize    int
+	ChunkOverlap int
+	// MaxHeaderLevel is the deepest header level that starts a new section.
+	MaxHeaderLevel int
+	// Selector is a CSS selector for the content to split, like "main". The
+	// whole body is split if it is empty or nothing matches.
+	Selector string
+}
+
+var _ MetadataTextSplitter = HTMLHeader{}
+
+// NewHTMLHeader creates a new html header splitter with default values. By
+// default sections start at headers of level 1 to 3, the chunk size is set to
+// 4000 and the chunk overlap is set to 200.
+func NewHTMLHeader() HTMLHeader {
+	return HTMLHeader{
+		ChunkSize:      _defaultChunkSize,
+		ChunkOverlap:   _defaultChunkOverlap,
+		MaxHeaderLevel: _defaultMaxHeaderLevel,
+	}
+}
+
+// SplitText splits an html text into multiple markdown texts.
+func (s HTMLHeader) SplitText(text string) ([]string, error) {
+	chunks, _, err := s.SplitTextWithMetadata(text)
+	return chunks, err
+}
+
+// SplitTextWithMetadata splits an html text into multiple markdown texts and
+// returns the headers of the section of every chunk.
+func (s HTMLHeader) SplitTextWithMetadata(text string) ([]string, []map[string]any, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return MarkdownHeader{
+		ChunkSize:      s.ChunkSize,
+		ChunkOverlap:   s.ChunkOverlap,
+		MaxHeaderLevel: s.MaxHeaderLevel,
+	}.SplitTextWithMetadata(htmlmd.Convert(htmlmd.Content(doc, s.Selector), nil))
+}
@@ -0,0 +1,46 @@
+package textsplitter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLHeaderSplitter(t *testing.T) {
+	t.Parallel()
+
+	html := `<html><body>
+<nav><h1>Site</h1></nav>
+<article>
+  <h1>Guide</h1>
+  <p>Intro text.</p>
+  <h2>Install</h2>
+  <p>Run <code>go get</code>.</p>
+  <h3>Linux</h3>
+  <ul><li>apt</li><li>snap</li></ul>
+  <h4>Notes</h4>
+  <p>Deep header stays in the section.</p>
+  <h2>Usage</h2>
+  <p>See <a href="/docs">docs</a>.</p>
+</article>
+</body></html>`
+
+	splitter := NewHTMLHeader()
+	splitter.Selector = "article"
+
+	chunks, metadatas, err := splitter.SplitTextWithMetadata(html)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"# Guide\n\nIntro text.",
+		"## Install\n\nRun `go get`.",
+		"### Linux\n\n- apt\n- snap\n\n#### Notes\n\nDeep header stays in the section.",
+		"## Usage\n\nSee [docs](/docs).",
+	}, chunks)
+	assert.Equal(t, []map[string]any{
+		{"h1": "Guide"},
+		{"h1": "Guide", "h2": "Install"},
+		{"h1": "Guide", "h2": "Install", "h3": "Linux"},
+		{"h1": "Guide", "h2": "Usage"},
+	}, metadatas)
+}
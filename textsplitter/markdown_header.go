@@ -0,0 +1,244 @@
+package textsplitter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const _defaultMaxHeaderLevel = 3
+
+// _markdownHeaderRegexp matches ATX headers like "## Title" or "## Title ##".
+var _markdownHeaderRegexp = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+
+// MarkdownHeader is a text splitter that splits markdown into sections on
+// headers, and then splits the sections into chunks of at most ChunkSize.
+// Fenced code blocks and tables are never split, so chunks holding them may be
+// longer than ChunkSize. The headers of the section of a chunk are returned as
+// metadata with the keys "h1", "h2" and so on.
+type MarkdownHeader struct {
+	ChunkSize    int
+	ChunkOverlap int
+	// MaxHeaderLevel is the deepest header level that starts a new section.
+	// Deeper headers are kept as content.
+	MaxHeaderLevel int
+	// StripHeaders removes the header lines of the sections from the chunks.
+	StripHeaders bool
+}
+
+var _ MetadataTextSplitter = MarkdownHeader{}
+
+// NewMarkdownHeader creates a new markdown header splitter with default values.
+// By default sections start at headers of level 1 to 3, the header lines are
+// kept, the chunk size is set to 4000 and the chunk overlap is set to 200.
+func NewMarkdownHeader() MarkdownHeader {
+	return MarkdownHeader{
+		ChunkSize:      _defaultChunkSize,
+		ChunkOverlap:   _defaultChunkOverlap,
+		MaxHeaderLevel: _defaultMaxHeaderLevel,
+	}
+}
+
+// SplitText splits a markdown text into multiple texts.
+func (s MarkdownHeader) SplitText(text string) ([]string, error) {
+	chunks, _, err := s.SplitTextWithMetadata(text)
+	return chunks, err
+}
+
+// SplitTextWithMetadata splits a markdown text into multiple texts and returns
+// the headers of the section of every chunk.
+func (s MarkdownHeader) SplitTextWithMetadata(text string) ([]string, []map[string]any, error) {
+	chunks := make([]string, 0)
+	metadatas := make([]map[string]any, 0)
+
+	for _, section := range s.sections(text) {
+		sectionChunks, err := s.splitSection(section.lines)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, chunk := range sectionChunks {
+			metadata := make(map[string]any, len(section.headers))
+			for i, header := range section.headers {
+				if header != "" {
+					metadata["h"+strconv.Itoa(i+1)] = header
+				}
+			}
+			chunks = append(chunks, chunk)
+			metadatas = append(metadatas, metadata)
+		}
+	}
+
+	return chunks, metadatas, nil
+}
+
+// markdownSection is the content under a header with the header path.
+type markdownSection struct {
+	headers []string
+	lines   []string
+}
+
+// sections splits the text into sections on the headers outside of code
+// blocks. Sections without content besides headers are dropped.
+func (s MarkdownHeader) sections(text string) []markdownSection {
+	sections := make([]markdownSection, 0)
+	headers := make([]string, s.MaxHeaderLevel)
+	current := markdownSection{headers: []string{}}
+	hasContent := false
+	fence := ""
+
+	flush := func() {
+		if hasContent {
+			sections = append(sections, current)
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if fence != "" {
+			current.lines = append(current.lines, line)
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence = openingFence(line); fence != "" {
+			current.lines = append(current.lines, line)
+			hasContent = true
+			continue
+		}
+
+		level, title := markdownHeader(line)
+		if level == 0 || level > s.MaxHeaderLevel {
+			current.lines = append(current.lines, line)
+			hasContent = hasContent || strings.TrimSpace(line) != ""
+			continue
+		}
+
+		flush()
+		headers[level-1] = title
+		for i := level; i < len(headers); i++ {
+			headers[i] = ""
+		}
+		current = markdownSection{headers: append([]string{}, headers[:level]...)}
+		hasContent = false
+		if !s.StripHeaders {
+			current.lines = append(current.lines, line)
+		}
+	}
+	flush()
+
+	return sections
+}
+
+// splitSection splits the lines of a section into blocks separated by blank
+// lines, and merges the blocks into chunks. Code blocks and tables are kept
+// whole, other blocks longer than the chunk size are split further.
+func (s MarkdownHeader) splitSection(lines []string) ([]string, error) {
+	recursive := NewRecursiveCharacter()
+	recursive.ChunkSize = s.ChunkSize
+	recursive.ChunkOverlap = s.ChunkOverlap
+
+	splits := make([]string, 0)
+	for _, block := range markdownBlocks(lines) {
+		if block.atomic || len(block.text) <= s.ChunkSize {
+			splits = append(splits, block.text)
+			continue
+		}
+
+		blockSplits, err := recursive.SplitText(block.text)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, blockSplits...)
+	}
+
+	return mergeSplits(splits, "\n\n", s.ChunkSize, s.ChunkOverlap, stringLen), nil
+}
+
+// markdownBlock is a run of lines separated from other blocks by blank lines.
+// Atomic blocks are code blocks and tables.
+type markdownBlock struct {
+	text   string
+	atomic bool
+}
+
+func markdownBlocks(lines []string) []markdownBlock {
+	blocks := make([]markdownBlock, 0)
+	current := make([]string, 0)
+	atomic := false
+	fence := ""
+
+	flush := func() {
+		text := strings.Join(current, "\n")
+		if strings.TrimSpace(text) != "" {
+			blocks = append(blocks, markdownBlock{text: text, atomic: atomic})
+		}
+		current = current[:0]
+		atomic = false
+	}
+
+	for _, line := range lines {
+		if fence != "" {
+			current = append(current, line)
+			if closesFence(line, fence) {
+				fence = ""
+				flush()
+			}
+			continue
+		}
+		if f := openingFence(line); f != "" {
+			flush()
+			fence = f
+			atomic = true
+			current = append(current, line)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") {
+			atomic = true
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return blocks
+}
+
+// markdownHeader returns the level and title of an ATX header line, or a zero
+// level if the line is no header.
+func markdownHeader(line string) (int, string) {
+	m := _markdownHeaderRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return 0, ""
+	}
+	return len(m[1]), strings.TrimSpace(m[2])
+}
+
+// openingFence returns the fence of a line opening a fenced code block, like
+// "```" or "~~~~", or "" if the line opens none.
+func openingFence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, c := range []string{"`", "~"} {
+		fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, c))]
+		if len(fence) >= 3 {
+			return fence
+		}
+	}
+	return ""
+}
+
+// closesFence reports whether the line closes the fenced code block opened
+// with the fence.
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	rest := strings.TrimLeft(trimmed, fence[:1])
+	return len(trimmed)-len(rest) >= len(fence) && rest == ""
+}
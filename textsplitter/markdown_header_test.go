@@ -0,0 +1,96 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const _markdownText = `前言内容
+
+# 员工手册
+
+## 年假
+
+员工每年享有五天年假。
+
+### 申请流程
+
+` + "```bash" + `
+# 这不是标题
+apply --days 5
+
+echo done
+` + "```" + `
+
+#### 注意
+
+| 工龄 | 天数 |
+| --- | --- |
+| 十年 | 10 |
+
+## 报销 ##
+
+#无空格不是标题
+`
+
+func TestMarkdownHeaderSplitter(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewMarkdownHeader()
+	chunks, metadatas, err := splitter.SplitTextWithMetadata(_markdownText)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"前言内容",
+		"## 年假\n\n员工每年享有五天年假。",
+		"### 申请流程\n\n```bash\n# 这不是标题\napply --days 5\n\necho done\n```\n\n" +
+			"#### 注意\n\n| 工龄 | 天数 |\n| --- | --- |\n| 十年 | 10 |",
+		"## 报销 ##\n\n#无空格不是标题",
+	}, chunks)
+	assert.Equal(t, []map[string]any{
+		{},
+		{"h1": "员工手册", "h2": "年假"},
+		{"h1": "员工手册", "h2": "年假", "h3": "申请流程"},
+		{"h1": "员工手册", "h2": "报销"},
+	}, metadatas)
+}
+
+func TestMarkdownHeaderSplitterChunks(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewMarkdownHeader()
+	splitter.ChunkSize = 40
+	splitter.ChunkOverlap = 0
+	splitter.StripHeaders = true
+
+	docs, err := CreateDocuments(splitter, []string{_markdownText}, []map[string]any{{"source": "handbook.md", "h2": "x"}})
+	require.NoError(t, err)
+
+	var code, table schema.Document
+	for _, doc := range docs {
+		assert.NotContains(t, doc.PageContent, "## 年假")
+		assert.Equal(t, "handbook.md", doc.Metadata["source"])
+		if strings.HasPrefix(doc.PageContent, "```") {
+			code = doc
+		}
+		if strings.HasPrefix(doc.PageContent, "|") {
+			table = doc
+		}
+	}
+	assert.Equal(t, "```bash\n# 这不是标题\napply --days 5\n\necho done\n```", code.PageContent)
+	assert.Equal(t, "申请流程", code.Metadata["h3"])
+	assert.Equal(t, "| 工龄 | 天数 |\n| --- | --- |\n| 十年 | 10 |", table.PageContent)
+	assert.Equal(t, "年假", table.Metadata["h2"])
+	assert.Equal(t, map[string]any{"source": "handbook.md", "h2": "x"}, docs[0].Metadata)
+
+	texts, err := splitter.SplitText("# 标题\n\n" + strings.Repeat("很长的段落。", 20))
+	require.NoError(t, err)
+	assert.Greater(t, len(texts), 1)
+	for _, text := range texts {
+		assert.LessOrEqual(t, len(text), 40)
+	}
+}
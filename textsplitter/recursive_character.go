@@ -2,14 +2,30 @@ package textsplitter
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
+// _closingPunctuation are the closing quotes and brackets that stay attached
+// to a kept separator, so "他说：“好。”" is not split between "。" and "”".
+const _closingPunctuation = "”’」』）》】〉"
+
 // RecursiveCharacter is a text splitter that will split texts recursively by different
 // characters.
 type RecursiveCharacter struct {
 	Separators   []string
 	ChunkSize    int
 	ChunkOverlap int
+	// KeepSeparator keeps every separator at the end of the split before it,
+	// together with closing quotes and brackets following it, instead of
+	// dropping the separators at chunk boundaries.
+	KeepSeparator bool
+	// LenFunc measures the length of texts for ChunkSize and ChunkOverlap.
+	// Defaults to the number of bytes.
+	LenFunc func(string) int
+	// SplitFunc splits a text on a separator, keeping the separator in the
+	// splits, like before or after it. Splits are then merged without a
+	// separator. It takes precedence over KeepSeparator.
+	SplitFunc func(text, separator string) []string
 }
 
 // NewRecursiveCharacter creates a new recursive character splitter with default values. By
@@ -23,13 +39,39 @@ func NewRecursiveCharacter() RecursiveCharacter {
 	}
 }
 
+// NewChineseRecursiveCharacter creates a new recursive character splitter for
+// Chinese and mixed Chinese and English texts. It splits on paragraphs, lines,
+// Chinese and English sentence endings, clause separators like "；", "，" and
+// "、", and spaces, in that order. Separators are kept at the end of their
+// sentence or clause, and the length of texts is measured in runes. The chunk
+// size is set to 4000 and chunk overlap is set to 200.
+func NewChineseRecursiveCharacter() RecursiveCharacter {
+	return RecursiveCharacter{
+		Separators: []string{
+			"\n\n", "\n",
+			"。", "！", "？", "!", "?", ". ", "…",
+			"；", ";", "，", ", ", "、",
+			" ", "",
+		},
+		ChunkSize:     _defaultChunkSize,
+		ChunkOverlap:  _defaultChunkOverlap,
+		KeepSeparator: true,
+		LenFunc:       utf8.RuneCountInString,
+	}
+}
+
 // SplitText splits a text into multiple text.
 func (s RecursiveCharacter) SplitText(text string) ([]string, error) {
+	return s.splitText(text, s.Separators), nil
+}
+
+func (s RecursiveCharacter) splitText(text string, separators []string) []string {
 	finalChunks := make([]string, 0)
 
 	// Find the appropriate separator
-	separator := s.Separators[len(s.Separators)-1]
-	for _, s := range s.Separators {
+	separator := separators[len(separators)-1]
+	var nextSeparators []string
+	for i, s := range separators {
 		if s == "" {
 			separator = s
 			break
@@ -37,38 +79,97 @@ func (s RecursiveCharacter) SplitText(text string) ([]string, error) {
 
 		if strings.Contains(text, s) {
 			separator = s
+			nextSeparators = separators[i+1:]
 			break
 		}
 	}
 
-	splits := strings.Split(text, separator)
+	splits, mergeSeparator := s.split(text, separator)
 	goodSplits := make([]string, 0)
 
 	// Merge the splits, recursively splitting larger texts.
 	for _, split := range splits {
-		if len(split) < s.ChunkSize {
+		if s.length(split) < s.ChunkSize {
 			goodSplits = append(goodSplits, split)
 			continue
 		}
 
 		if len(goodSplits) > 0 {
-			mergedText := mergeSplits(goodSplits, separator, s.ChunkSize, s.ChunkOverlap)
+			mergedText := mergeSplits(goodSplits, mergeSeparator, s.ChunkSize, s.ChunkOverlap, s.length)
 
 			finalChunks = append(finalChunks, mergedText...)
 			goodSplits = make([]string, 0)
 		}
 
-		otherInfo, err := s.SplitText(split)
-		if err != nil {
-			return nil, err
+		if len(nextSeparators) == 0 {
+			finalChunks = append(finalChunks, strings.TrimSpace(split))
+			continue
 		}
-		finalChunks = append(finalChunks, otherInfo...)
+		finalChunks = append(finalChunks, s.splitText(split, nextSeparators)...)
 	}
 
 	if len(goodSplits) > 0 {
-		mergedText := mergeSplits(goodSplits, separator, s.ChunkSize, s.ChunkOverlap)
+		mergedText := mergeSplits(goodSplits, mergeSeparator, s.ChunkSize, s.ChunkOverlap, s.length)
 		finalChunks = append(finalChunks, mergedText...)
 	}
 
-	return finalChunks, nil
+	return finalChunks
+}
+
+// split splits the text on the separator and returns the splits with the
+// separator to merge them with.
+func (s RecursiveCharacter) split(text, separator string) ([]string, string) {
+	switch {
+	case s.SplitFunc != nil:
+		return s.SplitFunc(text, separator), ""
+	case s.KeepSeparator:
+		return splitKeepSeparator(text, separator), ""
+	default:
+		return strings.Split(text, separator), separator
+	}
+}
+
+func (s RecursiveCharacter) length(text string) int {
+	if s.LenFunc == nil {
+		return stringLen(text)
+	}
+	return s.LenFunc(text)
+}
+
+// stringLen returns the number of bytes of the text.
+func stringLen(text string) int {
+	return len(text)
+}
+
+// splitKeepSeparator splits the text after every separator and the closing
+// punctuation following it.
+func splitKeepSeparator(text, separator string) []string {
+	if separator == "" {
+		return strings.Split(text, "")
+	}
+
+	splits := make([]string, 0)
+	for {
+		idx := strings.Index(text, separator)
+		if idx < 0 {
+			break
+		}
+
+		end := idx + len(separator)
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !strings.ContainsRune(_closingPunctuation, r) {
+				break
+			}
+			end += size
+		}
+
+		splits = append(splits, text[:end])
+		text = text[end:]
+	}
+	if text != "" {
+		splits = append(splits, text)
+	}
+
+	return splits
 }
@@ -100,3 +100,33 @@ Bye!
 		assert.Equal(t, tc.expectedDocs, docs)
 	}
 }
+
+// TestRecursiveCharacterNextSeparators checks that recursing with the
+// separators after the one used splits texts with the default separators like
+// restarting from the first separator did.
+//
+//nolint:dupword
+func TestRecursiveCharacterNextSeparators(t *testing.T) {
+	t.Parallel()
+	text := "Hi.\n\nI'm Harrison.\n\nHow? Are? You?\nOkay then f f f f.\n" +
+		"This is a weird text to write, but gotta test the splittingggg some how.\n\nBye!\n\n-H."
+	testCases := map[int][]string{
+		20: {
+			"Hi.\n\nI'm Harrison.", "How? Are? You?", "Okay then f f f f.", "This is a weird text",
+			"text to write, but", "but gotta test the", "the splittingggg", "some how.", "Bye!\n\n-H.",
+		},
+		35: {
+			"Hi.\n\nI'm Harrison.", "How? Are? You?\nOkay then f f f f.", "This is a weird text to write, but",
+			"but gotta test the splittingggg", "some how.", "Bye!\n\n-H.",
+		},
+	}
+	for chunkSize, expected := range testCases {
+		splitter := NewRecursiveCharacter()
+		splitter.ChunkSize = chunkSize
+		splitter.ChunkOverlap = 4
+
+		chunks, err := splitter.SplitText(text)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, chunks, chunkSize)
+	}
+}
@@ -0,0 +1,318 @@
+package textsplitter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+// _sentenceEndings are the runes ending a sentence. A "." only ends a sentence
+// when it is followed by a whitespace.
+const _sentenceEndings = "。！？!?…"
+
+// ErrMissingEmbedder is returned when a Semantic splitter has no embedder.
+var ErrMissingEmbedder = errors.New("semantic splitter needs an embedder")
+
+// BreakpointThresholdType is the way a Semantic splitter computes the distance
+// above which adjacent sentences are placed in different chunks.
+type BreakpointThresholdType string
+
+const (
+	// BreakpointPercentile breaks at distances above the BreakpointThreshold
+	// percentile of all distances.
+	BreakpointPercentile BreakpointThresholdType = "percentile"
+	// BreakpointStandardDeviation breaks at distances more than
+	// BreakpointThreshold standard deviations above the mean.
+	BreakpointStandardDeviation BreakpointThresholdType = "standard_deviation"
+	// BreakpointInterquartile breaks at distances more than BreakpointThreshold
+	// interquartile ranges above the mean.
+	BreakpointInterquartile BreakpointThresholdType = "interquartile"
+)
+
+// _defaultBreakpointThresholds are the default thresholds of the breakpoint
+// threshold types.
+var _defaultBreakpointThresholds = map[BreakpointThresholdType]float64{ //nolint:gochecknoglobals
+	BreakpointPercentile:        95,  //nolint:gomnd
+	BreakpointStandardDeviation: 3,   //nolint:gomnd
+	BreakpointInterquartile:     1.5, //nolint:gomnd
+}
+
+// Semantic is a text splitter that splits texts into sentences, embeds every
+// sentence together with its neighbours and places chunk boundaries where the
+// cosine distance between adjacent sentences is above a threshold.
+type Semantic struct {
+	Embedder embeddings.Embedder
+	// BreakpointThresholdType is the way the threshold is computed from the
+	// distances between adjacent sentences.
+	BreakpointThresholdType BreakpointThresholdType
+	// BreakpointThreshold is the percentile, or the number of standard
+	// deviations or interquartile ranges, of the threshold. Zero uses the
+	// default of the threshold type: 95, 3 and 1.5.
+	BreakpointThreshold float64
+	// BufferSize is the number of sentences on each side embedded together
+	// with a sentence, to smooth the distances.
+	BufferSize int
+	// MinChunkSize is the length below which a chunk is not ended at a
+	// breakpoint.
+	MinChunkSize int
+	// MaxChunkSize is the length above which chunks are ended regardless of
+	// breakpoints. Sentences longer than it are split further. Zero disables
+	// the limit.
+	MaxChunkSize int
+	// LenFunc measures the length of texts for MinChunkSize and MaxChunkSize.
+	// Defaults to the number of bytes.
+	LenFunc func(string) int
+}
+
+// NewSemantic creates a new semantic splitter with the embedder. By default
+// chunks break at the 95th percentile of the distances, sentences are embedded
+// with one sentence on each side, lengths are measured in runes and chunks are
+// at most 4000 runes.
+func NewSemantic(embedder embeddings.Embedder) Semantic {
+	return Semantic{
+		Embedder:                embedder,
+		BreakpointThresholdType: BreakpointPercentile,
+		BufferSize:              1,
+		MaxChunkSize:            _defaultChunkSize,
+		LenFunc:                 utf8.RuneCountInString,
+	}
+}
+
+// SplitText splits a text into multiple text.
+func (s Semantic) SplitText(text string) ([]string, error) {
+	return s.SplitTextContext(context.Background(), text)
+}
+
+// SplitTextContext splits a text into multiple text, using the context for
+// embedding the sentences.
+func (s Semantic) SplitTextContext(ctx context.Context, text string) ([]string, error) {
+	if s.Embedder == nil {
+		return nil, ErrMissingEmbedder
+	}
+
+	sentences := splitSentences(text)
+	if len(sentences) < 2 { //nolint:gomnd
+		return s.finalizeChunks(sentences), nil
+	}
+
+	distances, err := s.distances(ctx, sentences)
+	if err != nil {
+		return nil, err
+	}
+	threshold := s.threshold(distances)
+
+	chunks := make([]string, 0)
+	current := ""
+	flush := func() {
+		if strings.TrimSpace(current) != "" {
+			chunks = append(chunks, current)
+		}
+		current = ""
+	}
+	for i, sentence := range sentences {
+		if current != "" && s.MaxChunkSize > 0 &&
+			s.length(strings.TrimSpace(current+sentence)) > s.MaxChunkSize {
+			flush()
+		}
+		current += sentence
+		if i < len(distances) && distances[i] > threshold &&
+			s.length(strings.TrimSpace(current)) >= s.MinChunkSize {
+			flush()
+		}
+	}
+
+	// Merge a last chunk that is too short into the previous one.
+	if len(chunks) > 0 && current != "" && s.length(strings.TrimSpace(current)) < s.MinChunkSize {
+		last := chunks[len(chunks)-1]
+		if s.MaxChunkSize <= 0 || s.length(strings.TrimSpace(last+current)) <= s.MaxChunkSize {
+			chunks[len(chunks)-1] = last + current
+			current = ""
+		}
+	}
+	flush()
+
+	return s.finalizeChunks(chunks), nil
+}
+
+// distances returns the cosine distances between the embeddings of adjacent
+// sentences, each embedded together with BufferSize sentences on each side.
+func (s Semantic) distances(ctx context.Context, sentences []string) ([]float64, error) {
+	combined := make([]string, len(sentences))
+	for i := range sentences {
+		start := i - s.BufferSize
+		if start < 0 {
+			start = 0
+		}
+		end := i + s.BufferSize + 1
+		if end > len(sentences) {
+			end = len(sentences)
+		}
+		combined[i] = strings.TrimSpace(strings.Join(sentences[start:end], ""))
+	}
+
+	vectors, err := s.Embedder.EmbedDocuments(ctx, combined)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(combined) {
+		return nil, embeddings.ErrEmbedderWrongNumberVectors
+	}
+
+	distances := make([]float64, len(vectors)-1)
+	for i := 0; i < len(vectors)-1; i++ {
+		similarity, err := embeddings.CosineSimilarity(vectors[i], vectors[i+1])
+		if err != nil {
+			return nil, err
+		}
+		distances[i] = 1 - similarity
+	}
+	return distances, nil
+}
+
+// threshold returns the distance above which adjacent sentences are placed in
+// different chunks.
+func (s Semantic) threshold(distances []float64) float64 {
+	thresholdType := s.BreakpointThresholdType
+	if thresholdType == "" {
+		thresholdType = BreakpointPercentile
+	}
+	amount := s.BreakpointThreshold
+	if amount == 0 {
+		amount = _defaultBreakpointThresholds[thresholdType]
+	}
+
+	switch thresholdType {
+	case BreakpointStandardDeviation:
+		mean, std := meanStd(distances)
+		return mean + amount*std
+	case BreakpointInterquartile:
+		mean, _ := meanStd(distances)
+		return mean + amount*(percentile(distances, 75)-percentile(distances, 25)) //nolint:gomnd
+	case BreakpointPercentile:
+		return percentile(distances, amount)
+	default:
+		return percentile(distances, amount)
+	}
+}
+
+// finalizeChunks splits chunks longer than MaxChunkSize and trims them.
+func (s Semantic) finalizeChunks(chunks []string) []string {
+	splitter := RecursiveCharacter{
+		Separators:    []string{"\n", "，", "；", ",", ";", " ", ""},
+		ChunkSize:     s.MaxChunkSize,
+		KeepSeparator: true,
+		LenFunc:       s.LenFunc,
+	}
+
+	final := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		if s.MaxChunkSize <= 0 || s.length(chunk) <= s.MaxChunkSize {
+			final = append(final, chunk)
+			continue
+		}
+		for _, split := range splitter.splitText(chunk, splitter.Separators) {
+			if split = strings.TrimSpace(split); split != "" {
+				final = append(final, split)
+			}
+		}
+	}
+	return final
+}
+
+func (s Semantic) length(text string) int {
+	if s.LenFunc == nil {
+		return stringLen(text)
+	}
+	return s.LenFunc(text)
+}
+
+// splitSentences splits the text after sentence endings and line breaks. The
+// sentences keep their punctuation and the whitespace following them, so
+// joining them returns the text.
+func splitSentences(text string) []string {
+	sentences := make([]string, 0)
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if !isSentenceEnd(r, text[i:]) {
+			continue
+		}
+
+		for i < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[i:])
+			if !strings.ContainsRune(_sentenceEndings+_closingPunctuation, next) && !unicode.IsSpace(next) {
+				break
+			}
+			i += nextSize
+		}
+		sentences = append(sentences, text[start:i])
+		start = i
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+
+	return sentences
+}
+
+func isSentenceEnd(r rune, rest string) bool {
+	switch {
+	case r == '\n' || strings.ContainsRune(_sentenceEndings, r):
+		return true
+	case r == '.':
+		next, _ := utf8.DecodeRuneInString(rest)
+		return rest == "" || unicode.IsSpace(next)
+	default:
+		return false
+	}
+}
+
+// percentile returns the p-th percentile of the values, interpolating
+// linearly between the closest ranks.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1) //nolint:gomnd
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower < 0 {
+		return sorted[0]
+	}
+	if upper >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// meanStd returns the mean and the population standard deviation of the values.
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
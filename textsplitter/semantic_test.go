@@ -0,0 +1,124 @@
+package textsplitter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+// topicEmbedder embeds texts by the topics they mention.
+type topicEmbedder struct {
+	topics []string
+	err    error
+}
+
+func (e topicEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vector := make([]float64, len(e.topics))
+		for i, topic := range e.topics {
+			vector[i] = float64(strings.Count(text, topic))
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+func (e topicEmbedder) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	vectors, err := e.EmbedDocuments(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+var _ embeddings.Embedder = topicEmbedder{}
+
+func TestSemanticSplitter(t *testing.T) {
+	t.Parallel()
+
+	text := "猫很可爱。猫喜欢睡觉。猫爱吃鱼。股票上涨了。股票市场波动。"
+	expected := []string{"猫很可爱。猫喜欢睡觉。猫爱吃鱼。", "股票上涨了。股票市场波动。"}
+
+	for _, thresholdType := range []BreakpointThresholdType{
+		BreakpointPercentile, BreakpointStandardDeviation, BreakpointInterquartile,
+	} {
+		splitter := NewSemantic(topicEmbedder{topics: []string{"猫", "股票"}})
+		splitter.BufferSize = 0
+		splitter.BreakpointThresholdType = thresholdType
+		if thresholdType == BreakpointStandardDeviation {
+			splitter.BreakpointThreshold = 1
+		}
+
+		chunks, err := splitter.SplitText(text)
+		require.NoError(t, err)
+		assert.Equal(t, expected, chunks, thresholdType)
+	}
+}
+
+func TestSemanticSplitterEnglish(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewSemantic(topicEmbedder{topics: []string{"cat", "stock"}})
+	splitter.BufferSize = 0
+	chunks, err := splitter.SplitText("The cat sleeps. The cat eats fish.\nThe stock market rose. The stock fell 1.5 percent.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"The cat sleeps. The cat eats fish.",
+		"The stock market rose. The stock fell 1.5 percent.",
+	}, chunks)
+}
+
+func TestSemanticSplitterChunkSizes(t *testing.T) {
+	t.Parallel()
+
+	text := "猫很可爱。猫喜欢睡觉。猫爱吃鱼。股票上涨了。股票市场波动。"
+
+	splitter := NewSemantic(topicEmbedder{topics: []string{"猫", "股票"}})
+	splitter.BufferSize = 0
+	splitter.MaxChunkSize = 10
+	chunks, err := splitter.SplitText(text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"猫很可爱。", "猫喜欢睡觉。", "猫爱吃鱼。", "股票上涨了。", "股票市场波动。"}, chunks)
+
+	splitter.MaxChunkSize = 0
+	splitter.MinChunkSize = 20
+	chunks, err = splitter.SplitText(text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{text}, chunks)
+
+	// Sentences longer than the maximum are split further.
+	splitter.MinChunkSize = 0
+	splitter.MaxChunkSize = 6
+	chunks, err = splitter.SplitText("猫很可爱，猫喜欢睡觉，猫爱吃鱼")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"猫很可爱，", "猫喜欢睡觉，", "猫爱吃鱼"}, chunks)
+}
+
+func TestSemanticSplitterErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Semantic{}.SplitText("text")
+	require.ErrorIs(t, err, ErrMissingEmbedder)
+
+	errEmbed := errors.New("embed error")
+	_, err = NewSemantic(topicEmbedder{err: errEmbed}).SplitText("第一句。第二句。")
+	require.ErrorIs(t, err, errEmbed)
+}
+
+func TestSplitSentences(t *testing.T) {
+	t.Parallel()
+
+	text := "他说：“好。”然后走了！！\n\nVersion 1.5 is out. Really?Yes"
+	sentences := splitSentences(text)
+	assert.Equal(t, []string{"他说：“好。”", "然后走了！！\n\n", "Version 1.5 is out. ", "Really?", "Yes"}, sentences)
+	assert.Equal(t, text, strings.Join(sentences, ""))
+}
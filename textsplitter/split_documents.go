@@ -27,7 +27,9 @@ func SplitDocuments(textSplitter TextSplitter, documents []schema.Document) ([]s
 
 // CreateDocuments creates documents from texts and metadatas with a text splitter. If
 // the length of the metadatas is zero, the result documents will contain no metadata.
-// Otherwise the numbers of texts and metadatas must match.
+// Otherwise the numbers of texts and metadatas must match. If the text splitter is a
+// MetadataTextSplitter, the metadata of every chunk is added to the document metadata,
+// replacing the values of keys in both.
 func CreateDocuments(textSplitter TextSplitter, texts []string, metadatas []map[string]any) ([]schema.Document, error) {
 	if len(metadatas) == 0 {
 		metadatas = make([]map[string]any, len(texts))
@@ -40,17 +42,22 @@ func CreateDocuments(textSplitter TextSplitter, texts []string, metadatas []map[
 	documents := make([]schema.Document, 0)
 
 	for i := 0; i < len(texts); i++ {
-		chunks, err := textSplitter.SplitText(texts[i])
+		chunks, chunkMetadatas, err := splitText(textSplitter, texts[i])
 		if err != nil {
 			return nil, err
 		}
 
-		for _, chunk := range chunks {
+		for j, chunk := range chunks {
 			// Copy the document metadata
 			curMetadata := make(map[string]any, len(metadatas[i]))
 			for key, value := range metadatas[i] {
 				curMetadata[key] = value
 			}
+			if chunkMetadatas != nil {
+				for key, value := range chunkMetadatas[j] {
+					curMetadata[key] = value
+				}
+			}
 
 			documents = append(documents, schema.Document{
 				PageContent: chunk,
@@ -62,21 +69,43 @@ func CreateDocuments(textSplitter TextSplitter, texts []string, metadatas []map[
 	return documents, nil
 }
 
+// splitText splits a text with the text splitter, and returns the metadata of the
+// chunks if it is a MetadataTextSplitter.
+func splitText(textSplitter TextSplitter, text string) ([]string, []map[string]any, error) {
+	metadataSplitter, ok := textSplitter.(MetadataTextSplitter)
+	if !ok {
+		chunks, err := textSplitter.SplitText(text)
+		return chunks, nil, err
+	}
+
+	chunks, metadatas, err := metadataSplitter.SplitTextWithMetadata(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chunks) != len(metadatas) {
+		return nil, nil, ErrMismatchMetadatasAndText
+	}
+	return chunks, metadatas, nil
+}
+
 // joinDocs comines two documents with the separator used to split them.
 func joinDocs(docs []string, separator string) string {
 	return strings.TrimSpace(strings.Join(docs, separator))
 }
 
-// mergeSplits merges smaller splits into splits that are closer to the chunkSize.
-func mergeSplits(splits []string, separator string, chunkSize int, chunkOverlap int) []string { //nolint:cyclop
+// mergeSplits merges smaller splits into splits that are closer to the chunkSize,
+// measuring the length of texts with lenFunc.
+func mergeSplits(splits []string, separator string, chunkSize int, chunkOverlap int, lenFunc func(string) int) []string { //nolint:cyclop,lll
 	docs := make([]string, 0)
 	currentDoc := make([]string, 0)
 	total := 0
+	separatorLen := lenFunc(separator)
 
 	for _, split := range splits {
-		totalWithSplit := total + len(split)
+		splitLen := lenFunc(split)
+		totalWithSplit := total + splitLen
 		if len(currentDoc) != 0 {
-			totalWithSplit += len(separator)
+			totalWithSplit += separatorLen
 		}
 
 		maybePrintWarning(total, chunkSize)
@@ -86,19 +115,19 @@ func mergeSplits(splits []string, separator string, chunkSize int, chunkOverlap
 				docs = append(docs, doc)
 			}
 
-			for shouldPop(chunkOverlap, chunkSize, total, len(split), len(separator), len(currentDoc)) {
-				total -= len(currentDoc[0]) //nolint:gosec
+			for shouldPop(chunkOverlap, chunkSize, total, splitLen, separatorLen, len(currentDoc)) {
+				total -= lenFunc(currentDoc[0]) //nolint:gosec
 				if len(currentDoc) > 1 {
-					total -= len(separator)
+					total -= separatorLen
 				}
 				currentDoc = currentDoc[1:] //nolint:gosec
 			}
 		}
 
 		currentDoc = append(currentDoc, split)
-		total += len(split)
+		total += splitLen
 		if len(currentDoc) > 1 {
-			total += len(separator)
+			total += separatorLen
 		}
 	}
 
@@ -9,3 +9,13 @@ const (
 type TextSplitter interface {
 	SplitText(string) ([]string, error)
 }
+
+// MetadataTextSplitter is a TextSplitter that also returns metadata for every
+// chunk, like the headers of the section a chunk belongs to. SplitDocuments
+// and CreateDocuments add the metadata of the chunks to the metadata of the
+// documents.
+type MetadataTextSplitter interface {
+	TextSplitter
+	// SplitTextWithMetadata splits a text and returns the metadata of every chunk.
+	SplitTextWithMetadata(string) ([]string, []map[string]any, error)
+}
@@ -2,8 +2,10 @@ package textsplitter
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/pkoukk/tiktoken-go"
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -22,6 +24,9 @@ type TokenSplitter struct {
 	EncodingName      string
 	AllowedSpecial    []string
 	DisallowedSpecial []string
+	// Tokenizer encodes texts instead of the tiktoken encoding, like a
+	// SentencePiece tokenizer for models not using tiktoken.
+	Tokenizer llms.TokenEncoder
 }
 
 func NewTokenSplitter() TokenSplitter {
@@ -37,6 +42,14 @@ func NewTokenSplitter() TokenSplitter {
 
 // SplitText splits a text into multiple text.
 func (s TokenSplitter) SplitText(text string) ([]string, error) {
+	if s.Tokenizer != nil {
+		inputIds, err := s.Tokenizer.Encode(text)
+		if err != nil {
+			return nil, err
+		}
+		return s.splitTokens(inputIds, s.Tokenizer.Decode)
+	}
+
 	// Get the tokenizer
 	var tk *tiktoken.Tiktoken
 	var err error
@@ -48,14 +61,15 @@ func (s TokenSplitter) SplitText(text string) ([]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("tiktoken.GetEncoding: %w", err)
 	}
-	texts := s.splitText(text, tk)
+	inputIds := tk.Encode(text, s.AllowedSpecial, s.DisallowedSpecial)
 
-	return texts, nil
+	return s.splitTokens(inputIds, func(ids []int) (string, error) {
+		return tk.Decode(ids), nil
+	})
 }
 
-func (s TokenSplitter) splitText(text string, tk *tiktoken.Tiktoken) []string {
+func (s TokenSplitter) splitTokens(inputIds []int, decode func([]int) (string, error)) ([]string, error) {
 	splits := make([]string, 0)
-	inputIds := tk.Encode(text, s.AllowedSpecial, s.DisallowedSpecial)
 
 	startIdx := 0
 	curIdx := len(inputIds)
@@ -63,13 +77,30 @@ func (s TokenSplitter) splitText(text string, tk *tiktoken.Tiktoken) []string {
 		curIdx = startIdx + s.ChunkSize
 	}
 	for startIdx < len(inputIds) {
-		chunkIds := inputIds[startIdx:curIdx]
-		splits = append(splits, tk.Decode(chunkIds))
+		chunk, err := decode(inputIds[startIdx:curIdx])
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, chunk)
 		startIdx += s.ChunkSize - s.ChunkOverlap
 		curIdx = startIdx + s.ChunkSize
 		if curIdx > len(inputIds) {
 			curIdx = len(inputIds)
 		}
 	}
-	return splits
+	return splits, nil
+}
+
+// TokenizerLen returns a function counting the tokens of a text with the
+// tokenizer, like a tokenizer.Tiktoken, for use as the LenFunc of a
+// RecursiveCharacter splitter. Texts the tokenizer fails on are measured in
+// runes.
+func TokenizerLen(tokenizer llms.Tokenizer) func(string) int {
+	return func(text string) int {
+		n, err := tokenizer.CountTokens(text)
+		if err != nil {
+			return utf8.RuneCountInString(text)
+		}
+		return n
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/schema"
 )
 
@@ -85,3 +86,42 @@ Bye!
 		assert.Equal(t, tc.expectedDocs, docs)
 	}
 }
+
+// runeEncoder encodes every rune as a token.
+type runeEncoder struct{}
+
+func (runeEncoder) CountTokens(text string) (int, error) {
+	return len([]rune(text)), nil
+}
+
+func (runeEncoder) Encode(text string) ([]int, error) {
+	tokens := make([]int, 0, len(text))
+	for _, r := range text {
+		tokens = append(tokens, int(r))
+	}
+	return tokens, nil
+}
+
+func (runeEncoder) Decode(tokens []int) (string, error) {
+	runes := make([]rune, 0, len(tokens))
+	for _, t := range tokens {
+		runes = append(runes, rune(t))
+	}
+	return string(runes), nil
+}
+
+func TestTokenSplitterWithTokenizer(t *testing.T) {
+	t.Parallel()
+
+	splitter := NewTokenSplitter()
+	splitter.ChunkSize = 4
+	splitter.ChunkOverlap = 1
+	splitter.Tokenizer = runeEncoder{}
+
+	docs, err := splitter.SplitText("今天天气很好")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"今天天气", "气很好"}, docs)
+
+	lenFunc := TokenizerLen(runeEncoder{})
+	assert.Equal(t, 6, lenFunc("今天天气很好"))
+}
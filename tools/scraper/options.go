@@ -101,3 +101,28 @@ func WithBlacklist(blacklist []string) Options {
 		o.Blacklist = append(o.Blacklist, blacklist...)
 	}
 }
+
+// WithAllowedDomains creates an Options function that sets the domains
+// whose links are followed, including their subdomains. By default only
+// links to the host of the current page are followed.
+//
+// domains: the domains to follow links to, like "example.com".
+// Returns: an Options function.
+func WithAllowedDomains(domains ...string) Options {
+	return func(o *Scraper) {
+		o.AllowedDomains = domains
+	}
+}
+
+// WithRobotsTxt sets whether the Scraper respects the robots.txt files
+// of the sites it visits.
+//
+// Default value: false
+//
+// respect: the boolean value indicating if robots.txt is respected.
+// Returns: an Options function.
+func WithRobotsTxt(respect bool) Options {
+	return func(o *Scraper) {
+		o.RespectRobotsTxt = respect
+	}
+}
@@ -0,0 +1,101 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly"
+)
+
+// Page is a page scraped by Scrape. The raw html can be loaded with
+// documentloaders.NewHTML(bytes.NewReader(page.HTML), documentloaders.WithHTMLURL(page.URL)).
+type Page struct {
+	URL  string
+	HTML []byte
+}
+
+// Scrape scrapes websites starting from the URLs like Call, following the
+// same links, and returns the raw html of every page instead of a summary.
+// The URLs are at depth 1 and the pages they link to at depth 2, up to
+// MaxDepth.
+func (s Scraper) Scrape(ctx context.Context, urls ...string) ([]Page, error) {
+	for _, input := range urls {
+		if _, err := url.ParseRequestURI(input); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrScrapingFailed, err)
+		}
+	}
+
+	c := colly.NewCollector(
+		colly.MaxDepth(s.MaxDepth),
+		colly.Async(s.Async),
+	)
+	c.IgnoreRobotsTxt = !s.RespectRobotsTxt
+	err := c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: s.Parallels,
+		Delay:       time.Duration(s.Delay) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrScrapingFailed, err)
+	}
+
+	var mu sync.Mutex
+	pages := make([]Page, 0)
+	scrapedLinks := make(map[string]bool)
+
+	c.OnRequest(func(r *colly.Request) {
+		if ctx.Err() != nil {
+			r.Abort()
+		}
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		if !strings.Contains(strings.ToLower(r.Headers.Get("Content-Type")), "html") {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		currentURL := r.Request.URL.String()
+		if scrapedLinks[currentURL] {
+			return
+		}
+		scrapedLinks[currentURL] = true
+		pages = append(pages, Page{URL: currentURL, HTML: r.Body})
+	})
+
+	c.OnHTML("a[href]", func(e *colly.HTMLElement) {
+		u, ok := s.follow(e.Request.AbsoluteURL(e.Attr("href")), e.Request.URL)
+		if !ok {
+			return
+		}
+
+		mu.Lock()
+		visited := scrapedLinks[u.String()]
+		mu.Unlock()
+		if !visited {
+			_ = e.Request.Visit(u.String())
+		}
+	})
+
+	for _, input := range urls {
+		err := c.Visit(input)
+		if errors.Is(err, colly.ErrRobotsTxtBlocked) || errors.Is(err, colly.ErrAlreadyVisited) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrScrapingFailed, err)
+		}
+	}
+	c.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return pages, nil
+}
@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrape(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<html><body><a href="/about">About</a><a href="/login">Login</a></body></html>`)
+	})
+	mux.HandleFunc("/about", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<html><body><h1>About</h1><a href="/">Home</a><a href="/team">Team</a></body></html>`)
+	})
+	mux.HandleFunc("/team", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<html><body><h1>Team</h1></body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s, err := New(WithMaxDepth(2), WithDelay(0))
+	require.NoError(t, err)
+
+	pages, err := s.Scrape(context.Background(), server.URL+"/")
+	require.NoError(t, err)
+
+	urls := make([]string, 0, len(pages))
+	for _, page := range pages {
+		urls = append(urls, page.URL)
+	}
+	sort.Strings(urls)
+	assert.Equal(t, []string{server.URL + "/", server.URL + "/about"}, urls)
+
+	for _, page := range pages {
+		if page.URL == server.URL+"/about" {
+			assert.Contains(t, string(page.HTML), "<h1>About</h1>")
+		}
+	}
+
+	// /team is at depth 3.
+	s, err = New(WithMaxDepth(3), WithDelay(0))
+	require.NoError(t, err)
+	pages, err = s.Scrape(context.Background(), server.URL+"/")
+	require.NoError(t, err)
+	assert.Len(t, pages, 3)
+}
@@ -27,6 +27,11 @@ type Scraper struct {
 	Delay     int64
 	Blacklist []string
 	Async     bool
+	// AllowedDomains are the domains whose links are followed, including
+	// their subdomains. Only links to the current host are followed if empty.
+	AllowedDomains []string
+	// RespectRobotsTxt skips the pages disallowed by robots.txt.
+	RespectRobotsTxt bool
 }
 
 var _ tools.Tool = Scraper{}
@@ -99,6 +104,7 @@ func (s Scraper) Call(ctx context.Context, input string) (string, error) {
 		colly.MaxDepth(s.MaxDepth),
 		colly.Async(s.Async),
 	)
+	c.IgnoreRobotsTxt = !s.RespectRobotsTxt
 
 	err = c.Limit(&colly.LimitRule{
 		DomainGlob:  "*",
@@ -165,30 +171,11 @@ func (s Scraper) Call(ctx context.Context, input string) (string, error) {
 		link := e.Attr("href")
 		absoluteLink := e.Request.AbsoluteURL(link)
 
-		// Parse the link to get the hostname
-		u, err := url.Parse(absoluteLink)
-		if err != nil {
-			// Handle the error appropriately
+		u, ok := s.follow(absoluteLink, e.Request.URL)
+		if !ok {
 			return
 		}
 
-		// Check if the link's hostname matches the current request's hostname
-		if u.Hostname() != e.Request.URL.Hostname() {
-			return
-		}
-
-		// Check for redundant pages
-		for _, item := range s.Blacklist {
-			if strings.Contains(u.Path, item) {
-				return
-			}
-		}
-
-		// Normalize the path to treat '/' and '/index.html' as the same path
-		if u.Path == "/index.html" || u.Path == "" {
-			u.Path = "/"
-		}
-
 		// Only visit the page if it hasn't been visited yet
 		if !scrapedLinks[u.String()] {
 			err := c.Visit(u.String())
@@ -218,3 +205,51 @@ func (s Scraper) Call(ctx context.Context, input string) (string, error) {
 
 	return siteData.String(), nil
 }
+
+// Follows reports whether the Scraper follows a link found on the page at
+// the current URL.
+func (s Scraper) Follows(link string, current *url.URL) bool {
+	_, ok := s.follow(link, current)
+	return ok
+}
+
+// follow returns the normalized URL of a link found on the current page, and
+// whether the link should be followed: it must be on an allowed domain, or on
+// the current host if there are none, and not contain a blacklisted item.
+func (s Scraper) follow(link string, current *url.URL) (*url.URL, bool) {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, false
+	}
+
+	if len(s.AllowedDomains) == 0 && u.Hostname() != current.Hostname() {
+		return nil, false
+	}
+	if len(s.AllowedDomains) > 0 && !s.allowedDomain(u.Hostname()) {
+		return nil, false
+	}
+
+	// Check for redundant pages
+	for _, item := range s.Blacklist {
+		if strings.Contains(u.Path, item) {
+			return nil, false
+		}
+	}
+
+	// Normalize the path to treat '/' and '/index.html' as the same path
+	if u.Path == "/index.html" || u.Path == "" {
+		u.Path = "/"
+	}
+	return u, true
+}
+
+func (s Scraper) allowedDomain(host string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range s.AllowedDomains {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
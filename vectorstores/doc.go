@@ -5,6 +5,8 @@ and querying documents as vector embeddings.
 The main components of this package are:
 
 - VectorStore interface: a common interface for saving and querying vector embeddings of documents.
+- Deleter interface: implemented by vector stores that can delete documents by the ids
+they were added with.
 - Options: a set of options for similarity search and document addition.
 - Retriever: a retriever for vector stores that implements the schema.Retriever interface.
 
@@ -0,0 +1,3 @@
+// Package milvus contains an implementation of the vectorStore
+// interface using the milvus RESTful API.
+package milvus
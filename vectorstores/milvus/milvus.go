@@ -0,0 +1,179 @@
+package milvus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	// ErrMissingTextKey is returned in SimilaritySearch if an entity
+	// from the query is missing the text key.
+	ErrMissingTextKey = errors.New("missing text key in entity")
+	// ErrEmbedderWrongNumberVectors is returned when if the embedder returns a number
+	// of vectors that is not equal to the number of documents given.
+	ErrEmbedderWrongNumberVectors = errors.New(
+		"number of vectors from embedder does not match number of documents",
+	)
+	// ErrEmptyResponse is returned if the API gives an empty response.
+	ErrEmptyResponse         = errors.New("empty response")
+	ErrInvalidScoreThreshold = errors.New(
+		"score threshold must be between 0 and 1")
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+// Store is a wrapper around the milvus RESTful API.
+type Store struct {
+	embedder   embeddings.Embedder
+	httpClient *http.Client
+
+	rawURL         string
+	baseURL        *url.URL
+	token          string
+	dbName         string
+	collectionName string
+	textKey        string
+	primaryField   string
+	vectorField    string
+	metricType     string
+}
+
+var (
+	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.Deleter     = Store{}
+)
+
+// New creates a new Store with options. Options for url, collection name
+// and embedder must be set.
+func New(opts ...Option) (Store, error) {
+	return applyClientOptions(opts...)
+}
+
+// AddDocuments creates vector embeddings from the documents using the embedder
+// and inserts the entities into the milvus collection. The name space option
+// selects the partition to insert into. The collection is created with the
+// dimension of the embedder and the partition is created if they do not exist yet.
+// Entities are upserted if ids are set with vectorstores.WithIDs.
+func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+
+	if opts.IDs != nil && len(opts.IDs) != len(docs) {
+		return vectorstores.ErrMismatchIDsAndDocuments
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	if len(vectors) != len(docs) {
+		return ErrEmbedderWrongNumberVectors
+	}
+
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	if err := s.ensureCollection(ctx, len(vectors[0])); err != nil {
+		return err
+	}
+
+	if opts.NameSpace != "" {
+		if err := s.ensurePartition(ctx, opts.NameSpace); err != nil {
+			return err
+		}
+	}
+
+	metadatas := make([]map[string]any, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		metadata := make(map[string]any, len(docs[i].Metadata))
+		for key, value := range docs[i].Metadata {
+			metadata[key] = value
+		}
+		metadata[s.textKey] = texts[i]
+
+		metadatas = append(metadatas, metadata)
+	}
+
+	return s.insertEntities(ctx, opts.NameSpace, opts.IDs, vectors, metadatas)
+}
+
+// Delete deletes the entities with the ids. The name space option limits the
+// deletion to a partition.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.deleteEntities(ctx, s.getOptions(options...).NameSpace, ids)
+}
+
+// SimilaritySearch creates a vector embedding from the query using the embedder
+// and searches the collection for the most similar entities. The name space option
+// limits the search to a partition. Filters must be a milvus boolean expression
+// string, e.g. `city == "London" and year > 2020`.
+// See https://milvus.io/docs/boolean.md
+//
+// The score threshold is only applied for the "COSINE" and "IP" metric types.
+func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	opts := s.getOptions(options...)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.getFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.searchEntities(ctx, opts.NameSpace, vector, numDocuments, scoreThreshold, filter)
+}
+
+func (s Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+func (s Store) getScoreThreshold(opts vectorstores.Options) (float64, error) {
+	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1 {
+		return 0, ErrInvalidScoreThreshold
+	}
+	return opts.ScoreThreshold, nil
+}
+
+func (s Store) getFilter(opts vectorstores.Options) (string, error) {
+	if opts.Filters == nil {
+		return "", nil
+	}
+	filter, ok := opts.Filters.(string)
+	if !ok {
+		return "", ErrInvalidFilter
+	}
+	return filter, nil
+}
+
+func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
@@ -0,0 +1,325 @@
+package milvus_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/milvus"
+)
+
+// fakeEmbedder embeds texts as counts of a few fixed words.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, embed(text))
+	}
+	return vectors, nil
+}
+
+func (fakeEmbedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float64 {
+	words := []string{"tokyo", "japan", "potato", "food"}
+	v := make([]float64, len(words))
+	for i, w := range words {
+		v[i] = float64(strings.Count(strings.ToLower(text), w))
+	}
+	return v
+}
+
+// fakeMilvus is a minimal in-memory stand-in for the milvus v2 RESTful API.
+type fakeMilvus struct {
+	mu         sync.Mutex
+	dimension  int
+	partitions map[string]bool
+	entities   map[string][]map[string]any
+	tokens     []string
+}
+
+func newFakeMilvus() *fakeMilvus {
+	return &fakeMilvus{
+		partitions: map[string]bool{},
+		entities:   map[string][]map[string]any{},
+	}
+}
+
+func (f *fakeMilvus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tokens = append(f.tokens, r.Header.Get("Authorization"))
+
+	var req map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	switch r.URL.Path {
+	case "/v2/vectordb/collections/has":
+		writeData(w, map[string]any{"has": f.dimension != 0})
+	case "/v2/vectordb/collections/create":
+		f.dimension = int(req["dimension"].(float64))
+		writeData(w, map[string]any{})
+	case "/v2/vectordb/partitions/has":
+		writeData(w, map[string]any{"has": f.partitions[req["partitionName"].(string)]})
+	case "/v2/vectordb/partitions/create":
+		f.partitions[req["partitionName"].(string)] = true
+		writeData(w, map[string]any{})
+	case "/v2/vectordb/entities/insert":
+		if f.dimension == 0 {
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 100, "message": "collection not found"})
+			return
+		}
+		partition, _ := req["partitionName"].(string)
+		for _, e := range req["data"].([]any) {
+			f.entities[partition] = append(f.entities[partition], e.(map[string]any))
+		}
+		writeData(w, map[string]any{"insertCount": len(req["data"].([]any))})
+	case "/v2/vectordb/entities/upsert":
+		partition, _ := req["partitionName"].(string)
+		for _, e := range req["data"].([]any) {
+			entity := e.(map[string]any)
+			f.delete(partition, fmt.Sprintf("%q", entity["id"]))
+			f.entities[partition] = append(f.entities[partition], entity)
+		}
+		writeData(w, map[string]any{"upsertCount": len(req["data"].([]any))})
+	case "/v2/vectordb/entities/delete":
+		partition, _ := req["partitionName"].(string)
+		f.delete(partition, req["filter"].(string))
+		writeData(w, map[string]any{})
+	case "/v2/vectordb/entities/search":
+		f.search(w, req)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// delete deletes the entities whose quoted id appears in the filter.
+func (f *fakeMilvus) delete(partition, filter string) {
+	entities := f.entities[partition][:0]
+	for _, e := range f.entities[partition] {
+		if !strings.Contains(filter, fmt.Sprintf("%q", e["id"])) {
+			entities = append(entities, e)
+		}
+	}
+	f.entities[partition] = entities
+}
+
+func (f *fakeMilvus) search(w http.ResponseWriter, req map[string]any) {
+	partitions := []string{}
+	for p := range f.entities {
+		partitions = append(partitions, p)
+	}
+	if names, ok := req["partitionNames"].([]any); ok {
+		partitions = []string{names[0].(string)}
+	}
+	query := toFloats(req["data"].([]any)[0].([]any))
+	filter, _ := req["filter"].(string)
+
+	results := make([]map[string]any, 0)
+	for _, p := range partitions {
+		for _, e := range f.entities[p] {
+			if !matches(e, filter) {
+				continue
+			}
+			result := map[string]any{}
+			for k, v := range e {
+				result[k] = v
+			}
+			result["distance"] = cosine(query, toFloats(e["vector"].([]any)))
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i]["distance"].(float64) > results[j]["distance"].(float64)
+	})
+	if limit := int(req["limit"].(float64)); len(results) > limit {
+		results = results[:limit]
+	}
+	writeData(w, results)
+}
+
+// matches supports filters of the form `key == "value"`.
+func matches(entity map[string]any, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	parts := strings.SplitN(filter, "==", 2)
+	key := strings.TrimSpace(parts[0])
+	value := strings.Trim(strings.TrimSpace(parts[1]), `"`)
+	return entity[key] == value
+}
+
+func toFloats(values []any) []float64 {
+	v := make([]float64, len(values))
+	for i, value := range values {
+		v[i] = value.(float64)
+	}
+	return v
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "data": data})
+}
+
+func TestMilvusStore(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeMilvus()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := milvus.New(
+		milvus.WithURL(server.URL),
+		milvus.WithCollectionName("test"),
+		milvus.WithEmbedder(fakeEmbedder{}),
+		milvus.WithToken("root:Milvus"),
+	)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo japan", Metadata: map[string]any{"kind": "city"}},
+		{PageContent: "potato food", Metadata: map[string]any{"kind": "food"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, fake.dimension)
+	require.Equal(t, "Bearer root:Milvus", fake.tokens[0])
+
+	docs, err := store.SimilaritySearch(context.Background(), "japan", 1)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo japan", docs[0].PageContent)
+	require.Equal(t, map[string]any{"kind": "city"}, docs[0].Metadata)
+
+	docs, err = store.SimilaritySearch(context.Background(), "japan", 2,
+		vectorstores.WithFilters(`kind == "food"`))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "potato food", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(context.Background(), "tokyo", 2,
+		vectorstores.WithScoreThreshold(0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	_, err = store.SimilaritySearch(context.Background(), "tokyo", 2,
+		vectorstores.WithFilters(map[string]any{"kind": "food"}))
+	require.ErrorIs(t, err, milvus.ErrInvalidFilter)
+}
+
+func TestMilvusStorePartitions(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeMilvus()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := milvus.New(
+		milvus.WithURL(server.URL),
+		milvus.WithCollectionName("test"),
+		milvus.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+	}, vectorstores.WithNameSpace("a"))
+	require.NoError(t, err)
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo japan"},
+	}, vectorstores.WithNameSpace("b"))
+	require.NoError(t, err)
+	require.True(t, fake.partitions["a"])
+	require.True(t, fake.partitions["b"])
+
+	docs, err := store.SimilaritySearch(context.Background(), "tokyo", 5, vectorstores.WithNameSpace("b"))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo japan", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(context.Background(), "tokyo", 5)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+}
+
+func TestMilvusStoreIDs(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeMilvus()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := milvus.New(
+		milvus.WithURL(server.URL),
+		milvus.WithCollectionName("test"),
+		milvus.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	}, vectorstores.WithIDs([]string{"a", "b"}))
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo japan"},
+	}, vectorstores.WithIDs([]string{"a"}))
+	require.NoError(t, err)
+	require.Len(t, fake.entities[""], 2)
+
+	require.NoError(t, store.Delete(context.Background(), []string{"b"}))
+	require.Len(t, fake.entities[""], 1)
+	require.Equal(t, "tokyo japan", fake.entities[""][0]["text"])
+
+	err = store.AddDocuments(context.Background(), []schema.Document{{PageContent: "tokyo"}},
+		vectorstores.WithIDs([]string{"a", "b"}))
+	require.ErrorIs(t, err, vectorstores.ErrMismatchIDsAndDocuments)
+}
+
+func TestMilvusAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 1800, "message": "user hasn't authenticated"})
+	}))
+	defer server.Close()
+
+	store, err := milvus.New(
+		milvus.WithURL(server.URL),
+		milvus.WithCollectionName("test"),
+		milvus.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	_, err = store.SimilaritySearch(context.Background(), "tokyo", 1)
+	var apiErr milvus.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 1800, apiErr.Code)
+}
@@ -0,0 +1,142 @@
+package milvus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+const (
+	_milvusEnvVrName     = "MILVUS_TOKEN"
+	_defaultTextKey      = "text"
+	_defaultPrimaryField = "id"
+	_defaultVectorField  = "vector"
+	_defaultMetricType   = "COSINE"
+	_idMaxLength         = 64
+)
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Option is a function type that can be used to modify the client.
+type Option func(p *Store)
+
+// WithURL is an option for setting the url of the milvus server, e.g.
+// http://localhost:19530. Must be set.
+func WithURL(milvusURL string) Option {
+	return func(p *Store) {
+		p.rawURL = milvusURL
+	}
+}
+
+// WithCollectionName is an option for specifying the collection name. Must be set.
+func WithCollectionName(name string) Option {
+	return func(p *Store) {
+		p.collectionName = name
+	}
+}
+
+// WithDatabaseName is an option for specifying the database the collection
+// belongs to. If not set the server default database is used.
+func WithDatabaseName(name string) Option {
+	return func(p *Store) {
+		p.dbName = name
+	}
+}
+
+// WithEmbedder is an option for setting the embedder to use. Must be set.
+func WithEmbedder(e embeddings.Embedder) Option {
+	return func(p *Store) {
+		p.embedder = e
+	}
+}
+
+// WithToken is an option for setting the token used to authenticate. The token
+// is either an api key or "username:password". If the option is not set the
+// token is read from the MILVUS_TOKEN environment variable. A milvus server
+// without authentication does not require a token.
+func WithToken(token string) Option {
+	return func(p *Store) {
+		p.token = token
+	}
+}
+
+// WithTextKey is an option for setting the field that stores the text of the
+// document the vector represents.
+func WithTextKey(textKey string) Option {
+	return func(p *Store) {
+		p.textKey = textKey
+	}
+}
+
+// WithPrimaryField is an option for setting the name of the primary key field.
+func WithPrimaryField(field string) Option {
+	return func(p *Store) {
+		p.primaryField = field
+	}
+}
+
+// WithVectorField is an option for setting the name of the vector field.
+func WithVectorField(field string) Option {
+	return func(p *Store) {
+		p.vectorField = field
+	}
+}
+
+// WithMetricType is an option for setting the metric type used when a
+// collection is created. One of "COSINE", "IP" or "L2". Defaults to "COSINE".
+func WithMetricType(metricType string) Option {
+	return func(p *Store) {
+		p.metricType = metricType
+	}
+}
+
+// WithHTTPClient is an option for setting the http client used to talk to the
+// milvus server.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Store) {
+		p.httpClient = client
+	}
+}
+
+func applyClientOptions(opts ...Option) (Store, error) {
+	o := &Store{
+		textKey:      _defaultTextKey,
+		primaryField: _defaultPrimaryField,
+		vectorField:  _defaultVectorField,
+		metricType:   _defaultMetricType,
+		httpClient:   http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.rawURL == "" {
+		return Store{}, fmt.Errorf("%w: missing url", ErrInvalidOptions)
+	}
+
+	u, err := url.Parse(o.rawURL)
+	if err != nil {
+		return Store{}, fmt.Errorf("%w: invalid url: %s", ErrInvalidOptions, err.Error())
+	}
+	o.baseURL = u
+
+	if o.collectionName == "" {
+		return Store{}, fmt.Errorf("%w: missing collection name", ErrInvalidOptions)
+	}
+
+	if o.embedder == nil {
+		return Store{}, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
+	}
+
+	if o.token == "" {
+		o.token = os.Getenv(_milvusEnvVrName)
+	}
+
+	return *o, nil
+}
@@ -0,0 +1,247 @@
+package milvus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// APIError is an error type returned if the status code from the rest
+// api is not 200 or the response carries a non zero code.
+type APIError struct {
+	Task    string
+	Code    int
+	Message string
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("%s: code %d: %s", e.Task, e.Code, e.Message)
+}
+
+type response struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (s Store) ensureCollection(ctx context.Context, dimension int) error {
+	var has struct {
+		Has bool `json:"has"`
+	}
+	err := s.call(ctx, "checking collection", "/v2/vectordb/collections/has", map[string]any{
+		"collectionName": s.collectionName,
+	}, &has)
+	if err != nil || has.Has {
+		return err
+	}
+
+	return s.call(ctx, "creating collection", "/v2/vectordb/collections/create", map[string]any{
+		"collectionName":   s.collectionName,
+		"dimension":        dimension,
+		"metricType":       s.metricType,
+		"idType":           "VarChar",
+		"autoId":           false,
+		"primaryFieldName": s.primaryField,
+		"vectorFieldName":  s.vectorField,
+		"params": map[string]any{
+			"max_length":         _idMaxLength,
+			"enableDynamicField": true,
+		},
+	}, nil)
+}
+
+func (s Store) ensurePartition(ctx context.Context, partition string) error {
+	payload := map[string]any{
+		"collectionName": s.collectionName,
+		"partitionName":  partition,
+	}
+
+	var has struct {
+		Has bool `json:"has"`
+	}
+	err := s.call(ctx, "checking partition", "/v2/vectordb/partitions/has", payload, &has)
+	if err != nil || has.Has {
+		return err
+	}
+
+	return s.call(ctx, "creating partition", "/v2/vectordb/partitions/create", payload, nil)
+}
+
+func (s Store) insertEntities(
+	ctx context.Context,
+	partition string,
+	ids []string,
+	vectors [][]float64,
+	metadatas []map[string]any,
+) error {
+	data := make([]map[string]any, 0, len(vectors))
+	for i := 0; i < len(vectors); i++ {
+		entity := make(map[string]any, len(metadatas[i])+2)
+		for key, value := range metadatas[i] {
+			entity[key] = value
+		}
+		entity[s.primaryField] = uuid.New().String()
+		if ids != nil {
+			entity[s.primaryField] = ids[i]
+		}
+		entity[s.vectorField] = vectors[i]
+
+		data = append(data, entity)
+	}
+
+	payload := map[string]any{
+		"collectionName": s.collectionName,
+		"data":           data,
+	}
+	if partition != "" {
+		payload["partitionName"] = partition
+	}
+
+	if ids != nil {
+		return s.call(ctx, "upserting entities", "/v2/vectordb/entities/upsert", payload, nil)
+	}
+	return s.call(ctx, "inserting entities", "/v2/vectordb/entities/insert", payload, nil)
+}
+
+func (s Store) deleteEntities(ctx context.Context, partition string, ids []string) error {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, strconv.Quote(id))
+	}
+
+	payload := map[string]any{
+		"collectionName": s.collectionName,
+		"filter":         fmt.Sprintf("%s in [%s]", s.primaryField, strings.Join(quoted, ", ")),
+	}
+	if partition != "" {
+		payload["partitionName"] = partition
+	}
+
+	return s.call(ctx, "deleting entities", "/v2/vectordb/entities/delete", payload, nil)
+}
+
+func (s Store) searchEntities(
+	ctx context.Context,
+	partition string,
+	vector []float64,
+	numDocuments int,
+	scoreThreshold float64,
+	filter string,
+) ([]schema.Document, error) {
+	payload := map[string]any{
+		"collectionName": s.collectionName,
+		"data":           [][]float64{vector},
+		"annsField":      s.vectorField,
+		"limit":          numDocuments,
+		"outputFields":   []string{"*"},
+	}
+	if partition != "" {
+		payload["partitionNames"] = []string{partition}
+	}
+	if filter != "" {
+		payload["filter"] = filter
+	}
+
+	var entities []map[string]any
+	if err := s.call(ctx, "searching entities", "/v2/vectordb/entities/search", payload, &entities); err != nil {
+		return nil, err
+	}
+
+	if entities == nil {
+		return nil, ErrEmptyResponse
+	}
+
+	docs := make([]schema.Document, 0, len(entities))
+	for _, entity := range entities {
+		distance, _ := entity["distance"].(float64)
+		if scoreThreshold != 0 && s.higherIsBetter() && distance < scoreThreshold {
+			continue
+		}
+
+		pageContent, ok := entity[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(entity, s.textKey)
+		delete(entity, s.primaryField)
+		delete(entity, s.vectorField)
+		delete(entity, "distance")
+
+		docs = append(docs, schema.Document{
+			PageContent: pageContent,
+			Metadata:    entity,
+		})
+	}
+
+	return docs, nil
+}
+
+// higherIsBetter reports whether larger distances mean more similar entities
+// for the metric type of the store.
+func (s Store) higherIsBetter() bool {
+	return s.metricType == "COSINE" || s.metricType == "IP"
+}
+
+// call posts the payload to the endpoint and decodes the data of the response
+// into out if out is not nil.
+func (s Store) call(ctx context.Context, task, path string, payload map[string]any, out any) error {
+	if s.dbName != "" {
+		payload["dbName"] = s.dbName
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	u := *s.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(payloadBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+
+	if r.StatusCode != http.StatusOK {
+		return APIError{Task: task, Code: r.StatusCode, Message: string(body)}
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return err
+	}
+
+	if resp.Code != 0 && resp.Code != http.StatusOK {
+		return APIError{Task: task, Code: resp.Code, Message: resp.Message}
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
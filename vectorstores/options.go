@@ -11,6 +11,15 @@ type Options struct {
 	ScoreThreshold float64
 	Filters        any
 	Embedder       embeddings.Embedder
+	HybridSearch   *HybridSearch
+	IDs            []string
+}
+
+// HybridSearch holds the settings for a search combining keyword and vector search.
+type HybridSearch struct {
+	// Alpha weights vector search against keyword search. An alpha of 1 is a pure
+	// vector search and an alpha of 0 is a pure keyword search.
+	Alpha float64
 }
 
 // WithNameSpace returns an Option for setting the name space.
@@ -44,3 +53,21 @@ func WithEmbedder(embedder embeddings.Embedder) Option {
 		o.Embedder = embedder
 	}
 }
+
+// WithHybridSearch returns an Option for searching with both keywords and vectors
+// in vector stores that support it, like weaviate. Alpha weights vector search
+// against keyword search.
+func WithHybridSearch(alpha float64) Option {
+	return func(o *Options) {
+		o.HybridSearch = &HybridSearch{Alpha: alpha}
+	}
+}
+
+// WithIDs returns an Option for setting the ids of the documents added with
+// AddDocuments, one per document. Stores replace existing documents with the
+// same id. Without ids stores generate random ones.
+func WithIDs(ids []string) Option {
+	return func(o *Options) {
+		o.IDs = ids
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/tls"
 	"fmt"
 
-	"github.com/google/uuid"
 	"github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"github.com/tmc/langchaingo/schema"
 	"google.golang.org/grpc"
@@ -44,6 +43,7 @@ func (s Store) getGRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
 
 func (s Store) grpcUpsert(
 	ctx context.Context,
+	ids []string,
 	vectors [][]float64,
 	metadatas []map[string]any,
 	nameSpace string,
@@ -58,7 +58,7 @@ func (s Store) grpcUpsert(
 		pineconeVectors = append(
 			pineconeVectors,
 			&pinecone_grpc.Vector{
-				Id:       uuid.New().String(),
+				Id:       ids[i],
 				Values:   float64ToFloat32(vectors[i]),
 				Metadata: metadataStruct,
 			},
@@ -73,6 +73,15 @@ func (s Store) grpcUpsert(
 	return err
 }
 
+func (s Store) grpcDelete(ctx context.Context, ids []string, nameSpace string) error {
+	_, err := s.client.Delete(ctx, &pinecone_grpc.DeleteRequest{
+		Ids:       ids,
+		Namespace: nameSpace,
+	})
+
+	return err
+}
+
 func (s Store) grpcQuery(
 	ctx context.Context,
 	vector []float64,
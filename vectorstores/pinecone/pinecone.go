@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
@@ -41,7 +42,10 @@ type Store struct {
 	useGRPC     bool
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.Deleter     = Store{}
+)
 
 // New creates a new Store with options. Options for index name, environment, project name
 // and embedder must be set.
@@ -72,6 +76,11 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 
 	nameSpace := s.getNameSpace(opts)
 
+	ids, err := s.getIDs(opts, len(docs))
+	if err != nil {
+		return err
+	}
+
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -98,10 +107,25 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 	}
 
 	if s.useGRPC {
-		return s.grpcUpsert(ctx, vectors, metadatas, nameSpace)
+		return s.grpcUpsert(ctx, ids, vectors, metadatas, nameSpace)
 	}
 
-	return s.restUpsert(ctx, vectors, metadatas, nameSpace)
+	return s.restUpsert(ctx, ids, vectors, metadatas, nameSpace)
+}
+
+// Delete deletes the vectors with the ids from the name space.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	if s.useGRPC {
+		return s.grpcDelete(ctx, ids, nameSpace)
+	}
+
+	return s.restDelete(ctx, ids, nameSpace)
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
@@ -143,6 +167,20 @@ func (s Store) getNameSpace(opts vectorstores.Options) string {
 	return s.nameSpace
 }
 
+func (s Store) getIDs(opts vectorstores.Options, numDocs int) ([]string, error) {
+	if opts.IDs == nil {
+		ids := make([]string, 0, numDocs)
+		for i := 0; i < numDocs; i++ {
+			ids = append(ids, uuid.New().String())
+		}
+		return ids, nil
+	}
+	if len(opts.IDs) != numDocs {
+		return nil, vectorstores.ErrMismatchIDsAndDocuments
+	}
+	return opts.IDs, nil
+}
+
 func (s Store) getScoreThreshold(opts vectorstores.Options) (float64,
 	error,
 ) {
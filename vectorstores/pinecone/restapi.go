@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"net/url"
 
-	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/schema"
 )
 
@@ -47,6 +46,7 @@ type upsertPayload struct {
 
 func (s Store) restUpsert(
 	ctx context.Context,
+	ids []string,
 	vectors [][]float64,
 	metadatas []map[string]any,
 	nameSpace string,
@@ -56,7 +56,7 @@ func (s Store) restUpsert(
 		v = append(v, vector{
 			Values:   vectors[i],
 			Metadata: metadatas[i],
-			ID:       uuid.New().String(),
+			ID:       ids[i],
 		})
 	}
 
@@ -84,6 +84,31 @@ func (s Store) restUpsert(
 	return newAPIError("upserting vectors", body)
 }
 
+type deletePayload struct {
+	IDs       []string `json:"ids"`
+	Namespace string   `json:"namespace"`
+}
+
+func (s Store) restDelete(ctx context.Context, ids []string, nameSpace string) error {
+	body, status, err := doRequest(
+		ctx,
+		deletePayload{IDs: ids, Namespace: nameSpace},
+		getEndpoint(s.indexName, s.projectName, s.environment)+"/vectors/delete",
+		s.apiKey,
+		http.MethodPost,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("deleting vectors", body)
+}
+
 type sparseValues struct {
 	Indices []int     `json:"indices"`
 	Values  []float64 `json:"values"`
@@ -0,0 +1,3 @@
+// Package qdrant contains an implementation of the vectorStore
+// interface using the qdrant REST API.
+package qdrant
@@ -0,0 +1,116 @@
+package qdrant
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+const (
+	_qdrantEnvVrName = "QDRANT_API_KEY"
+	_defaultTextKey  = "text"
+	_defaultDistance = "Cosine"
+)
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Option is a function type that can be used to modify the client.
+type Option func(p *Store)
+
+// WithURL is an option for setting the url of the qdrant server, e.g.
+// http://localhost:6333. Must be set.
+func WithURL(qdrantURL string) Option {
+	return func(p *Store) {
+		p.rawURL = qdrantURL
+	}
+}
+
+// WithCollectionName is an option for specifying the collection name. Must be set.
+// The collection is used when no name space is given to AddDocuments or
+// SimilaritySearch.
+func WithCollectionName(name string) Option {
+	return func(p *Store) {
+		p.collectionName = name
+	}
+}
+
+// WithEmbedder is an option for setting the embedder to use. Must be set.
+func WithEmbedder(e embeddings.Embedder) Option {
+	return func(p *Store) {
+		p.embedder = e
+	}
+}
+
+// WithAPIKey is an option for setting the api key. If the option is not set
+// the api key is read from the QDRANT_API_KEY environment variable. A local
+// qdrant server does not require an api key.
+func WithAPIKey(apiKey string) Option {
+	return func(p *Store) {
+		p.apiKey = apiKey
+	}
+}
+
+// WithTextKey is an option for setting the text key in the payload of the points
+// in the collection. The text key stores the text of the document the vector represents.
+func WithTextKey(textKey string) Option {
+	return func(p *Store) {
+		p.textKey = textKey
+	}
+}
+
+// WithDistance is an option for setting the distance function used when a
+// collection is created. One of "Cosine", "Euclid" or "Dot". Defaults to "Cosine".
+func WithDistance(distance string) Option {
+	return func(p *Store) {
+		p.distance = distance
+	}
+}
+
+// WithHTTPClient is an option for setting the http client used to talk to the
+// qdrant server.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Store) {
+		p.httpClient = client
+	}
+}
+
+func applyClientOptions(opts ...Option) (Store, error) {
+	o := &Store{
+		textKey:    _defaultTextKey,
+		distance:   _defaultDistance,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.rawURL == "" {
+		return Store{}, fmt.Errorf("%w: missing url", ErrInvalidOptions)
+	}
+
+	u, err := url.Parse(o.rawURL)
+	if err != nil {
+		return Store{}, fmt.Errorf("%w: invalid url: %s", ErrInvalidOptions, err.Error())
+	}
+	o.baseURL = u
+
+	if o.collectionName == "" {
+		return Store{}, fmt.Errorf("%w: missing collection name", ErrInvalidOptions)
+	}
+
+	if o.embedder == nil {
+		return Store{}, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
+	}
+
+	if o.apiKey == "" {
+		o.apiKey = os.Getenv(_qdrantEnvVrName)
+	}
+
+	return *o, nil
+}
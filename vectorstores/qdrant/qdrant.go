@@ -0,0 +1,181 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+var (
+	// ErrMissingTextKey is returned in SimilaritySearch if a point
+	// from the query is missing the text key.
+	ErrMissingTextKey = errors.New("missing text key in point payload")
+	// ErrEmbedderWrongNumberVectors is returned when if the embedder returns a number
+	// of vectors that is not equal to the number of documents given.
+	ErrEmbedderWrongNumberVectors = errors.New(
+		"number of vectors from embedder does not match number of documents",
+	)
+	// ErrEmptyResponse is returned if the API gives an empty response.
+	ErrEmptyResponse         = errors.New("empty response")
+	ErrInvalidScoreThreshold = errors.New(
+		"score threshold must be between 0 and 1")
+)
+
+// Store is a wrapper around the qdrant REST API.
+type Store struct {
+	embedder   embeddings.Embedder
+	httpClient *http.Client
+
+	rawURL         string
+	baseURL        *url.URL
+	apiKey         string
+	collectionName string
+	textKey        string
+	distance       string
+}
+
+var (
+	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.Deleter     = Store{}
+)
+
+// New creates a new Store with options. Options for url, collection name
+// and embedder must be set.
+func New(opts ...Option) (Store, error) {
+	return applyClientOptions(opts...)
+}
+
+// AddDocuments creates vector embeddings from the documents using the embedder
+// and upserts the points to the qdrant collection. The name space option selects
+// the collection to use. The collection is created with the dimension of the
+// embedder if it does not exist yet. Ids set with vectorstores.WithIDs must be
+// UUIDs or unsigned integers.
+func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+
+	ids, err := s.getIDs(opts, len(docs))
+	if err != nil {
+		return err
+	}
+
+	collection := s.getCollection(opts)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	if len(vectors) != len(docs) {
+		return ErrEmbedderWrongNumberVectors
+	}
+
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	if err := s.ensureCollection(ctx, collection, len(vectors[0])); err != nil {
+		return err
+	}
+
+	payloads := make([]map[string]any, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		payload := make(map[string]any, len(docs[i].Metadata))
+		for key, value := range docs[i].Metadata {
+			payload[key] = value
+		}
+		payload[s.textKey] = texts[i]
+
+		payloads = append(payloads, payload)
+	}
+
+	return s.upsertPoints(ctx, collection, ids, vectors, payloads)
+}
+
+// Delete deletes the points with the ids from the collection selected by the
+// name space option.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.deletePoints(ctx, s.getCollection(s.getOptions(options...)), ids)
+}
+
+// SimilaritySearch creates a vector embedding from the query using the embedder
+// and searches the collection for the most similar points. Filters are passed
+// to qdrant as a payload filter, e.g.
+//
+//	map[string]any{"must": []map[string]any{{"key": "city", "match": map[string]any{"value": "London"}}}}
+//
+// See https://qdrant.tech/documentation/concepts/filtering/
+func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	opts := s.getOptions(options...)
+
+	collection := s.getCollection(opts)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.searchPoints(ctx, collection, vector, numDocuments, scoreThreshold, opts.Filters)
+}
+
+func (s Store) getCollection(opts vectorstores.Options) string {
+	if opts.NameSpace != "" {
+		return opts.NameSpace
+	}
+	return s.collectionName
+}
+
+func (s Store) getIDs(opts vectorstores.Options, numDocs int) ([]string, error) {
+	if opts.IDs == nil {
+		ids := make([]string, 0, numDocs)
+		for i := 0; i < numDocs; i++ {
+			ids = append(ids, uuid.New().String())
+		}
+		return ids, nil
+	}
+	if len(opts.IDs) != numDocs {
+		return nil, vectorstores.ErrMismatchIDsAndDocuments
+	}
+	return opts.IDs, nil
+}
+
+func (s Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+func (s Store) getScoreThreshold(opts vectorstores.Options) (float64, error) {
+	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1 {
+		return 0, ErrInvalidScoreThreshold
+	}
+	return opts.ScoreThreshold, nil
+}
+
+func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
@@ -0,0 +1,343 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/qdrant"
+)
+
+// fakeEmbedder embeds texts as counts of a few fixed words.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, embed(text))
+	}
+	return vectors, nil
+}
+
+func (fakeEmbedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	return embed(text), nil
+}
+
+func embed(text string) []float64 {
+	words := []string{"tokyo", "japan", "potato", "food"}
+	v := make([]float64, len(words))
+	for i, w := range words {
+		v[i] = float64(strings.Count(strings.ToLower(text), w))
+	}
+	return v
+}
+
+type fakePoint struct {
+	ID      any            `json:"id"`
+	Vector  []float64      `json:"vector"`
+	Payload map[string]any `json:"payload"`
+}
+
+// fakeQdrant is a minimal in-memory stand-in for the qdrant REST API.
+type fakeQdrant struct {
+	mu          sync.Mutex
+	collections map[string]int
+	points      map[string][]fakePoint
+	apiKeys     []string
+}
+
+func newFakeQdrant() *fakeQdrant {
+	return &fakeQdrant{
+		collections: map[string]int{},
+		points:      map[string][]fakePoint{},
+	}
+}
+
+func (f *fakeQdrant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.apiKeys = append(f.apiKeys, r.Header.Get("api-key"))
+
+	parts := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	if len(parts) < 2 || parts[0] != "collections" {
+		http.NotFound(w, r)
+		return
+	}
+	name, err := url.PathUnescape(parts[1])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		if _, ok := f.collections[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeResult(w, map[string]any{})
+	case len(parts) == 2 && r.Method == http.MethodPut:
+		var req struct {
+			Vectors struct {
+				Size int `json:"size"`
+			} `json:"vectors"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.collections[name] = req.Vectors.Size
+		writeResult(w, true)
+	case len(parts) == 3 && r.Method == http.MethodPut:
+		var req struct {
+			Points []fakePoint `json:"points"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, p := range req.Points {
+			f.deletePoint(name, p.ID)
+			f.points[name] = append(f.points[name], p)
+		}
+		writeResult(w, map[string]any{"status": "completed"})
+	case len(parts) == 4 && parts[3] == "delete":
+		var req struct {
+			Points []any `json:"points"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, id := range req.Points {
+			f.deletePoint(name, id)
+		}
+		writeResult(w, map[string]any{"status": "completed"})
+	case len(parts) == 4 && parts[3] == "search":
+		f.search(w, r, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeQdrant) deletePoint(name string, id any) {
+	points := f.points[name][:0]
+	for _, p := range f.points[name] {
+		if p.ID != id {
+			points = append(points, p)
+		}
+	}
+	f.points[name] = points
+}
+
+func (f *fakeQdrant) search(w http.ResponseWriter, r *http.Request, name string) {
+	if _, ok := f.collections[name]; !ok {
+		http.Error(w, `{"status":{"error":"Not found"}}`, http.StatusNotFound)
+		return
+	}
+	var req struct {
+		Vector         []float64      `json:"vector"`
+		Limit          int            `json:"limit"`
+		Filter         map[string]any `json:"filter"`
+		ScoreThreshold float64        `json:"score_threshold"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	type scored struct {
+		Score   float64        `json:"score"`
+		Payload map[string]any `json:"payload"`
+	}
+	results := make([]scored, 0)
+	for _, p := range f.points[name] {
+		if !matches(p.Payload, req.Filter) {
+			continue
+		}
+		var score float64
+		for i := range p.Vector {
+			score += p.Vector[i] * req.Vector[i]
+		}
+		if score < req.ScoreThreshold {
+			continue
+		}
+		results = append(results, scored{Score: score, Payload: p.Payload})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > req.Limit {
+		results = results[:req.Limit]
+	}
+	writeResult(w, results)
+}
+
+// matches supports the "must" + "match.value" subset of qdrant filters.
+func matches(payload map[string]any, filter map[string]any) bool {
+	must, _ := filter["must"].([]any)
+	for _, c := range must {
+		cond, _ := c.(map[string]any)
+		key, _ := cond["key"].(string)
+		match, _ := cond["match"].(map[string]any)
+		if payload[key] != match["value"] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "status": "ok"})
+}
+
+func TestQdrantStore(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeQdrant()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := qdrant.New(
+		qdrant.WithURL(server.URL),
+		qdrant.WithCollectionName("test"),
+		qdrant.WithEmbedder(fakeEmbedder{}),
+		qdrant.WithAPIKey("secret"),
+	)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo japan", Metadata: map[string]any{"kind": "city"}},
+		{PageContent: "potato food", Metadata: map[string]any{"kind": "food"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, fake.collections["test"])
+	require.Equal(t, "secret", fake.apiKeys[0])
+
+	docs, err := store.SimilaritySearch(context.Background(), "japan", 1)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo japan", docs[0].PageContent)
+	require.Equal(t, "city", docs[0].Metadata["kind"])
+
+	docs, err = store.SimilaritySearch(context.Background(), "japan food", 2,
+		vectorstores.WithFilters(map[string]any{
+			"must": []map[string]any{{"key": "kind", "match": map[string]any{"value": "food"}}},
+		}))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "potato food", docs[0].PageContent)
+
+	docs, err = store.SimilaritySearch(context.Background(), "tokyo", 2,
+		vectorstores.WithScoreThreshold(0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	_, err = store.SimilaritySearch(context.Background(), "tokyo", 2,
+		vectorstores.WithScoreThreshold(1.5))
+	require.ErrorIs(t, err, qdrant.ErrInvalidScoreThreshold)
+}
+
+func TestQdrantStoreNameSpace(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeQdrant()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := qdrant.New(
+		qdrant.WithURL(server.URL),
+		qdrant.WithCollectionName("default"),
+		qdrant.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+	}, vectorstores.WithNameSpace("other"))
+	require.NoError(t, err)
+	require.Contains(t, fake.collections, "other")
+	require.NotContains(t, fake.collections, "default")
+
+	docs, err := store.SimilaritySearch(context.Background(), "tokyo", 1, vectorstores.WithNameSpace("other"))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	_, err = store.SimilaritySearch(context.Background(), "tokyo", 1)
+	require.Error(t, err)
+	require.ErrorAs(t, err, &qdrant.APIError{})
+}
+
+func TestQdrantStoreIDs(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeQdrant()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := qdrant.New(
+		qdrant.WithURL(server.URL),
+		qdrant.WithCollectionName("test"),
+		qdrant.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	ids := []string{"8e0f4bb0-7c1a-4d8e-9a36-2d4c6b1f0a01", "8e0f4bb0-7c1a-4d8e-9a36-2d4c6b1f0a02"}
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	}, vectorstores.WithIDs(ids))
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo japan"},
+	}, vectorstores.WithIDs(ids[:1]))
+	require.NoError(t, err)
+	require.Len(t, fake.points["test"], 2)
+
+	require.NoError(t, store.Delete(context.Background(), ids[1:]))
+	require.Len(t, fake.points["test"], 1)
+	require.Equal(t, "tokyo japan", fake.points["test"][0].Payload["text"])
+
+	require.NoError(t, store.Delete(context.Background(), ids, vectorstores.WithNameSpace("missing")))
+
+	err = store.AddDocuments(context.Background(), []schema.Document{{PageContent: "tokyo"}}, vectorstores.WithIDs(ids))
+	require.ErrorIs(t, err, vectorstores.ErrMismatchIDsAndDocuments)
+
+	// Unsigned integer ids are sent as JSON numbers.
+	err = store.AddDocuments(context.Background(), []schema.Document{{PageContent: "potato"}},
+		vectorstores.WithIDs([]string{"42"}))
+	require.NoError(t, err)
+	require.Len(t, fake.points["test"], 2)
+	require.Equal(t, float64(42), fake.points["test"][1].ID)
+
+	require.NoError(t, store.Delete(context.Background(), []string{"42"}))
+	require.Len(t, fake.points["test"], 1)
+}
+
+func TestQdrantCollectionNameEscaped(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeQdrant()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := qdrant.New(
+		qdrant.WithURL(server.URL),
+		qdrant.WithCollectionName("team/docs?x"),
+		qdrant.WithEmbedder(fakeEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, store.AddDocuments(context.Background(), []schema.Document{{PageContent: "tokyo"}}))
+	require.Len(t, fake.points["team/docs?x"], 1)
+}
+
+func TestQdrantInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := qdrant.New(qdrant.WithCollectionName("test"), qdrant.WithEmbedder(fakeEmbedder{}))
+	require.ErrorIs(t, err, qdrant.ErrInvalidOptions)
+
+	_, err = qdrant.New(qdrant.WithURL("http://localhost:6333"), qdrant.WithEmbedder(fakeEmbedder{}))
+	require.ErrorIs(t, err, qdrant.ErrInvalidOptions)
+
+	_, err = qdrant.New(qdrant.WithURL("http://localhost:6333"), qdrant.WithCollectionName("test"))
+	require.ErrorIs(t, err, qdrant.ErrInvalidOptions)
+}
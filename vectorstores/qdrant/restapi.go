@@ -0,0 +1,283 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// APIError is an error type returned if the status code from the rest
+// api is not 200.
+type APIError struct {
+	Task    string
+	Message string
+}
+
+func newAPIError(task string, body io.ReadCloser) APIError {
+	buf := new(bytes.Buffer)
+	_, err := io.Copy(buf, body)
+	if err != nil {
+		return APIError{Task: "reading body of error message", Message: err.Error()}
+	}
+
+	return APIError{Task: task, Message: buf.String()}
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Task, e.Message)
+}
+
+type vectorParams struct {
+	Size     int    `json:"size"`
+	Distance string `json:"distance"`
+}
+
+type createCollectionPayload struct {
+	Vectors vectorParams `json:"vectors"`
+}
+
+func (s Store) ensureCollection(ctx context.Context, collection string, dimension int) error {
+	body, status, err := s.doRequest(ctx, nil, s.endpoint("collections", collection), http.MethodGet)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	switch status {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return s.createCollection(ctx, collection, dimension)
+	default:
+		return newAPIError("getting collection", body)
+	}
+}
+
+func (s Store) createCollection(ctx context.Context, collection string, dimension int) error {
+	payload := createCollectionPayload{
+		Vectors: vectorParams{
+			Size:     dimension,
+			Distance: s.distance,
+		},
+	}
+
+	body, status, err := s.doRequest(ctx, payload, s.endpoint("collections", collection), http.MethodPut)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("creating collection", body)
+}
+
+type point struct {
+	ID      any            `json:"id"`
+	Vector  []float64      `json:"vector"`
+	Payload map[string]any `json:"payload"`
+}
+
+type upsertPayload struct {
+	Points []point `json:"points"`
+}
+
+func (s Store) upsertPoints(
+	ctx context.Context,
+	collection string,
+	ids []string,
+	vectors [][]float64,
+	payloads []map[string]any,
+) error {
+	points := make([]point, 0, len(vectors))
+	for i := 0; i < len(vectors); i++ {
+		points = append(points, point{
+			ID:      pointID(ids[i]),
+			Vector:  vectors[i],
+			Payload: payloads[i],
+		})
+	}
+
+	body, status, err := s.doRequest(
+		ctx,
+		upsertPayload{Points: points},
+		s.endpoint("collections", collection, "points")+"?wait=true",
+		http.MethodPut,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("upserting points", body)
+}
+
+type deletePayload struct {
+	Points []any `json:"points"`
+}
+
+func (s Store) deletePoints(ctx context.Context, collection string, ids []string) error {
+	points := make([]any, 0, len(ids))
+	for _, id := range ids {
+		points = append(points, pointID(id))
+	}
+	body, status, err := s.doRequest(
+		ctx,
+		deletePayload{Points: points},
+		s.endpoint("collections", collection, "points", "delete")+"?wait=true",
+		http.MethodPost,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Nothing to delete if the collection does not exist.
+	if status == http.StatusOK || status == http.StatusNotFound {
+		return nil
+	}
+
+	return newAPIError("deleting points", body)
+}
+
+type searchPayload struct {
+	Vector         []float64 `json:"vector"`
+	Limit          int       `json:"limit"`
+	WithPayload    bool      `json:"with_payload"`
+	Filter         any       `json:"filter,omitempty"`
+	ScoreThreshold *float64  `json:"score_threshold,omitempty"`
+}
+
+type scoredPoint struct {
+	ID      any            `json:"id"`
+	Score   float64        `json:"score"`
+	Payload map[string]any `json:"payload"`
+}
+
+type searchResponse struct {
+	Result []scoredPoint `json:"result"`
+	Status any           `json:"status"`
+}
+
+func (s Store) searchPoints(
+	ctx context.Context,
+	collection string,
+	vector []float64,
+	numDocuments int,
+	scoreThreshold float64,
+	filter any,
+) ([]schema.Document, error) {
+	payload := searchPayload{
+		Vector:      vector,
+		Limit:       numDocuments,
+		WithPayload: true,
+		Filter:      filter,
+	}
+	if scoreThreshold != 0 {
+		payload.ScoreThreshold = &scoreThreshold
+	}
+
+	body, status, err := s.doRequest(
+		ctx,
+		payload,
+		s.endpoint("collections", collection, "points", "search"),
+		http.MethodPost,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if status != http.StatusOK {
+		return nil, newAPIError("searching points", body)
+	}
+
+	var response searchResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Result == nil {
+		return nil, ErrEmptyResponse
+	}
+
+	docs := make([]schema.Document, 0, len(response.Result))
+	for _, p := range response.Result {
+		pageContent, ok := p.Payload[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(p.Payload, s.textKey)
+
+		docs = append(docs, schema.Document{
+			PageContent: pageContent,
+			Metadata:    p.Payload,
+		})
+	}
+
+	return docs, nil
+}
+
+// pointID returns the id of a point as qdrant expects it: unsigned integers
+// are JSON numbers and other ids, which must be UUIDs, JSON strings.
+func pointID(id string) any {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n
+	}
+	return id
+}
+
+// endpoint returns the URL of the path of the elements, escaping every element
+// so collection names can't change the path.
+func (s Store) endpoint(elems ...string) string {
+	escaped := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		escaped = append(escaped, url.PathEscape(elem))
+	}
+	u := *s.baseURL
+	base := strings.TrimSuffix(u.EscapedPath(), "/")
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.Join(elems, "/")
+	u.RawPath = base + "/" + strings.Join(escaped, "/")
+	return u.String()
+}
+
+func (s Store) doRequest(ctx context.Context, payload any, url, method string) (io.ReadCloser, int, error) {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("api-key", s.apiKey)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.Body, r.StatusCode, nil
+}
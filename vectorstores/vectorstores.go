@@ -2,11 +2,16 @@ package vectorstores
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/schema"
 )
 
+// ErrMismatchIDsAndDocuments is returned by AddDocuments when the number of
+// ids set with WithIDs does not match the number of documents.
+var ErrMismatchIDsAndDocuments = errors.New("number of ids and documents does not match")
+
 // VectorStore is the interface for saving and querying documents in the
 // form of vector embeddings.
 type VectorStore interface {
@@ -14,6 +19,12 @@ type VectorStore interface {
 	SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) //nolint:lll
 }
 
+// Deleter is implemented by vector stores that can delete documents by the ids
+// they were added with, see WithIDs.
+type Deleter interface {
+	Delete(ctx context.Context, ids []string, options ...Option) error
+}
+
 // Retriever is a retriever for vector stores.
 type Retriever struct {
 	CallbacksHandler callbacks.Handler
@@ -14,6 +14,7 @@ import (
 	"github.com/tmc/langchaingo/vectorstores"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
@@ -60,7 +61,10 @@ type Store struct {
 	queryAttrs []string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.Deleter     = Store{}
+)
 
 // New creates a new Store with options.
 // When using weaviate,
@@ -85,10 +89,17 @@ func New(opts ...Option) (Store, error) {
 	return s, nil
 }
 
+// AddDocuments creates vector embeddings from the documents using the embedder
+// and adds the objects to the index. Ids set with vectorstores.WithIDs must be
+// UUIDs, objects with existing ids are replaced.
 func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
 	opts := s.getOptions(options...)
 	nameSpace := s.getNameSpace(opts)
 
+	if opts.IDs != nil && len(opts.IDs) != len(docs) {
+		return vectorstores.ErrMismatchIDsAndDocuments
+	}
+
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -117,9 +128,13 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 
 	objects := make([]*models.Object, 0, len(docs))
 	for i := range docs {
+		id := uuid.New().String()
+		if opts.IDs != nil {
+			id = opts.IDs[i]
+		}
 		objects = append(objects, &models.Object{
 			Class:      s.indexName,
-			ID:         strfmt.UUID(uuid.New().String()),
+			ID:         strfmt.UUID(id),
 			Vector:     convertVector(vectors[i]),
 			Properties: metadatas[i],
 		})
@@ -130,6 +145,25 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 	return nil
 }
 
+// Delete deletes the objects with the ids from the index. Missing objects are ignored.
+func (s Store) Delete(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	for _, id := range ids {
+		err := s.client.Data().Deleter().WithClassName(s.indexName).WithID(id).Do(ctx)
+		var clientErr *fault.WeaviateClientError
+		if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SimilaritySearch creates a vector embedding from the query using the embedder
+// and queries to find the most similar documents. With the hybrid search option
+// the query is also matched by keywords and the results are fused by weaviate.
+// The score threshold is only applied to pure vector searches.
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
@@ -153,17 +187,28 @@ func (s Store) SimilaritySearch(
 		return nil, err
 	}
 
-	res, err := s.client.GraphQL().
+	get := s.client.GraphQL().
 		Get().
-		WithNearVector(s.client.GraphQL().
+		WithWhere(whereBuilder).
+		WithClassName(s.indexName).
+		WithLimit(numDocuments)
+
+	if opts.HybridSearch != nil {
+		get = get.WithHybrid(s.client.GraphQL().
+			HybridArgumentBuilder().
+			WithQuery(query).
+			WithVector(convertVector(vector)).
+			WithAlpha(float32(opts.HybridSearch.Alpha)),
+		).WithFields(s.createFields("score")...)
+	} else {
+		get = get.WithNearVector(s.client.GraphQL().
 			NearVectorArgBuilder().
 			WithVector(convertVector(vector)).
 			WithCertainty(scoreThreshold),
-		).
-		WithWhere(whereBuilder).
-		WithClassName(s.indexName).
-		WithLimit(numDocuments).
-		WithFields(s.createFields()...).Do(ctx)
+		).WithFields(s.createFields("certainty")...)
+	}
+
+	res, err := get.Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -252,18 +297,20 @@ func (s Store) createWhereBuilder(namespace string, filter any) (*filters.WhereB
 	}), nil
 }
 
-func (s Store) createFields() []graphql.Field {
+func (s Store) createFields(additional ...string) []graphql.Field {
 	fields := make([]graphql.Field, 0, len(s.queryAttrs))
 	for _, attr := range s.queryAttrs {
 		fields = append(fields, graphql.Field{
 			Name: attr,
 		})
 	}
+	additionalFields := make([]graphql.Field, 0, len(additional))
+	for _, name := range additional {
+		additionalFields = append(additionalFields, graphql.Field{Name: name})
+	}
 	fields = append(fields, graphql.Field{
-		Name: "_additional",
-		Fields: []graphql.Field{
-			{Name: "certainty"},
-		},
+		Name:   "_additional",
+		Fields: additionalFields,
 	})
 	return fields
 }
@@ -97,6 +97,39 @@ func TestWeaviateStoreRest(t *testing.T) {
 	require.Equal(t, docs[0].Metadata["country"], "japan")
 }
 
+func TestWeaviateStoreHybridSearch(t *testing.T) {
+	t.Parallel()
+
+	scheme, host := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	store, err := New(
+		WithScheme(scheme),
+		WithHost(host),
+		WithEmbedder(e),
+		WithNameSpace(uuid.New().String()),
+		WithIndexName(randomizedCamelCaseClass()),
+	)
+	require.NoError(t, err)
+
+	err = createTestClass(context.Background(), store)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "The product code of the blue kettle is KT-2041."},
+		{PageContent: "The product code of the red kettle is KT-3310."},
+		{PageContent: "Tokyo is the capital of Japan."},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.SimilaritySearch(context.Background(), "KT-3310", 1,
+		vectorstores.WithHybridSearch(0.25))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Contains(t, docs[0].PageContent, "KT-3310")
+}
+
 func TestWeaviateStoreRestWithScoreThreshold(t *testing.T) {
 	t.Parallel()
 
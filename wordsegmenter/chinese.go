@@ -0,0 +1,275 @@
+package wordsegmenter
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrInvalidDictionary is returned when a dictionary line can't be parsed.
+var ErrInvalidDictionary = errors.New("invalid dictionary")
+
+//go:embed dict.txt
+var _defaultDictionary []byte
+
+const _defaultUserWordFreq = 1000
+
+// Chinese is a jieba-style segmenter. Runs of Han characters are cut by building a
+// directed acyclic graph of all dictionary words in the run and taking the route
+// with the maximum probability, where the probability of a word is its frequency
+// in the dictionary divided by the total frequency. Runs of other letters and
+// digits, like English words or product codes, are kept whole and lowercased.
+//
+// A Chinese segmenter is safe for concurrent use.
+type Chinese struct {
+	mu         sync.RWMutex
+	freqs      map[string]int
+	total      int
+	searchMode bool
+}
+
+var _ Segmenter = &Chinese{}
+
+// ChineseOption is a function type that can be used to modify the segmenter.
+type ChineseOption func(*chineseOptions)
+
+type chineseOptions struct {
+	dictionaries  []io.Reader
+	noDefaultDict bool
+	userWords     []string
+	searchMode    bool
+}
+
+// WithDictionary is an option for loading additional words from a dictionary in
+// the jieba format. Every line holds a word, its frequency and an optional part
+// of speech tag separated by spaces, e.g. "人工智能 600 n". The frequency may be
+// omitted, in which case a default frequency is used.
+func WithDictionary(r io.Reader) ChineseOption {
+	return func(o *chineseOptions) {
+		o.dictionaries = append(o.dictionaries, r)
+	}
+}
+
+// WithoutDefaultDictionary is an option for not loading the small built in
+// dictionary. Use it together with WithDictionary to load a full jieba dictionary.
+func WithoutDefaultDictionary() ChineseOption {
+	return func(o *chineseOptions) {
+		o.noDefaultDict = true
+	}
+}
+
+// WithUserWords is an option for adding words, like product names or internal
+// terms, that must not be cut.
+func WithUserWords(words ...string) ChineseOption {
+	return func(o *chineseOptions) {
+		o.userWords = append(o.userWords, words...)
+	}
+}
+
+// WithSearchMode is an option for also emitting the shorter dictionary words
+// contained in long words, e.g. "人工智能" also yields "人工" and "智能". This
+// improves recall for lexical retrieval.
+func WithSearchMode() ChineseOption {
+	return func(o *chineseOptions) {
+		o.searchMode = true
+	}
+}
+
+// NewChinese creates a new jieba-style segmenter. By default a small built in
+// dictionary of common words is loaded.
+func NewChinese(opts ...ChineseOption) (*Chinese, error) {
+	o := &chineseOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := &Chinese{
+		freqs:      make(map[string]int),
+		searchMode: o.searchMode,
+	}
+
+	if !o.noDefaultDict {
+		if err := c.loadDictionary(bytes.NewReader(_defaultDictionary)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, r := range o.dictionaries {
+		if err := c.loadDictionary(r); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, w := range o.userWords {
+		c.addWord(w, _defaultUserWordFreq)
+	}
+
+	return c, nil
+}
+
+// AddWord adds a word with a frequency to the dictionary. If freq is not positive
+// a default frequency is used.
+func (c *Chinese) AddWord(word string, freq int) {
+	if freq <= 0 {
+		freq = _defaultUserWordFreq
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addWord(word, freq)
+}
+
+// Segment splits the text into words.
+func (c *Chinese) Segment(text string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	words := make([]string, 0)
+	for _, b := range splitBlocks(text) {
+		if !b.han {
+			words = append(words, b.text)
+			continue
+		}
+		for _, w := range c.cut([]rune(b.text)) {
+			if c.searchMode {
+				words = append(words, c.subWords(w)...)
+			}
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func (c *Chinese) loadDictionary(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		freq := _defaultUserWordFreq
+		if len(fields) > 1 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return fmt.Errorf("%w: line %d: %s", ErrInvalidDictionary, line, err.Error())
+			}
+			freq = n
+		}
+
+		c.addWord(fields[0], freq)
+	}
+
+	return scanner.Err()
+}
+
+// addWord stores the word and all its prefixes. Prefixes that are not words
+// themselves get a frequency of zero, so the graph construction can stop early.
+func (c *Chinese) addWord(word string, freq int) {
+	if old, ok := c.freqs[word]; ok {
+		c.total -= old
+	}
+	c.freqs[word] = freq
+	c.total += freq
+
+	runes := []rune(word)
+	for i := 1; i < len(runes); i++ {
+		prefix := string(runes[:i])
+		if _, ok := c.freqs[prefix]; !ok {
+			c.freqs[prefix] = 0
+		}
+	}
+}
+
+// dag returns for every position in the runes the end positions of all
+// dictionary words starting at it.
+func (c *Chinese) dag(runes []rune) [][]int {
+	dag := make([][]int, len(runes))
+	for k := range runes {
+		ends := make([]int, 0)
+		for i := k; i < len(runes); i++ {
+			freq, ok := c.freqs[string(runes[k:i+1])]
+			if !ok {
+				break
+			}
+			if freq > 0 {
+				ends = append(ends, i)
+			}
+		}
+		if len(ends) == 0 {
+			ends = append(ends, k)
+		}
+		dag[k] = ends
+	}
+	return dag
+}
+
+// cut returns the words on the maximum probability route through the graph.
+func (c *Chinese) cut(runes []rune) []string {
+	n := len(runes)
+	dag := c.dag(runes)
+	logTotal := math.Log(float64(maxInt(c.total, 1)))
+
+	// route[i] holds the best log probability of runes[i:] and the end of the
+	// first word on that route.
+	type step struct {
+		logProb float64
+		end     int
+	}
+	route := make([]step, n+1)
+	for i := n - 1; i >= 0; i-- {
+		best := step{logProb: math.Inf(-1)}
+		for _, end := range dag[i] {
+			freq := c.freqs[string(runes[i:end+1])]
+			logProb := math.Log(float64(maxInt(freq, 1))) - logTotal + route[end+1].logProb
+			if logProb > best.logProb {
+				best = step{logProb: logProb, end: end}
+			}
+		}
+		route[i] = best
+	}
+
+	words := make([]string, 0)
+	for i := 0; i < n; {
+		end := route[i].end
+		words = append(words, string(runes[i:end+1]))
+		i = end + 1
+	}
+	return words
+}
+
+// subWords returns the two and three character dictionary words contained in
+// a longer word.
+func (c *Chinese) subWords(word string) []string {
+	runes := []rune(word)
+	words := make([]string, 0)
+	for _, size := range []int{2, 3} {
+		if utf8.RuneCountInString(word) <= size {
+			continue
+		}
+		for i := 0; i+size <= len(runes); i++ {
+			sub := string(runes[i : i+size])
+			if c.freqs[sub] > 0 {
+				words = append(words, sub)
+			}
+		}
+	}
+	return words
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,15 @@
+/*
+Package wordsegmenter contains tokenizers that split text into words for
+lexical retrieval and sparse embeddings.
+
+The main components of this package are:
+
+- Segmenter interface: a common interface for splitting a text into words.
+- Simple: a segmenter that lowercases text and splits it on anything that is
+not a letter or a digit. Han characters are emitted one by one.
+- Chinese: a jieba-style segmenter that cuts runs of Han characters using a
+prefix dictionary and the maximum probability route through the word graph.
+- StopWords: a segmenter that removes stop words from the output of another
+segmenter, with built in English and Chinese stop word lists.
+*/
+package wordsegmenter
@@ -0,0 +1,60 @@
+package wordsegmenter
+
+import "strings"
+
+// EnglishStopWords is a list of common English stop words.
+var EnglishStopWords = []string{ //nolint:gochecknoglobals
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in",
+	"into", "is", "it", "no", "not", "of", "on", "or", "such", "that", "the",
+	"their", "then", "there", "these", "they", "this", "to", "was", "will",
+	"with", "what", "which", "who", "how", "i", "you", "he", "she", "we", "do",
+	"does", "did", "have", "has", "had", "from", "were", "been", "so", "can",
+}
+
+// ChineseStopWords is a list of common Chinese stop words.
+var ChineseStopWords = []string{ //nolint:gochecknoglobals
+	"的", "了", "和", "是", "就", "都", "而", "及", "与", "着", "或", "一个",
+	"没有", "我们", "你们", "他们", "它们", "我", "你", "他", "她", "它", "在",
+	"也", "有", "这", "那", "之", "以", "为", "于", "上", "下", "中", "对",
+	"把", "被", "从", "到", "等", "地", "得", "吗", "呢", "吧", "啊", "呀",
+	"哪", "什么", "怎么", "如何", "哪些", "这个", "那个", "这些", "那些", "请",
+	"并", "但", "还", "又", "很", "所", "其", "该", "如果", "因为", "所以",
+}
+
+// StopWords is a segmenter that removes stop words from the words returned by
+// another segmenter. Stop words are compared case insensitively.
+type StopWords struct {
+	segmenter Segmenter
+	stopWords map[string]struct{}
+}
+
+var _ Segmenter = StopWords{}
+
+// NewStopWords creates a new segmenter that removes the given stop words from
+// the output of the segmenter.
+func NewStopWords(segmenter Segmenter, stopWords ...[]string) StopWords {
+	set := make(map[string]struct{})
+	for _, list := range stopWords {
+		for _, w := range list {
+			set[strings.ToLower(w)] = struct{}{}
+		}
+	}
+
+	return StopWords{
+		segmenter: segmenter,
+		stopWords: set,
+	}
+}
+
+// Segment splits the text into words and removes the stop words.
+func (s StopWords) Segment(text string) []string {
+	words := s.segmenter.Segment(text)
+	filtered := make([]string, 0, len(words))
+	for _, w := range words {
+		if _, ok := s.stopWords[strings.ToLower(w)]; ok {
+			continue
+		}
+		filtered = append(filtered, w)
+	}
+	return filtered
+}
@@ -0,0 +1,87 @@
+package wordsegmenter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segmenter is the interface for splitting a text into words.
+type Segmenter interface {
+	// Segment returns the words of the text in order of appearance.
+	Segment(text string) []string
+}
+
+// Simple is a segmenter that lowercases text and splits it on anything that is
+// not a letter or a digit. Han characters are emitted as single character words.
+type Simple struct{}
+
+var _ Segmenter = Simple{}
+
+// NewSimple creates a new simple segmenter.
+func NewSimple() Simple {
+	return Simple{}
+}
+
+// Segment splits the text into lowercased words.
+func (Simple) Segment(text string) []string {
+	words := make([]string, 0)
+	for _, block := range splitBlocks(text) {
+		if !block.han {
+			words = append(words, block.text)
+			continue
+		}
+		for _, r := range block.text {
+			words = append(words, string(r))
+		}
+	}
+	return words
+}
+
+// block is a run of either Han characters or other letters and digits.
+type block struct {
+	text string
+	han  bool
+}
+
+// splitBlocks splits a text into runs of Han characters and runs of other
+// letters and digits. Everything else, like spaces and punctuation, separates
+// blocks and is dropped. Non Han blocks are lowercased.
+func splitBlocks(text string) []block {
+	blocks := make([]block, 0)
+	var current strings.Builder
+	currentHan := false
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		s := current.String()
+		if !currentHan {
+			s = strings.ToLower(s)
+		}
+		blocks = append(blocks, block{text: s, han: currentHan})
+		current.Reset()
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if !currentHan {
+				flush()
+				currentHan = true
+			}
+			current.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if currentHan {
+				flush()
+				currentHan = false
+			}
+			current.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return blocks
+}
@@ -0,0 +1,80 @@
+package wordsegmenter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimple(t *testing.T) {
+	t.Parallel()
+
+	words := NewSimple().Segment("Hello, World! X200-Pro 你好")
+	assert.Equal(t, []string{"hello", "world", "x200", "pro", "你", "好"}, words)
+}
+
+func TestChinese(t *testing.T) {
+	t.Parallel()
+
+	seg, err := NewChinese()
+	require.NoError(t, err)
+
+	type testCase struct {
+		text     string
+		expected []string
+	}
+	testCases := []testCase{
+		{
+			text:     "2023年以后的人事制度文件",
+			expected: []string{"2023", "年", "以后", "的", "人事", "制度", "文件"},
+		},
+		{
+			text:     "我们公司的产品型号是X200，价格很好。",
+			expected: []string{"我们", "公司", "的", "产品", "型号", "是", "x200", "价格", "很", "好"},
+		},
+		{
+			text:     "人工智能和机器学习",
+			expected: []string{"人工智能", "和", "机器学习"},
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, seg.Segment(tc.text), tc.text)
+	}
+}
+
+func TestChineseUserWords(t *testing.T) {
+	t.Parallel()
+
+	seg, err := NewChinese(
+		WithDictionary(strings.NewReader("星火大模型 10 nz\n")),
+		WithUserWords("盘古"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"星火大模型", "和", "盘古"}, seg.Segment("星火大模型和盘古"))
+
+	seg.AddWord("文心一言", 0)
+	assert.Equal(t, []string{"文心一言"}, seg.Segment("文心一言"))
+
+	_, err = NewChinese(WithDictionary(strings.NewReader("词 abc\n")))
+	require.ErrorIs(t, err, ErrInvalidDictionary)
+}
+
+func TestChineseSearchMode(t *testing.T) {
+	t.Parallel()
+
+	seg, err := NewChinese(WithUserWords("人工", "智能"), WithSearchMode())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"人工", "智能", "人工智能"}, seg.Segment("人工智能"))
+}
+
+func TestStopWords(t *testing.T) {
+	t.Parallel()
+
+	seg, err := NewChinese()
+	require.NoError(t, err)
+
+	words := NewStopWords(seg, ChineseStopWords, EnglishStopWords).Segment("什么是The公司的制度")
+	assert.Equal(t, []string{"公司", "制度"}, words)
+}